@@ -0,0 +1,325 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             (unknown)
+// source: api/grpc/cloudinfo.proto
+
+package cloudinfov1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// CloudInfoClient is the client API for CloudInfo service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type CloudInfoClient interface {
+	// GetProviders returns the providers having cached service information.
+	GetProviders(ctx context.Context, in *GetProvidersRequest, opts ...grpc.CallOption) (*GetProvidersResponse, error)
+	// GetServices returns the services of a provider.
+	GetServices(ctx context.Context, in *GetServicesRequest, opts ...grpc.CallOption) (*GetServicesResponse, error)
+	// GetRegions returns the regions of a service keyed by region ID.
+	GetRegions(ctx context.Context, in *GetRegionsRequest, opts ...grpc.CallOption) (*GetRegionsResponse, error)
+	// GetZones returns the availability zones of a region.
+	GetZones(ctx context.Context, in *GetZonesRequest, opts ...grpc.CallOption) (*GetZonesResponse, error)
+	// GetProductDetails returns the products of a region along with their prices.
+	GetProductDetails(ctx context.Context, in *GetProductDetailsRequest, opts ...grpc.CallOption) (*GetProductDetailsResponse, error)
+	// StreamProductDetails streams the products of a region one by one, for regions with large catalogs.
+	StreamProductDetails(ctx context.Context, in *GetProductDetailsRequest, opts ...grpc.CallOption) (CloudInfo_StreamProductDetailsClient, error)
+}
+
+type cloudInfoClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCloudInfoClient(cc grpc.ClientConnInterface) CloudInfoClient {
+	return &cloudInfoClient{cc}
+}
+
+func (c *cloudInfoClient) GetProviders(ctx context.Context, in *GetProvidersRequest, opts ...grpc.CallOption) (*GetProvidersResponse, error) {
+	out := new(GetProvidersResponse)
+	err := c.cc.Invoke(ctx, "/banzaicloud.cloudinfo.v1.CloudInfo/GetProviders", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cloudInfoClient) GetServices(ctx context.Context, in *GetServicesRequest, opts ...grpc.CallOption) (*GetServicesResponse, error) {
+	out := new(GetServicesResponse)
+	err := c.cc.Invoke(ctx, "/banzaicloud.cloudinfo.v1.CloudInfo/GetServices", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cloudInfoClient) GetRegions(ctx context.Context, in *GetRegionsRequest, opts ...grpc.CallOption) (*GetRegionsResponse, error) {
+	out := new(GetRegionsResponse)
+	err := c.cc.Invoke(ctx, "/banzaicloud.cloudinfo.v1.CloudInfo/GetRegions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cloudInfoClient) GetZones(ctx context.Context, in *GetZonesRequest, opts ...grpc.CallOption) (*GetZonesResponse, error) {
+	out := new(GetZonesResponse)
+	err := c.cc.Invoke(ctx, "/banzaicloud.cloudinfo.v1.CloudInfo/GetZones", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cloudInfoClient) GetProductDetails(ctx context.Context, in *GetProductDetailsRequest, opts ...grpc.CallOption) (*GetProductDetailsResponse, error) {
+	out := new(GetProductDetailsResponse)
+	err := c.cc.Invoke(ctx, "/banzaicloud.cloudinfo.v1.CloudInfo/GetProductDetails", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cloudInfoClient) StreamProductDetails(ctx context.Context, in *GetProductDetailsRequest, opts ...grpc.CallOption) (CloudInfo_StreamProductDetailsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &CloudInfo_ServiceDesc.Streams[0], "/banzaicloud.cloudinfo.v1.CloudInfo/StreamProductDetails", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &cloudInfoStreamProductDetailsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type CloudInfo_StreamProductDetailsClient interface {
+	Recv() (*ProductDetails, error)
+	grpc.ClientStream
+}
+
+type cloudInfoStreamProductDetailsClient struct {
+	grpc.ClientStream
+}
+
+func (x *cloudInfoStreamProductDetailsClient) Recv() (*ProductDetails, error) {
+	m := new(ProductDetails)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// CloudInfoServer is the server API for CloudInfo service.
+// All implementations must embed UnimplementedCloudInfoServer
+// for forward compatibility
+type CloudInfoServer interface {
+	// GetProviders returns the providers having cached service information.
+	GetProviders(context.Context, *GetProvidersRequest) (*GetProvidersResponse, error)
+	// GetServices returns the services of a provider.
+	GetServices(context.Context, *GetServicesRequest) (*GetServicesResponse, error)
+	// GetRegions returns the regions of a service keyed by region ID.
+	GetRegions(context.Context, *GetRegionsRequest) (*GetRegionsResponse, error)
+	// GetZones returns the availability zones of a region.
+	GetZones(context.Context, *GetZonesRequest) (*GetZonesResponse, error)
+	// GetProductDetails returns the products of a region along with their prices.
+	GetProductDetails(context.Context, *GetProductDetailsRequest) (*GetProductDetailsResponse, error)
+	// StreamProductDetails streams the products of a region one by one, for regions with large catalogs.
+	StreamProductDetails(*GetProductDetailsRequest, CloudInfo_StreamProductDetailsServer) error
+	mustEmbedUnimplementedCloudInfoServer()
+}
+
+// UnimplementedCloudInfoServer must be embedded to have forward compatible implementations.
+type UnimplementedCloudInfoServer struct {
+}
+
+func (UnimplementedCloudInfoServer) GetProviders(context.Context, *GetProvidersRequest) (*GetProvidersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProviders not implemented")
+}
+func (UnimplementedCloudInfoServer) GetServices(context.Context, *GetServicesRequest) (*GetServicesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetServices not implemented")
+}
+func (UnimplementedCloudInfoServer) GetRegions(context.Context, *GetRegionsRequest) (*GetRegionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRegions not implemented")
+}
+func (UnimplementedCloudInfoServer) GetZones(context.Context, *GetZonesRequest) (*GetZonesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetZones not implemented")
+}
+func (UnimplementedCloudInfoServer) GetProductDetails(context.Context, *GetProductDetailsRequest) (*GetProductDetailsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProductDetails not implemented")
+}
+func (UnimplementedCloudInfoServer) StreamProductDetails(*GetProductDetailsRequest, CloudInfo_StreamProductDetailsServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamProductDetails not implemented")
+}
+func (UnimplementedCloudInfoServer) mustEmbedUnimplementedCloudInfoServer() {}
+
+// UnsafeCloudInfoServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CloudInfoServer will
+// result in compilation errors.
+type UnsafeCloudInfoServer interface {
+	mustEmbedUnimplementedCloudInfoServer()
+}
+
+func RegisterCloudInfoServer(s grpc.ServiceRegistrar, srv CloudInfoServer) {
+	s.RegisterService(&CloudInfo_ServiceDesc, srv)
+}
+
+func _CloudInfo_GetProviders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProvidersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CloudInfoServer).GetProviders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/banzaicloud.cloudinfo.v1.CloudInfo/GetProviders",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CloudInfoServer).GetProviders(ctx, req.(*GetProvidersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CloudInfo_GetServices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetServicesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CloudInfoServer).GetServices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/banzaicloud.cloudinfo.v1.CloudInfo/GetServices",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CloudInfoServer).GetServices(ctx, req.(*GetServicesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CloudInfo_GetRegions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRegionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CloudInfoServer).GetRegions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/banzaicloud.cloudinfo.v1.CloudInfo/GetRegions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CloudInfoServer).GetRegions(ctx, req.(*GetRegionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CloudInfo_GetZones_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetZonesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CloudInfoServer).GetZones(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/banzaicloud.cloudinfo.v1.CloudInfo/GetZones",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CloudInfoServer).GetZones(ctx, req.(*GetZonesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CloudInfo_GetProductDetails_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProductDetailsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CloudInfoServer).GetProductDetails(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/banzaicloud.cloudinfo.v1.CloudInfo/GetProductDetails",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CloudInfoServer).GetProductDetails(ctx, req.(*GetProductDetailsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CloudInfo_StreamProductDetails_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetProductDetailsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CloudInfoServer).StreamProductDetails(m, &cloudInfoStreamProductDetailsServer{stream})
+}
+
+type CloudInfo_StreamProductDetailsServer interface {
+	Send(*ProductDetails) error
+	grpc.ServerStream
+}
+
+type cloudInfoStreamProductDetailsServer struct {
+	grpc.ServerStream
+}
+
+func (x *cloudInfoStreamProductDetailsServer) Send(m *ProductDetails) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// CloudInfo_ServiceDesc is the grpc.ServiceDesc for CloudInfo service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CloudInfo_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "banzaicloud.cloudinfo.v1.CloudInfo",
+	HandlerType: (*CloudInfoServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetProviders",
+			Handler:    _CloudInfo_GetProviders_Handler,
+		},
+		{
+			MethodName: "GetServices",
+			Handler:    _CloudInfo_GetServices_Handler,
+		},
+		{
+			MethodName: "GetRegions",
+			Handler:    _CloudInfo_GetRegions_Handler,
+		},
+		{
+			MethodName: "GetZones",
+			Handler:    _CloudInfo_GetZones_Handler,
+		},
+		{
+			MethodName: "GetProductDetails",
+			Handler:    _CloudInfo_GetProductDetails_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamProductDetails",
+			Handler:       _CloudInfo_StreamProductDetails_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "api/grpc/cloudinfo.proto",
+}
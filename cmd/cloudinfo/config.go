@@ -117,6 +117,12 @@ type configuration struct {
 		Address string
 
 		BasePath string
+
+		// Age after which served data is flagged as stale (zero disables the check)
+		StaleThreshold time.Duration
+
+		// Age after which product details are not served anymore (zero disables the check)
+		MaxAge time.Duration
 	}
 
 	// Scrape configuration
@@ -125,6 +131,48 @@ type configuration struct {
 
 		// Cloud info scrape interval
 		Interval time.Duration
+
+		// Provider specific scrape intervals overriding the default one
+		ProviderIntervals map[string]time.Duration
+
+		// Provider specific regions to scrape, every region is scraped for the providers not listed
+		ProviderRegions map[string][]string
+
+		// Short lived (spot) price scrape interval
+		ShortLivedInterval time.Duration
+
+		// Number of regions scraped in parallel per provider (zero means no limit)
+		RegionConcurrency int
+
+		// Number of providers scraped in parallel (zero means no limit)
+		ProviderConcurrency int
+
+		// Number of attempts for transiently failing provider calls (one disables retries)
+		RetryMaxAttempts int
+
+		// Delay before the first retry, doubled for each subsequent attempt
+		RetryBaseDelay time.Duration
+
+		// Time a provider call is given to complete (zero means no limit)
+		CallTimeout time.Duration
+
+		// Weight of the latest spot price in the smoothed spot price (zero disables smoothing)
+		SpotSmoothingFactor float64
+
+		// Number of consecutive price scrape failures pausing the price scrapes of a provider (zero disables pausing)
+		BreakerThreshold int
+
+		// Time the price scrapes of a provider are paused for before testing whether they succeed again
+		BreakerCooldown time.Duration
+
+		// Number of provider calls per second allowed per provider (zero means no limit)
+		RequestRate float64
+
+		// File the scraped prices are appended to as JSON lines (empty disables the price audit log)
+		AuditLogPath string
+
+		// Maximum fraction of the interval the periodic scrapes of the providers are randomly offset by (zero disables jitter)
+		Jitter float64
 	}
 
 	// Provider configuration
@@ -173,6 +221,14 @@ type configuration struct {
 
 	Management management.Config
 
+	// gRPC configuration
+	GRPC struct {
+		Enabled bool
+
+		// gRPC server address
+		Address string
+	}
+
 	ServiceLoader loader.Config
 
 	Store cistore.Config
@@ -258,6 +314,8 @@ func configure(v *viper.Viper, p *pflag.FlagSet) {
 	_ = v.BindPFlag("app.address", p.Lookup("listen-address"))
 
 	v.SetDefault("app.basePath", "/")
+	v.SetDefault("app.staleThreshold", 0)
+	v.SetDefault("app.maxAge", 0)
 
 	// Scrape configuration
 	p.Bool("scrape", true, "enable cloud info scraping")
@@ -266,6 +324,19 @@ func configure(v *viper.Viper, p *pflag.FlagSet) {
 	p.Duration("scrape-interval", 24*time.Hour, "duration (in go syntax) between renewing information")
 	_ = v.BindPFlag("scrape.interval", p.Lookup("scrape-interval"))
 
+	v.SetDefault("scrape.shortLivedInterval", 4*time.Minute)
+	v.SetDefault("scrape.regionConcurrency", 0)
+	v.SetDefault("scrape.providerConcurrency", 0)
+	v.SetDefault("scrape.retryMaxAttempts", 1)
+	v.SetDefault("scrape.retryBaseDelay", time.Second)
+	v.SetDefault("scrape.callTimeout", 0)
+	v.SetDefault("scrape.spotSmoothingFactor", 0)
+	v.SetDefault("scrape.breakerThreshold", 0)
+	v.SetDefault("scrape.breakerCooldown", 20*time.Minute)
+	v.SetDefault("scrape.requestRate", 0)
+	v.SetDefault("scrape.auditLogPath", "")
+	v.SetDefault("scrape.jitter", 0)
+
 	// Amazon config
 	p.Bool("provider-amazon", false, "enable amazon provider")
 	_ = v.BindPFlag("provider.amazon.enabled", p.Lookup("provider-amazon"))
@@ -341,6 +412,10 @@ func configure(v *viper.Viper, p *pflag.FlagSet) {
 	v.SetDefault("management.enabled", true)
 	v.SetDefault("management.address", ":8001")
 
+	// gRPC
+	v.SetDefault("grpc.enabled", false)
+	v.SetDefault("grpc.address", ":9090")
+
 	// ServiceLoader
 	v.SetDefault("serviceloader.serviceConfigLocation", "./configs")
 	v.SetDefault("serviceloader.serviceConfigName", "services")
@@ -351,6 +426,7 @@ func configure(v *viper.Viper, p *pflag.FlagSet) {
 	v.SetDefault("store.redis.enabled", false)
 	v.SetDefault("store.redis.host", "localhost")
 	v.SetDefault("store.redis.port", 6379)
+	v.SetDefault("store.redis.expiration", 0)
 
 	// Cassandra product store
 	v.SetDefault("store.cassandra.enabled", false)
@@ -362,4 +438,6 @@ func configure(v *viper.Viper, p *pflag.FlagSet) {
 	// InMemory product store
 	v.SetDefault("store.gocache.expiration", 0)
 	v.SetDefault("store.gocache.cleanupInterval", 0)
+	v.SetDefault("store.gocache.persistencePath", "")
+	v.SetDefault("store.gocache.compressionThreshold", 0)
 }
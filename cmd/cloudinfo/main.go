@@ -27,9 +27,15 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"net"
+	"net/http"
 	"net/url"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"emperror.dev/emperror"
 	"emperror.dev/errors"
@@ -38,6 +44,7 @@ import (
 	_ "github.com/sagikazarmark/viperx/remote/bankvaults"
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
+	"google.golang.org/grpc"
 
 	"github.com/banzaicloud/cloudinfo/internal/app/cloudinfo/api"
 	"github.com/banzaicloud/cloudinfo/internal/app/cloudinfo/cistore"
@@ -48,6 +55,7 @@ import (
 	"github.com/banzaicloud/cloudinfo/internal/cloudinfo"
 	"github.com/banzaicloud/cloudinfo/internal/cloudinfo/cloudinfoadapter"
 	"github.com/banzaicloud/cloudinfo/internal/cloudinfo/cloudinfodriver"
+	"github.com/banzaicloud/cloudinfo/internal/cloudinfo/cloudinfogrpc"
 	"github.com/banzaicloud/cloudinfo/internal/cloudinfo/metrics"
 	"github.com/banzaicloud/cloudinfo/internal/cloudinfo/providers/alibaba"
 	"github.com/banzaicloud/cloudinfo/internal/cloudinfo/providers/amazon"
@@ -170,6 +178,7 @@ func main() {
 	// use the configured store implementation
 	cloudInfoStore := cistore.NewCloudInfoStore(config.Store, cloudInfoLogger)
 	defer cloudInfoStore.Close()
+
 	if !cloudInfoStore.Ready() {
 		emperror.Panic(errors.New("configured product store not available"))
 	}
@@ -186,11 +195,43 @@ func main() {
 
 	serviceManager.LoadServiceInformation(providers)
 
-	prodInfo, err := cloudinfo.NewCloudInfo(providers, cloudInfoStore, cloudInfoLogger)
+	prodInfo, err := cloudinfo.NewCloudInfoWithOptions(providers, cloudInfoStore, cloudInfoLogger, cloudinfo.CloudInfoOptions{
+		StaleThreshold:            config.App.StaleThreshold,
+		MaxAge:                    config.App.MaxAge,
+		SpotPriceHistoryProviders: cloudinfo.SpotPriceHistoryProviders(infoers),
+		EventBus:                  eventBus,
+		Reporter:                  reporter,
+	})
 	emperror.Panic(err)
 
 	if config.Scrape.Enabled {
-		scrapingDriver := cloudinfo.NewScrapingDriver(config.Scrape.Interval, infoers, cloudInfoStore, eventBus, reporter, tracer, errorHandler, cloudInfoLogger)
+		auditSink := cloudinfo.NewNoOpAuditSink()
+		if config.Scrape.AuditLogPath != "" {
+			fileAuditSink, err := cloudinfo.NewFileAuditSink(config.Scrape.AuditLogPath, cloudInfoLogger)
+			emperror.Panic(err)
+			defer fileAuditSink.Close()
+
+			auditSink = fileAuditSink
+		}
+
+		scrapingDriver, err := cloudinfo.NewScrapingDriverWithOptions(config.Scrape.Interval, infoers, cloudInfoStore, eventBus, reporter, tracer, errorHandler, cloudInfoLogger,
+			cloudinfo.ScrapingOptions{
+				RenewalIntervals:    config.Scrape.ProviderIntervals,
+				ProviderRegions:     config.Scrape.ProviderRegions,
+				ShortLivedInterval:  config.Scrape.ShortLivedInterval,
+				RegionConcurrency:   config.Scrape.RegionConcurrency,
+				ProviderConcurrency: config.Scrape.ProviderConcurrency,
+				RetryMaxAttempts:    config.Scrape.RetryMaxAttempts,
+				RetryBaseDelay:      config.Scrape.RetryBaseDelay,
+				CallTimeout:         config.Scrape.CallTimeout,
+				RequestRate:         config.Scrape.RequestRate,
+				SpotSmoothingFactor: config.Scrape.SpotSmoothingFactor,
+				BreakerThreshold:    config.Scrape.BreakerThreshold,
+				BreakerCooldown:     config.Scrape.BreakerCooldown,
+				AuditSink:           auditSink,
+				Jitter:              config.Scrape.Jitter,
+			})
+		emperror.Panic(err)
 
 		err = scrapingDriver.StartScraping()
 		emperror.Panic(err)
@@ -236,7 +277,57 @@ func main() {
 
 	routeHandler.ConfigureRoutes(router, config.App.BasePath)
 
-	err = router.Run(config.App.Address)
+	server := &http.Server{Addr: config.App.Address, Handler: router}
+
+	// serve the cloud information over gRPC as well
+	var grpcServer *grpc.Server
+	if config.GRPC.Enabled {
+		listener, err := net.Listen("tcp", config.GRPC.Address)
+		emperror.Panic(errors.Wrap(err, "failed to listen on the gRPC address"))
+
+		grpcServer = grpc.NewServer()
+		cloudinfogrpc.NewServer(prodInfo, cloudInfoLogger).Register(grpcServer)
+
+		go func() {
+			logger.Info("serving gRPC", map[string]interface{}{"address": config.GRPC.Address})
+
+			if err := grpcServer.Serve(listener); err != nil {
+				logger.Error("failed to serve gRPC", map[string]interface{}{"error": err.Error()})
+			}
+		}()
+	}
+
+	// the persistent store saves its content when closed: on termination the servers are shut down gracefully
+	// and main returns, running the deferred calls
+	stopped := make(chan struct{})
+	if config.Store.Persistent() || grpcServer != nil {
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+
+		go func() {
+			defer close(stopped)
+
+			sig := <-signals
+			logger.Info("shutting down", map[string]interface{}{"signal": sig.String()})
+
+			if grpcServer != nil {
+				grpcServer.GracefulStop()
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			if err := server.Shutdown(ctx); err != nil {
+				logger.Error("failed to shut down the server gracefully", map[string]interface{}{"error": err.Error()})
+			}
+		}()
+	}
+
+	err = server.ListenAndServe()
+	if errors.Is(err, http.ErrServerClosed) {
+		<-stopped
+		return
+	}
 	emperror.Panic(errors.Wrap(err, "failed to run router"))
 }
 
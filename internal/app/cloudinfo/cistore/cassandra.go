@@ -18,6 +18,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"strings"
 	"sync"
 
 	"emperror.dev/emperror"
@@ -89,12 +90,49 @@ func (cps *cassandraProductStore) StorePrice(provider, region, instanceType stri
 	cps.set(cps.getKey(cloudinfo.PriceKeyTemplate, provider, region, instanceType), val)
 }
 
+// StorePrices stores the prices in a single batch
+func (cps *cassandraProductStore) StorePrices(provider, region string, prices map[string]types.Price) {
+	if err := cps.initSession(); err != nil {
+		cps.log.Error("failed to connect to backend")
+		return
+	}
+
+	ins := fmt.Sprintf("INSERT INTO %s.%s (key, value) VALUES (?, ?)", cps.keySpace, cps.tableName)
+	batch := cps.session.NewBatch(gocql.UnloggedBatch)
+	for instanceType, price := range prices {
+		key := cps.getKey(cloudinfo.PriceKeyTemplate, provider, region, instanceType)
+
+		mJson, err := json.Marshal(price)
+		if err != nil {
+			cps.log.Debug("failed to marshal value into json", map[string]interface{}{"key": key, "value": price})
+			continue
+		}
+
+		batch.Query(ins, key, mJson)
+	}
+
+	if err := cps.session.ExecuteBatch(batch); err != nil {
+		cps.log.Debug("failed to save prices", map[string]interface{}{"region": region})
+	}
+}
+
 func (cps *cassandraProductStore) GetPrice(provider, region, instanceType string) (types.Price, bool) {
 	var res types.Price
 	_, ok := cps.get(cps.getKey(cloudinfo.PriceKeyTemplate, provider, region, instanceType), &res)
 	return res, ok
 }
 
+func (cps *cassandraProductStore) StoreSpotPriceHistory(provider, region, instanceType string, val []types.TimestampedPrice) {
+	cps.set(cps.getKey(cloudinfo.SpotPriceHistoryKeyTemplate, provider, region, instanceType), val)
+}
+
+func (cps *cassandraProductStore) GetSpotPriceHistory(provider, region, instanceType string) ([]types.TimestampedPrice, bool) {
+	res := make([]types.TimestampedPrice, 0)
+	_, ok := cps.get(cps.getKey(cloudinfo.SpotPriceHistoryKeyTemplate, provider, region, instanceType), &res)
+
+	return res, ok
+}
+
 func (cps *cassandraProductStore) StoreVm(provider, service, region string, val []types.VMInfo) {
 	cps.set(cps.getKey(cloudinfo.VmKeyTemplate, provider, service, region), val)
 }
@@ -110,6 +148,28 @@ func (cps *cassandraProductStore) DeleteVm(provider, service, region string) {
 	cps.delete(cps.getKey(cloudinfo.VmKeyTemplate, provider, service, region))
 }
 
+func (cps *cassandraProductStore) StoreRegionProducts(provider, service, region string, val types.RegionProducts) {
+	cps.set(cps.getKey(cloudinfo.ProductsKeyTemplate, provider, service, region), val)
+}
+
+func (cps *cassandraProductStore) GetRegionProducts(provider, service, region string) (types.RegionProducts, bool) {
+	var res types.RegionProducts
+	_, ok := cps.get(cps.getKey(cloudinfo.ProductsKeyTemplate, provider, service, region), &res)
+
+	return res, ok
+}
+
+func (cps *cassandraProductStore) StoreCatalogChanges(provider, service, region string, val types.CatalogChanges) {
+	cps.set(cps.getKey(cloudinfo.CatalogChangesKeyTemplate, provider, service, region), val)
+}
+
+func (cps *cassandraProductStore) GetCatalogChanges(provider, service, region string) (types.CatalogChanges, bool) {
+	var res types.CatalogChanges
+	_, ok := cps.get(cps.getKey(cloudinfo.CatalogChangesKeyTemplate, provider, service, region), &res)
+
+	return res, ok
+}
+
 func (cps *cassandraProductStore) StoreImage(provider, service, regionId string, val []types.Image) {
 	cps.set(cps.getKey(cloudinfo.ImageKeyTemplate, provider, service, regionId), val)
 }
@@ -162,12 +222,50 @@ func (cps *cassandraProductStore) GetServices(provider string) ([]types.Service,
 	return res, ok
 }
 
+func (cps *cassandraProductStore) StoreScrapeErrors(provider string, val []types.ScrapeError) {
+	cps.set(cps.getKey(cloudinfo.ScrapeErrorsKeyTemplate, provider), val)
+}
+
+func (cps *cassandraProductStore) GetScrapeErrors(provider string) ([]types.ScrapeError, bool) {
+	res := make([]types.ScrapeError, 0)
+	_, ok := cps.get(cps.getKey(cloudinfo.ScrapeErrorsKeyTemplate, provider), &res)
+
+	return res, ok
+}
+
+// Keys lists the keys with the given prefix
+// the table is keyed by the full key, so all the keys are read and filtered
+func (cps *cassandraProductStore) Keys(prefix string) []string {
+	keys := make([]string, 0)
+	if err := cps.initSession(); err != nil {
+		cps.log.Error("failed to connect to backend")
+		return keys
+	}
+
+	iter := cps.session.Query(fmt.Sprintf("SELECT key FROM %s.%s", cps.keySpace, cps.tableName)).Iter()
+
+	var key string
+	for iter.Scan(&key) {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+
+	if err := iter.Close(); err != nil {
+		cps.log.Error("failed to list keys", map[string]interface{}{"prefix": prefix})
+	}
+
+	return keys
+}
+
+// Export is not supported by the cassandra store
 func (cps *cassandraProductStore) Export(w io.Writer) error {
-	panic("implement me")
+	return cloudinfo.ErrSnapshotNotSupported
 }
 
+// Import is not supported by the cassandra store
 func (cps *cassandraProductStore) Import(r io.Reader) error {
-	panic("implement me")
+	return cloudinfo.ErrSnapshotNotSupported
 }
 
 func (cps *cassandraProductStore) Close() {
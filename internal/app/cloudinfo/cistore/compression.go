@@ -0,0 +1,97 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cistore
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/gob"
+
+	"emperror.dev/errors"
+
+	"github.com/banzaicloud/cloudinfo/internal/cloudinfo/types"
+)
+
+// compressedCategories the categories of the entries large enough to be worth compressing
+// nolint: gochecknoglobals
+var compressedCategories = map[string]bool{
+	VmsCategory:      true,
+	ProductsCategory: true,
+}
+
+// compressedValue a gob encoded and gzip compressed cache entry
+type compressedValue []byte
+
+// compressedEntry wraps the compressed value, so that its type is encoded along with it
+type compressedEntry struct {
+	Value interface{}
+}
+
+// registerTypes registers the types of the cached values for gob encoding
+func registerTypes() {
+	gob.Register(map[string]string{})
+	gob.Register([]string{})
+	gob.Register(types.Price{})
+	gob.Register([]types.VMInfo{})
+	gob.Register([]types.Image{})
+	gob.Register([]types.LocationVersion{})
+	gob.Register([]types.Service{})
+	gob.Register([]types.TimestampedPrice{})
+	gob.Register([]types.ScrapeError{})
+	gob.Register(types.RegionProducts{})
+	gob.Register(types.CatalogChanges{})
+	gob.Register(compressedValue{})
+}
+
+// compress encodes the value and compresses it if the encoded value is at least threshold bytes long
+// false is returned if the value is below the threshold
+func compress(val interface{}, threshold int) (compressedValue, bool, error) {
+	var encoded bytes.Buffer
+	if err := gob.NewEncoder(&encoded).Encode(compressedEntry{Value: val}); err != nil {
+		return nil, false, errors.WrapIf(err, "failed to encode the value")
+	}
+
+	if encoded.Len() < threshold {
+		return nil, false, nil
+	}
+
+	var compressed bytes.Buffer
+	zw := gzip.NewWriter(&compressed)
+	if _, err := encoded.WriteTo(zw); err != nil {
+		return nil, false, errors.WrapIf(err, "failed to compress the value")
+	}
+	if err := zw.Close(); err != nil {
+		return nil, false, errors.WrapIf(err, "failed to compress the value")
+	}
+
+	return compressedValue(compressed.Bytes()), true, nil
+}
+
+// decompress restores the value compressed by compress
+// empty slices and maps in the value are restored as nil ones
+func decompress(val compressedValue) (interface{}, error) {
+	zr, err := gzip.NewReader(bytes.NewReader(val))
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to decompress the value")
+	}
+	defer zr.Close()
+
+	var entry compressedEntry
+	if err := gob.NewDecoder(zr).Decode(&entry); err != nil {
+		return nil, errors.WrapIf(err, "failed to decode the value")
+	}
+
+	return entry.Value, nil
+}
@@ -33,6 +33,23 @@ type Config struct {
 type GoCacheConfig struct {
 	expiration      time.Duration
 	cleanupInterval time.Duration
+
+	// PersistencePath is the snapshot file the store is loaded from and saved to (empty disables persistence)
+	PersistencePath string
+
+	// TTLs holds the expiration of the entries per category (eg.: regions, prices) overriding the default one
+	// the ttls are not applied to the persistent store, the entries loaded from the snapshot keep their expiration
+	TTLs map[string]time.Duration
+
+	// CompressionThreshold is the encoded size in bytes above which the VMs and products of a region are held
+	// compressed, trading CPU for memory in large deployments (zero disables compression)
+	// the threshold is not applied to the persistent store, its entries are held uncompressed
+	CompressionThreshold int
+}
+
+// Persistent returns whether the configured store is the persistent in-mem cache saving its content when closed
+func (c Config) Persistent() bool {
+	return !c.Redis.Enabled && !c.Cassandra.Enabled && c.GoCache.PersistencePath != ""
 }
 
 // NewCloudInfoStore builds a new cloudinfo store based on the passed in configuration
@@ -49,7 +66,13 @@ func NewCloudInfoStore(conf Config, log cloudinfo.Logger) cloudinfo.CloudInfoSto
 		return NewCassandraProductStore(conf.Cassandra, log)
 	}
 
+	if conf.GoCache.PersistencePath != "" {
+		log.Info("using persistent in-mem cache as product store")
+		return NewCacheProductStoreWithPersistence(conf.GoCache.PersistencePath, conf.GoCache.expiration, conf.GoCache.cleanupInterval, log)
+	}
+
 	// fallback to the "initial" implementation
 	log.Info("using in-mem cache as product store")
-	return NewCacheProductStore(conf.GoCache.expiration, conf.GoCache.cleanupInterval, log)
+	return NewCacheProductStoreWithCompression(conf.GoCache.CompressionThreshold, conf.GoCache.TTLs, conf.GoCache.expiration,
+		conf.GoCache.cleanupInterval, log)
 }
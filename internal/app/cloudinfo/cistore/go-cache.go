@@ -15,23 +15,52 @@
 package cistore
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
 	"time"
 
 	"emperror.dev/emperror"
+	"emperror.dev/errors"
 	"github.com/patrickmn/go-cache"
 
 	"github.com/banzaicloud/cloudinfo/internal/cloudinfo"
 	"github.com/banzaicloud/cloudinfo/internal/cloudinfo/types"
 )
 
+// the categories of the stored entries, named after the last static segment of their keys
+const (
+	RegionsCategory          = "regions"
+	ZonesCategory            = "zones"
+	PricesCategory           = "prices"
+	SpotPriceHistoryCategory = "spotpricehistory"
+	VmsCategory              = "vms"
+	ProductsCategory         = "products"
+	ImagesCategory           = "images"
+	VersionsCategory         = "versions"
+	StatusCategory           = "status"
+	ServicesCategory         = "services"
+	ScrapeErrorsCategory     = "scrapeerrors"
+	CatalogChangesCategory   = "catalogchanges"
+)
+
 // cacheProductStore in memory cloud product information storer
 type cacheProductStore struct {
 	*cache.Cache
-	// all items are cached with this expiry
+	// items are cached with this expiry unless their category has a ttl set
 	itemExpiry time.Duration
 	log        cloudinfo.Logger
+	// the expiry of the items per category
+	ttls map[string]time.Duration
+	// the encoded size in bytes above which the large entries are stored compressed, zero disables compression
+	compressionThreshold int
+	// mu is held shared by the single entry operations (the cache itself is safe for concurrent use)
+	// and exclusively by the batch writes, so that these are never seen half done
+	mu sync.RWMutex
 }
 
 func (cis *cacheProductStore) Ready() bool {
@@ -39,23 +68,23 @@ func (cis *cacheProductStore) Ready() bool {
 }
 
 func (cis *cacheProductStore) DeleteRegions(provider, service string) {
-	cis.Delete(cis.getKey(cloudinfo.RegionKeyTemplate, provider, service))
+	cis.delete(cis.getKey(cloudinfo.RegionKeyTemplate, provider, service))
 }
 
 func (cis *cacheProductStore) DeleteZones(provider, service, region string) {
-	cis.Delete(cis.getKey(cloudinfo.ZoneKeyTemplate, provider, service, region))
+	cis.delete(cis.getKey(cloudinfo.ZoneKeyTemplate, provider, service, region))
 }
 
 func (cis *cacheProductStore) DeleteImage(provider, service, regionId string) {
-	cis.Delete(cis.getKey(cloudinfo.ImageKeyTemplate, provider, service, regionId))
+	cis.delete(cis.getKey(cloudinfo.ImageKeyTemplate, provider, service, regionId))
 }
 
 func (cis *cacheProductStore) DeleteVersion(provider, service, region string) {
-	cis.Delete(cis.getKey(cloudinfo.VersionKeyTemplate, provider, service, region))
+	cis.delete(cis.getKey(cloudinfo.VersionKeyTemplate, provider, service, region))
 }
 
 func (cis *cacheProductStore) StoreRegions(provider, service string, val map[string]string) {
-	cis.Set(cis.getKey(cloudinfo.RegionKeyTemplate, provider, service), val, cis.itemExpiry)
+	cis.set(cis.getKey(cloudinfo.RegionKeyTemplate, provider, service), val)
 }
 
 func (cis *cacheProductStore) GetRegions(provider, service string) (map[string]string, bool) {
@@ -66,7 +95,7 @@ func (cis *cacheProductStore) GetRegions(provider, service string) (map[string]s
 }
 
 func (cis *cacheProductStore) StoreZones(provider, service, region string, val []string) {
-	cis.Set(cis.getKey(cloudinfo.ZoneKeyTemplate, provider, service, region), val, cis.itemExpiry)
+	cis.set(cis.getKey(cloudinfo.ZoneKeyTemplate, provider, service, region), val)
 }
 
 func (cis *cacheProductStore) GetZones(provider, service, region string) ([]string, bool) {
@@ -78,7 +107,17 @@ func (cis *cacheProductStore) GetZones(provider, service, region string) ([]stri
 }
 
 func (cis *cacheProductStore) StorePrice(provider, region, instanceType string, val types.Price) {
-	cis.Set(cis.getKey(cloudinfo.PriceKeyTemplate, provider, region, instanceType), val, cis.itemExpiry)
+	cis.set(cis.getKey(cloudinfo.PriceKeyTemplate, provider, region, instanceType), val)
+}
+
+// StorePrices stores the prices under a single lock of the store as the cache has no bulk write
+func (cis *cacheProductStore) StorePrices(provider, region string, prices map[string]types.Price) {
+	cis.mu.Lock()
+	defer cis.mu.Unlock()
+
+	for instanceType, price := range prices {
+		cis.put(cis.getKey(cloudinfo.PriceKeyTemplate, provider, region, instanceType), price)
+	}
 }
 
 func (cis *cacheProductStore) GetPrice(provider, region, instanceType string) (types.Price, bool) {
@@ -88,8 +127,19 @@ func (cis *cacheProductStore) GetPrice(provider, region, instanceType string) (t
 	return types.Price{}, false
 }
 
+func (cis *cacheProductStore) StoreSpotPriceHistory(provider, region, instanceType string, val []types.TimestampedPrice) {
+	cis.set(cis.getKey(cloudinfo.SpotPriceHistoryKeyTemplate, provider, region, instanceType), val)
+}
+
+func (cis *cacheProductStore) GetSpotPriceHistory(provider, region, instanceType string) ([]types.TimestampedPrice, bool) {
+	if res, ok := cis.get(cis.getKey(cloudinfo.SpotPriceHistoryKeyTemplate, provider, region, instanceType)); ok {
+		return res.([]types.TimestampedPrice), ok
+	}
+	return nil, false
+}
+
 func (cis *cacheProductStore) StoreVm(provider, service, region string, val []types.VMInfo) {
-	cis.Set(cis.getKey(cloudinfo.VmKeyTemplate, provider, service, region), val, cis.itemExpiry)
+	cis.set(cis.getKey(cloudinfo.VmKeyTemplate, provider, service, region), val)
 }
 
 func (cis *cacheProductStore) GetVm(provider, service, region string) ([]types.VMInfo, bool) {
@@ -100,8 +150,30 @@ func (cis *cacheProductStore) GetVm(provider, service, region string) ([]types.V
 	return nil, false
 }
 
+func (cis *cacheProductStore) StoreRegionProducts(provider, service, region string, val types.RegionProducts) {
+	cis.set(cis.getKey(cloudinfo.ProductsKeyTemplate, provider, service, region), val)
+}
+
+func (cis *cacheProductStore) GetRegionProducts(provider, service, region string) (types.RegionProducts, bool) {
+	if res, ok := cis.get(cis.getKey(cloudinfo.ProductsKeyTemplate, provider, service, region)); ok {
+		return res.(types.RegionProducts), ok
+	}
+	return types.RegionProducts{}, false
+}
+
+func (cis *cacheProductStore) StoreCatalogChanges(provider, service, region string, val types.CatalogChanges) {
+	cis.set(cis.getKey(cloudinfo.CatalogChangesKeyTemplate, provider, service, region), val)
+}
+
+func (cis *cacheProductStore) GetCatalogChanges(provider, service, region string) (types.CatalogChanges, bool) {
+	if res, ok := cis.get(cis.getKey(cloudinfo.CatalogChangesKeyTemplate, provider, service, region)); ok {
+		return res.(types.CatalogChanges), ok
+	}
+	return types.CatalogChanges{}, false
+}
+
 func (cis *cacheProductStore) StoreImage(provider, service, regionId string, val []types.Image) {
-	cis.Set(cis.getKey(cloudinfo.ImageKeyTemplate, provider, service, regionId), val, cis.itemExpiry)
+	cis.set(cis.getKey(cloudinfo.ImageKeyTemplate, provider, service, regionId), val)
 }
 
 func (cis *cacheProductStore) GetImage(provider, service, regionId string) ([]types.Image, bool) {
@@ -113,7 +185,7 @@ func (cis *cacheProductStore) GetImage(provider, service, regionId string) ([]ty
 }
 
 func (cis *cacheProductStore) StoreVersion(provider, service, region string, val []types.LocationVersion) {
-	cis.Set(cis.getKey(cloudinfo.VersionKeyTemplate, provider, service, region), val, cis.itemExpiry)
+	cis.set(cis.getKey(cloudinfo.VersionKeyTemplate, provider, service, region), val)
 }
 
 func (cis *cacheProductStore) GetVersion(provider, service, region string) ([]types.LocationVersion, bool) {
@@ -125,71 +197,306 @@ func (cis *cacheProductStore) GetVersion(provider, service, region string) ([]ty
 }
 
 func (cis *cacheProductStore) StoreStatus(provider string, val string) {
-	cis.Set(cis.getKey(cloudinfo.StatusKeyTemplate, provider), val, cis.itemExpiry)
+	cis.set(cis.getKey(cloudinfo.StatusKeyTemplate, provider), val)
 }
 
 func (cis *cacheProductStore) GetStatus(provider string) (string, bool) {
-	if res, ok := cis.Get(cis.getKey(cloudinfo.StatusKeyTemplate, provider)); ok {
+	if res, ok := cis.get(cis.getKey(cloudinfo.StatusKeyTemplate, provider)); ok {
 		return res.(string), ok
 	}
 
 	return "", false
 }
 
-// Export writes the content of the store into the passed in writer
+// Keys walks the cache items and lists the keys with the given prefix
+// expired items not yet cleaned up are left out
+func (cis *cacheProductStore) Keys(prefix string) []string {
+	keys := make([]string, 0)
+	for key := range cis.items() {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+
+	return keys
+}
+
+// Export writes the content of the store as a JSON snapshot into the passed in writer
 func (cis *cacheProductStore) Export(w io.Writer) error {
-	if err := cis.Save(w); err != nil {
-		cis.log.Error("failed to export the store", map[string]interface{}{"op": "export", "destination": "todo"})
-		return emperror.WrapWith(err, "failed to export the store", "op", "export", "destination", "todo")
+	snapshot := newStoreSnapshot()
+	for key, item := range cis.items() {
+		object, ok := cis.value(key, item.Object)
+		if !ok {
+			continue
+		}
+
+		raw, err := json.Marshal(object)
+		if err != nil {
+			return emperror.WrapWith(err, "failed to encode store entry", "op", "export", "key", key)
+		}
+
+		if _, err := snapshot.add(key, raw); err != nil {
+			return emperror.WrapWith(err, "failed to export the store", "op", "export")
+		}
+	}
+
+	if err := snapshot.write(w); err != nil {
+		cis.log.Error("failed to export the store", map[string]interface{}{"op": "export"})
+		return emperror.WrapWith(err, "failed to export the store", "op", "export")
 	}
 	return nil
 }
 
-// Import loads the store data from the standard input
+// Import loads the store data from a JSON snapshot, replacing the existing entries
+// the snapshot is decoded entirely before the store is touched, so an invalid snapshot leaves the store intact,
+// then the content of the store is swapped under the lock of the store, so readers see either the old or the new content
 func (cis *cacheProductStore) Import(r io.Reader) error {
-	if err := cis.Load(r); err != nil {
-		cis.log.Error("failed to load store data", map[string]interface{}{"op": "import", "destination": "todo"})
-		return emperror.WrapWith(err, "failed to load the store data", "op", "import", "destination", "todo")
+	snapshot, err := readStoreSnapshot(r)
+	if err != nil {
+		cis.log.Error("failed to load store data", map[string]interface{}{"op": "import"})
+		return emperror.WrapWith(err, "failed to load the store data", "op", "import")
+	}
+
+	entries := snapshot.entries()
+
+	cis.mu.Lock()
+	defer cis.mu.Unlock()
+
+	cis.Flush()
+	for key, value := range entries {
+		cis.put(key, value)
 	}
 	return nil
 }
 
 func (cis *cacheProductStore) DeleteVm(provider, service, region string) {
-	cis.Delete(cis.getKey(cloudinfo.VmKeyTemplate, provider, service, region))
+	cis.delete(cis.getKey(cloudinfo.VmKeyTemplate, provider, service, region))
 }
 
 func (cis *cacheProductStore) StoreServices(provider string, services []types.Service) {
-	cis.Set(cis.getKey(cloudinfo.ServicesKeyTemplate, provider), services, cis.itemExpiry)
+	cis.set(cis.getKey(cloudinfo.ServicesKeyTemplate, provider), services)
 }
 
 func (cis *cacheProductStore) GetServices(provider string) ([]types.Service, bool) {
-	r, o := cis.Get(cis.getKey(cloudinfo.ServicesKeyTemplate, provider))
+	r, o := cis.get(cis.getKey(cloudinfo.ServicesKeyTemplate, provider))
 	if !o {
 		return nil, o
 	}
 	return r.([]types.Service), o
 }
 
+func (cis *cacheProductStore) StoreScrapeErrors(provider string, val []types.ScrapeError) {
+	cis.set(cis.getKey(cloudinfo.ScrapeErrorsKeyTemplate, provider), val)
+}
+
+func (cis *cacheProductStore) GetScrapeErrors(provider string) ([]types.ScrapeError, bool) {
+	if res, ok := cis.get(cis.getKey(cloudinfo.ScrapeErrorsKeyTemplate, provider)); ok {
+		return res.([]types.ScrapeError), ok
+	}
+	return nil, false
+}
+
 // NewCacheProductStore creates a new store instance.
 // the backing cache is initialized with the defaultExpiration and cleanupInterval
 func NewCacheProductStore(cloudInfoExpiration, cleanupInterval time.Duration, logger cloudinfo.Logger) cloudinfo.CloudInfoStore {
+	return NewCacheProductStoreWithTTLs(nil, cloudInfoExpiration, cleanupInterval, logger)
+}
+
+// NewCacheProductStoreWithTTLs creates a new store instance caching the items of the categories with the given ttls
+// (eg.: long ttl for the rarely changing regions and short ttl for prices), the other items are cached as by NewCacheProductStore
+func NewCacheProductStoreWithTTLs(ttls map[string]time.Duration, cloudInfoExpiration, cleanupInterval time.Duration,
+	logger cloudinfo.Logger) cloudinfo.CloudInfoStore {
+	return NewCacheProductStoreWithCompression(0, ttls, cloudInfoExpiration, cleanupInterval, logger)
+}
+
+// NewCacheProductStoreWithCompression creates a new store instance as by NewCacheProductStoreWithTTLs, holding the
+// large entries (eg.: the VMs of a region) gob encoded and gzip compressed if their encoded size reaches the threshold
+func NewCacheProductStoreWithCompression(compressionThreshold int, ttls map[string]time.Duration, cloudInfoExpiration,
+	cleanupInterval time.Duration, logger cloudinfo.Logger) cloudinfo.CloudInfoStore {
+	if compressionThreshold > 0 {
+		registerTypes()
+	}
+
 	return &cacheProductStore{
-		cache.New(cloudInfoExpiration, cleanupInterval),
-		cleanupInterval,
-		logger,
+		Cache:                cache.New(cloudInfoExpiration, cleanupInterval),
+		itemExpiry:           cleanupInterval,
+		log:                  logger,
+		ttls:                 ttls,
+		compressionThreshold: compressionThreshold,
 	}
 }
 
+// persistentCacheProductStore in memory store that survives restarts by snapshotting its content to a file
+type persistentCacheProductStore struct {
+	*cacheProductStore
+	// path of the snapshot file
+	path string
+}
+
+// NewCacheProductStoreWithPersistence creates a new in memory store instance backed by a snapshot file.
+// the snapshot is loaded at creation (if present) and written when the store is closed
+func NewCacheProductStoreWithPersistence(path string, cloudInfoExpiration, cleanupInterval time.Duration, logger cloudinfo.Logger) cloudinfo.CloudInfoStore {
+	// the types of the cached values need to be known for decoding the snapshot
+	registerTypes()
+
+	store := &persistentCacheProductStore{
+		cacheProductStore: &cacheProductStore{
+			Cache:      cache.New(cloudInfoExpiration, cleanupInterval),
+			itemExpiry: cleanupInterval,
+			log:        logger,
+		},
+		path: path,
+	}
+
+	if err := store.load(); err != nil {
+		logger.Warn("failed to load the store snapshot, starting with an empty store", map[string]interface{}{"path": path, "error": err})
+	}
+
+	return store
+}
+
+// load imports the snapshot file; expired entries are dropped, the rest keep their remaining ttl
+func (pcs *persistentCacheProductStore) load() error {
+	f, err := os.Open(pcs.path)
+	if os.IsNotExist(err) {
+		pcs.log.Info("no store snapshot found", map[string]interface{}{"path": pcs.path})
+		return nil
+	}
+	if err != nil {
+		return errors.WrapIfWithDetails(err, "failed to open the store snapshot", "path", pcs.path)
+	}
+	defer f.Close()
+
+	// the native format is used, that preserves the expiration of the entries
+	pcs.mu.Lock()
+	defer pcs.mu.Unlock()
+
+	return errors.WrapIfWithDetails(pcs.Load(f), "failed to load the store snapshot", "path", pcs.path)
+}
+
+// save writes the content of the store into the snapshot file
+// the snapshot is written into a temporary file first, so that a failure doesn't corrupt the previous snapshot
+func (pcs *persistentCacheProductStore) save() error {
+	tmp, err := os.Create(filepath.Join(filepath.Dir(pcs.path), "."+filepath.Base(pcs.path)+".tmp"))
+	if err != nil {
+		return errors.WrapIfWithDetails(err, "failed to create the store snapshot", "path", pcs.path)
+	}
+
+	pcs.mu.RLock()
+	err = pcs.Save(tmp)
+	pcs.mu.RUnlock()
+	if err != nil {
+		tmp.Close()
+		return errors.WrapIfWithDetails(err, "failed to save the store snapshot", "path", pcs.path)
+	}
+
+	if err = tmp.Close(); err != nil {
+		return errors.WrapIfWithDetails(err, "failed to write the store snapshot", "path", pcs.path)
+	}
+
+	return errors.WrapIfWithDetails(os.Rename(tmp.Name(), pcs.path), "failed to replace the store snapshot", "path", pcs.path)
+}
+
+// Close snapshots the content of the store
+func (pcs *persistentCacheProductStore) Close() {
+	if err := pcs.save(); err != nil {
+		pcs.log.Error("failed to save the store snapshot", map[string]interface{}{"path": pcs.path, "error": err})
+		return
+	}
+
+	pcs.log.Info("store snapshot saved", map[string]interface{}{"path": pcs.path})
+}
+
 func (cis *cacheProductStore) getKey(keyTemplate string, args ...interface{}) string {
 	return fmt.Sprintf(keyTemplate, args...)
 }
 
+// set caches the value with the expiry of its category, compressed if it's large enough
+func (cis *cacheProductStore) set(key string, val interface{}) {
+	cis.mu.RLock()
+	defer cis.mu.RUnlock()
+
+	cis.put(key, val)
+}
+
+// put caches the value as by set, the caller holds the lock of the store
+func (cis *cacheProductStore) put(key string, val interface{}) {
+	category := keyCategory(key)
+
+	expiry := cis.itemExpiry
+	if ttl, ok := cis.ttls[category]; ok {
+		expiry = ttl
+	}
+
+	if cis.compressionThreshold > 0 && compressedCategories[category] {
+		compressed, ok, err := compress(val, cis.compressionThreshold)
+		if err != nil {
+			cis.log.Warn("failed to compress the entry, storing it uncompressed", map[string]interface{}{"key": key, "error": err})
+		} else if ok {
+			val = compressed
+		}
+	}
+
+	cis.Set(key, val, expiry)
+}
+
+// keyCategory returns the category of the entry stored under the key
+func keyCategory(key string) string {
+	parts := strings.Split(strings.TrimSuffix(key, "/"), "/")
+	if len(parts) < 2 {
+		return ""
+	}
+
+	// the prices are keyed by instance type
+	if category := parts[len(parts)-2]; category == PricesCategory || category == SpotPriceHistoryCategory {
+		return category
+	}
+
+	return parts[len(parts)-1]
+}
+
+// get returns the cached value, decompressed if it was stored compressed
 func (cis *cacheProductStore) get(key string) (interface{}, bool) {
-	if val, ok := cis.Get(key); ok && val != nil {
+	cis.mu.RLock()
+	val, ok := cis.Get(key)
+	cis.mu.RUnlock()
+	if !ok || val == nil {
+		return nil, false
+	}
+
+	return cis.value(key, val)
+}
+
+// delete removes the entry stored under the key
+func (cis *cacheProductStore) delete(key string) {
+	cis.mu.RLock()
+	defer cis.mu.RUnlock()
+
+	cis.Delete(key)
+}
+
+// items returns a copy of the cached entries
+func (cis *cacheProductStore) items() map[string]cache.Item {
+	cis.mu.RLock()
+	defer cis.mu.RUnlock()
+
+	return cis.Items()
+}
+
+// value decompresses the cached value if needed
+func (cis *cacheProductStore) value(key string, val interface{}) (interface{}, bool) {
+	compressed, ok := val.(compressedValue)
+	if !ok {
 		return val, true
 	}
 
-	return nil, false
+	decompressed, err := decompress(compressed)
+	if err != nil {
+		cis.log.Error("failed to decompress the entry", map[string]interface{}{"key": key, "error": err})
+		return nil, false
+	}
+
+	return decompressed, true
 }
 
 func (cis *cacheProductStore) Close() {
@@ -0,0 +1,351 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cistore
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"logur.dev/logur"
+
+	"github.com/banzaicloud/cloudinfo/internal/cloudinfo"
+	"github.com/banzaicloud/cloudinfo/internal/cloudinfo/cloudinfoadapter"
+	"github.com/banzaicloud/cloudinfo/internal/cloudinfo/types"
+	"github.com/banzaicloud/cloudinfo/internal/platform/redis"
+)
+
+func TestNewCacheProductStoreWithPersistence(t *testing.T) {
+	logger := cloudinfoadapter.NewLogger(&logur.TestLogger{})
+	path := filepath.Join(t.TempDir(), "cloudinfo.snapshot")
+
+	vms := []types.VMInfo{{Type: "m5.large", OnDemandPrice: 0.096, Cpus: 2, Mem: 8}}
+
+	// a missing snapshot results in an empty store
+	store := NewCacheProductStoreWithPersistence(path, time.Hour, 0, logger)
+	_, ok := store.GetVm("amazon", "compute", "us-east-1")
+	assert.False(t, ok, "the store should be empty")
+
+	store.StoreVm("amazon", "compute", "us-east-1", vms)
+	store.StoreRegions("amazon", "compute", map[string]string{"us-east-1": "US East (N. Virginia)"})
+	store.StorePrice("amazon", "us-east-1", "m5.large", types.Price{OnDemandPrice: 0.096})
+	store.Close()
+
+	// the snapshot is loaded by the new store
+	reloaded := NewCacheProductStoreWithPersistence(path, time.Hour, 0, logger)
+
+	cachedVms, ok := reloaded.GetVm("amazon", "compute", "us-east-1")
+	assert.True(t, ok, "the vms should be reloaded")
+	assert.Equal(t, vms, cachedVms)
+
+	regions, ok := reloaded.GetRegions("amazon", "compute")
+	assert.True(t, ok, "the regions should be reloaded")
+	assert.Equal(t, map[string]string{"us-east-1": "US East (N. Virginia)"}, regions)
+
+	price, ok := reloaded.GetPrice("amazon", "us-east-1", "m5.large")
+	assert.True(t, ok, "the price should be reloaded")
+	assert.Equal(t, 0.096, price.OnDemandPrice)
+}
+
+func TestConfig_Persistent(t *testing.T) {
+	tests := []struct {
+		name       string
+		config     Config
+		persistent bool
+	}{
+		{
+			name:       "in-mem cache",
+			config:     Config{},
+			persistent: false,
+		},
+		{
+			name:       "persistent in-mem cache",
+			config:     Config{GoCache: GoCacheConfig{PersistencePath: "cloudinfo.snapshot"}},
+			persistent: true,
+		},
+		{
+			name:       "redis takes precedence",
+			config:     Config{Redis: redis.Config{Enabled: true}, GoCache: GoCacheConfig{PersistencePath: "cloudinfo.snapshot"}},
+			persistent: false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.persistent, test.config.Persistent())
+		})
+	}
+}
+
+func TestCacheProductStore_StorePrices(t *testing.T) {
+	store := NewCacheProductStore(time.Hour, 0, cloudinfoadapter.NewLogger(&logur.TestLogger{}))
+	store.StorePrice("amazon", "us-east-1", "m4.large", types.Price{OnDemandPrice: 0.1})
+
+	store.StorePrices("amazon", "us-east-1", map[string]types.Price{
+		"m5.large":  {OnDemandPrice: 0.096, SpotPrice: types.SpotPriceInfo{"us-east-1a": 0.035}},
+		"m5.xlarge": {OnDemandPrice: 0.192},
+	})
+
+	price, ok := store.GetPrice("amazon", "us-east-1", "m5.large")
+	assert.True(t, ok, "the price should be stored")
+	assert.Equal(t, types.Price{OnDemandPrice: 0.096, SpotPrice: types.SpotPriceInfo{"us-east-1a": 0.035}}, price)
+
+	price, ok = store.GetPrice("amazon", "us-east-1", "m5.xlarge")
+	assert.True(t, ok, "the price should be stored")
+	assert.Equal(t, 0.192, price.OnDemandPrice)
+
+	price, ok = store.GetPrice("amazon", "us-east-1", "m4.large")
+	assert.True(t, ok, "the prices not in the bulk write should be kept")
+	assert.Equal(t, 0.1, price.OnDemandPrice)
+}
+
+func TestCacheProductStore_StorePricesIsAtomic(t *testing.T) {
+	store := NewCacheProductStore(time.Hour, 0, cloudinfoadapter.NewLogger(&logur.TestLogger{}))
+
+	prices := make(map[string]types.Price, 200)
+	for i := 0; i < 200; i++ {
+		prices[fmt.Sprintf("type%d", i)] = types.Price{OnDemandPrice: float64(i) / 100}
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		store.StorePrices("amazon", "us-east-1", prices)
+	}()
+
+	prefix := fmt.Sprintf(cloudinfo.PriceKeyTemplate, "amazon", "us-east-1", "")
+	for stored := false; !stored; {
+		select {
+		case <-done:
+			stored = true
+		default:
+		}
+
+		keys := store.Keys(prefix)
+		assert.Contains(t, []int{0, len(prices)}, len(keys), "the prices should be seen either all or none")
+	}
+}
+
+func TestNewCacheProductStoreWithTTLs(t *testing.T) {
+	store := NewCacheProductStoreWithTTLs(map[string]time.Duration{
+		RegionsCategory: time.Hour,
+		PricesCategory:  50 * time.Millisecond,
+	}, time.Hour, 0, cloudinfoadapter.NewLogger(&logur.TestLogger{}))
+
+	store.StoreRegions("amazon", "compute", map[string]string{"us-east-1": "US East (N. Virginia)"})
+	store.StorePrice("amazon", "us-east-1", "m5.large", types.Price{OnDemandPrice: 0.096})
+	store.StorePrices("amazon", "us-east-1", map[string]types.Price{"m5.xlarge": {OnDemandPrice: 0.192}})
+	store.StoreZones("amazon", "compute", "us-east-1", []string{"us-east-1a"})
+
+	time.Sleep(100 * time.Millisecond)
+
+	_, ok := store.GetRegions("amazon", "compute")
+	assert.True(t, ok, "the regions should outlive the prices")
+	_, ok = store.GetZones("amazon", "compute", "us-east-1")
+	assert.True(t, ok, "the categories without ttl should be cached with the default expiration")
+	_, ok = store.GetPrice("amazon", "us-east-1", "m5.large")
+	assert.False(t, ok, "the price should be expired")
+	_, ok = store.GetPrice("amazon", "us-east-1", "m5.xlarge")
+	assert.False(t, ok, "the bulk stored price should be expired")
+}
+
+// testVms returns the number of VMs with every field set, so that they survive the gob round trip unchanged
+func testVms(count int) []types.VMInfo {
+	vms := make([]types.VMInfo, 0, count)
+	for i := 0; i < count; i++ {
+		vms = append(vms, types.VMInfo{
+			Category:      types.CategoryGeneral,
+			Type:          fmt.Sprintf("m5.%dxlarge", i),
+			OnDemandPrice: 0.192 * float64(i+1),
+			SpotPrice:     []types.ZonePrice{{Zone: "us-east-1a", Price: 0.07 * float64(i+1)}},
+			Cpus:          4 * float64(i+1),
+			Mem:           16 * float64(i+1),
+			NtwPerf:       "Up to 10 Gigabit",
+			NtwPerfCat:    "high",
+			NtwGbps:       10,
+			Zones:         []string{"us-east-1a", "us-east-1b"},
+			Attributes:    map[string]string{types.CPU: "4", types.Memory: "16"},
+			CurrentGen:    true,
+		})
+	}
+
+	return vms
+}
+
+func TestNewCacheProductStoreWithCompression(t *testing.T) {
+	store := NewCacheProductStoreWithCompression(1024, nil, time.Hour, 0, cloudinfoadapter.NewLogger(&logur.TestLogger{}))
+	large, small := testVms(100), testVms(1)
+
+	store.StoreVm("amazon", "compute", "us-east-1", large)
+	store.StoreVm("amazon", "compute", "us-west-1", small)
+
+	raw, _ := store.(*cacheProductStore).Get(fmt.Sprintf(cloudinfo.VmKeyTemplate, "amazon", "compute", "us-east-1"))
+	assert.IsType(t, compressedValue{}, raw, "the large entry should be compressed")
+	raw, _ = store.(*cacheProductStore).Get(fmt.Sprintf(cloudinfo.VmKeyTemplate, "amazon", "compute", "us-west-1"))
+	assert.IsType(t, []types.VMInfo{}, raw, "the entry below the threshold should be kept as is")
+
+	vms, ok := store.GetVm("amazon", "compute", "us-east-1")
+	assert.True(t, ok, "the vms should be stored")
+	assert.Equal(t, large, vms)
+
+	vms, ok = store.GetVm("amazon", "compute", "us-west-1")
+	assert.True(t, ok, "the vms should be stored")
+	assert.Equal(t, small, vms)
+
+	var snapshot bytes.Buffer
+	assert.Nil(t, store.Export(&snapshot), "the error should be nil")
+	assert.Contains(t, snapshot.String(), large[99].Type, "the compressed entries should be exported decompressed")
+}
+
+func BenchmarkCacheProductStore_GetVm(b *testing.B) {
+	for _, threshold := range []int{0, 1024} {
+		b.Run(fmt.Sprintf("threshold=%d", threshold), func(b *testing.B) {
+			store := NewCacheProductStoreWithCompression(threshold, nil, time.Hour, 0, cloudinfoadapter.NewLogger(&logur.TestLogger{}))
+			store.StoreVm("amazon", "compute", "us-east-1", testVms(400))
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				store.GetVm("amazon", "compute", "us-east-1")
+			}
+		})
+	}
+}
+
+func TestKeyCategory(t *testing.T) {
+	tests := []struct {
+		key      string
+		category string
+	}{
+		{key: fmt.Sprintf(cloudinfo.RegionKeyTemplate, "amazon", "compute"), category: RegionsCategory},
+		{key: fmt.Sprintf(cloudinfo.ZoneKeyTemplate, "amazon", "compute", "us-east-1"), category: ZonesCategory},
+		{key: fmt.Sprintf(cloudinfo.PriceKeyTemplate, "amazon", "us-east-1", "m5.large"), category: PricesCategory},
+		{key: fmt.Sprintf(cloudinfo.SpotPriceHistoryKeyTemplate, "amazon", "us-east-1", "m5.large"), category: SpotPriceHistoryCategory},
+		{key: fmt.Sprintf(cloudinfo.VmKeyTemplate, "amazon", "compute", "us-east-1"), category: VmsCategory},
+		{key: fmt.Sprintf(cloudinfo.ProductsKeyTemplate, "amazon", "compute", "us-east-1"), category: ProductsCategory},
+		{key: fmt.Sprintf(cloudinfo.ImageKeyTemplate, "amazon", "compute", "us-east-1"), category: ImagesCategory},
+		{key: fmt.Sprintf(cloudinfo.VersionKeyTemplate, "amazon", "compute", "us-east-1"), category: VersionsCategory},
+		{key: fmt.Sprintf(cloudinfo.StatusKeyTemplate, "amazon"), category: StatusCategory},
+		{key: fmt.Sprintf(cloudinfo.ServicesKeyTemplate, "amazon"), category: ServicesCategory},
+		{key: fmt.Sprintf(cloudinfo.ScrapeErrorsKeyTemplate, "amazon"), category: ScrapeErrorsCategory},
+		{key: fmt.Sprintf(cloudinfo.CatalogChangesKeyTemplate, "amazon", "compute", "us-east-1"), category: CatalogChangesCategory},
+	}
+	for _, test := range tests {
+		t.Run(test.category, func(t *testing.T) {
+			assert.Equal(t, test.category, keyCategory(test.key))
+		})
+	}
+}
+
+func TestCacheProductStore_ExportImport(t *testing.T) {
+	logger := cloudinfoadapter.NewLogger(&logur.TestLogger{})
+
+	source := NewCacheProductStore(time.Hour, 0, logger)
+	source.StoreStatus("amazon", "1546300800000")
+	source.StoreServices("amazon", []types.Service{{Service: "compute"}})
+	source.StoreRegions("amazon", "compute", map[string]string{"us-east-1": "US East (N. Virginia)"})
+	source.StoreZones("amazon", "compute", "us-east-1", []string{"us-east-1a", "us-east-1b"})
+	source.StorePrice("amazon", "us-east-1", "m5.large", types.Price{OnDemandPrice: 0.096, SpotPrice: types.SpotPriceInfo{"us-east-1a": 0.035}})
+	source.StoreVm("amazon", "compute", "us-east-1", []types.VMInfo{
+		{Type: "m5.large", OnDemandPrice: 0.096, Cpus: 2, Mem: 8, Attributes: map[string]string{"cpu": "2"}},
+	})
+	source.StoreImage("amazon", "compute", "us-east-1", []types.Image{{Name: "ami-1234", Version: "1.17"}})
+	source.StoreVersion("amazon", "compute", "us-east-1", []types.LocationVersion{{Location: "us-east-1", Versions: []string{"1.17"}}})
+
+	var snapshot bytes.Buffer
+	assert.Nil(t, source.Export(&snapshot), "the export should succeed")
+
+	target := NewCacheProductStore(time.Hour, 0, logger)
+	target.StoreStatus("amazon", "0")
+	target.StorePrice("amazon", "eu-west-1", "m4.large", types.Price{OnDemandPrice: 0.1})
+	assert.Nil(t, target.Import(&snapshot), "the import should succeed")
+
+	_, ok := target.GetPrice("amazon", "eu-west-1", "m4.large")
+	assert.False(t, ok, "the entries missing from the snapshot should be dropped")
+
+	status, _ := target.GetStatus("amazon")
+	assert.Equal(t, "1546300800000", status)
+	services, _ := target.GetServices("amazon")
+	assert.Equal(t, []types.Service{{Service: "compute"}}, services)
+	regions, _ := target.GetRegions("amazon", "compute")
+	assert.Equal(t, map[string]string{"us-east-1": "US East (N. Virginia)"}, regions)
+	zones, _ := target.GetZones("amazon", "compute", "us-east-1")
+	assert.Equal(t, []string{"us-east-1a", "us-east-1b"}, zones)
+	price, _ := target.GetPrice("amazon", "us-east-1", "m5.large")
+	assert.Equal(t, types.Price{OnDemandPrice: 0.096, SpotPrice: types.SpotPriceInfo{"us-east-1a": 0.035}}, price)
+	vms, _ := target.GetVm("amazon", "compute", "us-east-1")
+	assert.Equal(t, map[string]string{"cpu": "2"}, vms[0].Attributes)
+	images, _ := target.GetImage("amazon", "compute", "us-east-1")
+	assert.Equal(t, []types.Image{{Name: "ami-1234", Version: "1.17"}}, images)
+	versions, _ := target.GetVersion("amazon", "compute", "us-east-1")
+	assert.Equal(t, []types.LocationVersion{{Location: "us-east-1", Versions: []string{"1.17"}}}, versions)
+
+	// invalid snapshots leave the store intact
+	assert.NotNil(t, target.Import(strings.NewReader("{invalid")), "the import should fail")
+	status, _ = target.GetStatus("amazon")
+	assert.Equal(t, "1546300800000", status)
+}
+
+func TestCacheProductStore_ImportIsAtomic(t *testing.T) {
+	logger := cloudinfoadapter.NewLogger(&logur.TestLogger{})
+
+	prices := make(map[string]types.Price, 200)
+	for i := 0; i < 200; i++ {
+		prices[fmt.Sprintf("type%d", i)] = types.Price{OnDemandPrice: float64(i) / 100}
+	}
+	source := NewCacheProductStore(time.Hour, 0, logger)
+	source.StorePrices("amazon", "us-east-1", prices)
+
+	var snapshot bytes.Buffer
+	assert.Nil(t, source.Export(&snapshot), "the export should succeed")
+
+	target := NewCacheProductStore(time.Hour, 0, logger)
+	target.StorePrice("amazon", "us-east-1", "m4.large", types.Price{OnDemandPrice: 0.1})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		assert.Nil(t, target.Import(&snapshot), "the import should succeed")
+	}()
+
+	prefix := fmt.Sprintf(cloudinfo.PriceKeyTemplate, "amazon", "us-east-1", "")
+	for imported := false; !imported; {
+		select {
+		case <-done:
+			imported = true
+		default:
+		}
+
+		keys := target.Keys(prefix)
+		assert.Contains(t, []int{1, len(prices)}, len(keys), "either the old or the imported content should be seen")
+	}
+}
+
+func TestCacheProductStore_Keys(t *testing.T) {
+	store := NewCacheProductStore(time.Hour, 0, cloudinfoadapter.NewLogger(&logur.TestLogger{}))
+	store.StoreVm("amazon", "compute", "us-east-1", []types.VMInfo{{Type: "m5.large"}})
+	store.StoreVm("amazon", "compute", "eu-west-1", []types.VMInfo{{Type: "c5.large"}})
+	store.StoreVm("google", "compute", "us-east1", []types.VMInfo{{Type: "n1-standard-1"}})
+
+	assert.ElementsMatch(t, []string{
+		fmt.Sprintf(cloudinfo.VmKeyTemplate, "amazon", "compute", "us-east-1"),
+		fmt.Sprintf(cloudinfo.VmKeyTemplate, "amazon", "compute", "eu-west-1"),
+	}, store.Keys("/banzaicloud.com/cloudinfo/providers/amazon/"))
+	assert.Equal(t, 3, len(store.Keys("")))
+	assert.Equal(t, []string{}, store.Keys("/banzaicloud.com/cloudinfo/providers/azure/"))
+}
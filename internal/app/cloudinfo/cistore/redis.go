@@ -18,7 +18,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"strings"
+	"time"
 
+	"emperror.dev/errors"
 	redigo "github.com/gomodule/redigo/redis"
 
 	cloudinfo "github.com/banzaicloud/cloudinfo/internal/cloudinfo"
@@ -28,7 +31,9 @@ import (
 
 type redisProductStore struct {
 	pool *redigo.Pool
-	log  cloudinfo.Logger
+	// ttl is the expiration of the stored entries, zero means the entries never expire
+	ttl time.Duration
+	log cloudinfo.Logger
 }
 
 func (rps *redisProductStore) Ready() bool {
@@ -109,7 +114,7 @@ func (rps *redisProductStore) set(key string, value interface{}) (interface{}, b
 		return nil, false
 	}
 
-	if _, err = conn.Do("SET", key, mJson); err != nil {
+	if _, err = conn.Do("SET", rps.setArgs(key, mJson)...); err != nil {
 		rps.log.Error("failed to set key to value", map[string]interface{}{"key": key, "value": value})
 		return nil, false
 	}
@@ -117,6 +122,16 @@ func (rps *redisProductStore) set(key string, value interface{}) (interface{}, b
 	return mJson, true
 }
 
+// setArgs returns the arguments of the SET command for the key, including the expiration if configured
+func (rps *redisProductStore) setArgs(key string, mJson []byte) []interface{} {
+	args := []interface{}{key, mJson}
+	if rps.ttl > 0 {
+		args = append(args, "PX", rps.ttl.Milliseconds())
+	}
+
+	return args
+}
+
 func (rps *redisProductStore) delete(key string) {
 	conn := rps.pool.Get()
 	defer conn.Close()
@@ -131,19 +146,119 @@ func NewRedisProductStore(config redis.Config, log cloudinfo.Logger) cloudinfo.C
 
 	return &redisProductStore{
 		pool: pool,
+		ttl:  config.Expiration,
 		log:  log.WithFields(map[string]interface{}{"cistore": "redis"}),
 	}
 }
 
-// Export writes the content of the store into the passed in writer
+// Export writes the content of the store as a JSON snapshot into the passed in writer
 func (rps *redisProductStore) Export(w io.Writer) error {
-	// todo implement the export
-	return nil
+	conn := rps.pool.Get()
+	defer conn.Close()
+
+	keys, err := scanKeys(conn, keyPrefix)
+	if err != nil {
+		return err
+	}
+
+	snapshot := newStoreSnapshot()
+	for _, key := range keys {
+		raw, err := redigo.Bytes(conn.Do("GET", key))
+		if err == redigo.ErrNil {
+			// the entry expired in the meantime
+			continue
+		}
+		if err != nil {
+			return errors.WrapIfWithDetails(err, "failed to get entry", "key", key)
+		}
+
+		if _, err := snapshot.add(key, raw); err != nil {
+			return err
+		}
+	}
+
+	return snapshot.write(w)
 }
 
-// Import loads the store data from the standard input
+// Keys lists the keys with the given prefix
+func (rps *redisProductStore) Keys(prefix string) []string {
+	conn := rps.pool.Get()
+	defer conn.Close()
+
+	keys, err := scanKeys(conn, prefix)
+	if err != nil {
+		rps.log.Error("failed to list keys", map[string]interface{}{"prefix": prefix})
+		return []string{}
+	}
+
+	return keys
+}
+
+// scanKeys iterates the keyspace with SCAN collecting the keys with the given prefix
+func scanKeys(conn redigo.Conn, prefix string) ([]string, error) {
+	var keys []string
+	for cursor := 0; ; {
+		values, err := redigo.Values(conn.Do("SCAN", cursor, "MATCH", redisGlobEscaper.Replace(prefix)+"*"))
+		if err != nil {
+			return nil, errors.WrapIf(err, "failed to scan the store keys")
+		}
+
+		if len(values) != 2 {
+			return nil, errors.NewWithDetails("unexpected SCAN reply", "len", len(values))
+		}
+
+		if cursor, err = redigo.Int(values[0], nil); err != nil {
+			return nil, errors.WrapIf(err, "failed to scan the store keys")
+		}
+
+		page, err := redigo.Strings(values[1], nil)
+		if err != nil {
+			return nil, errors.WrapIf(err, "failed to scan the store keys")
+		}
+		keys = append(keys, page...)
+
+		if cursor == 0 {
+			return keys, nil
+		}
+	}
+}
+
+// redisGlobEscaper escapes the glob special characters of the SCAN MATCH pattern
+// nolint: gochecknoglobals
+var redisGlobEscaper = strings.NewReplacer(`\`, `\\`, "*", `\*`, "?", `\?`, "[", `\[`, "]", `\]`)
+
+// Import loads the store data from a JSON snapshot
+// the entries are replaced in a single transaction
 func (rps *redisProductStore) Import(r io.Reader) error {
-	// todo implement the import
+	snapshot, err := readStoreSnapshot(r)
+	if err != nil {
+		return err
+	}
+
+	conn := rps.pool.Get()
+	defer conn.Close()
+
+	if err = conn.Send("MULTI"); err != nil {
+		return errors.WrapIf(err, "failed to start the import transaction")
+	}
+
+	for key, value := range snapshot.entries() {
+		mJson, err := json.Marshal(value)
+		if err != nil {
+			_, _ = conn.Do("DISCARD")
+			return errors.WrapIfWithDetails(err, "failed to marshal value into json", "key", key)
+		}
+
+		if err = conn.Send("SET", rps.setArgs(key, mJson)...); err != nil {
+			_, _ = conn.Do("DISCARD")
+			return errors.WrapIfWithDetails(err, "failed to set key to value", "key", key)
+		}
+	}
+
+	if _, err = conn.Do("EXEC"); err != nil {
+		return errors.WrapIf(err, "failed to import the store data")
+	}
+
 	return nil
 }
 
@@ -177,6 +292,37 @@ func (rps *redisProductStore) StorePrice(provider, region, instanceType string,
 	rps.set(rps.getKey(cloudinfo.PriceKeyTemplate, provider, region, instanceType), val)
 }
 
+// StorePrices stores the prices in a single transaction
+func (rps *redisProductStore) StorePrices(provider, region string, prices map[string]types.Price) {
+	conn := rps.pool.Get()
+	defer conn.Close()
+
+	if err := conn.Send("MULTI"); err != nil {
+		rps.log.Error("failed to start the price transaction", map[string]interface{}{"region": region})
+		return
+	}
+
+	for instanceType, price := range prices {
+		key := rps.getKey(cloudinfo.PriceKeyTemplate, provider, region, instanceType)
+
+		mJson, err := json.Marshal(price)
+		if err != nil {
+			rps.log.Debug("failed to marshal value into json", map[string]interface{}{"key": key, "value": price})
+			continue
+		}
+
+		if err = conn.Send("SET", rps.setArgs(key, mJson)...); err != nil {
+			_, _ = conn.Do("DISCARD")
+			rps.log.Error("failed to set key to value", map[string]interface{}{"key": key, "value": price})
+			return
+		}
+	}
+
+	if _, err := conn.Do("EXEC"); err != nil {
+		rps.log.Error("failed to store prices", map[string]interface{}{"region": region})
+	}
+}
+
 func (rps *redisProductStore) GetPrice(provider, region, instanceType string) (types.Price, bool) {
 	var (
 		res = types.Price{}
@@ -186,6 +332,19 @@ func (rps *redisProductStore) GetPrice(provider, region, instanceType string) (t
 	return res, ok
 }
 
+func (rps *redisProductStore) StoreSpotPriceHistory(provider, region, instanceType string, val []types.TimestampedPrice) {
+	rps.set(rps.getKey(cloudinfo.SpotPriceHistoryKeyTemplate, provider, region, instanceType), val)
+}
+
+func (rps *redisProductStore) GetSpotPriceHistory(provider, region, instanceType string) ([]types.TimestampedPrice, bool) {
+	var (
+		res = make([]types.TimestampedPrice, 0)
+	)
+	_, ok := rps.get(rps.getKey(cloudinfo.SpotPriceHistoryKeyTemplate, provider, region, instanceType), &res)
+
+	return res, ok
+}
+
 func (rps *redisProductStore) StoreVm(provider, service, region string, val []types.VMInfo) {
 	rps.set(rps.getKey(cloudinfo.VmKeyTemplate, provider, service, region), val)
 }
@@ -203,6 +362,28 @@ func (rps *redisProductStore) DeleteVm(provider, service, region string) {
 	rps.delete(rps.getKey(cloudinfo.VmKeyTemplate, provider, service, region))
 }
 
+func (rps *redisProductStore) StoreRegionProducts(provider, service, region string, val types.RegionProducts) {
+	rps.set(rps.getKey(cloudinfo.ProductsKeyTemplate, provider, service, region), val)
+}
+
+func (rps *redisProductStore) GetRegionProducts(provider, service, region string) (types.RegionProducts, bool) {
+	var res types.RegionProducts
+	_, ok := rps.get(rps.getKey(cloudinfo.ProductsKeyTemplate, provider, service, region), &res)
+
+	return res, ok
+}
+
+func (rps *redisProductStore) StoreCatalogChanges(provider, service, region string, val types.CatalogChanges) {
+	rps.set(rps.getKey(cloudinfo.CatalogChangesKeyTemplate, provider, service, region), val)
+}
+
+func (rps *redisProductStore) GetCatalogChanges(provider, service, region string) (types.CatalogChanges, bool) {
+	var res types.CatalogChanges
+	_, ok := rps.get(rps.getKey(cloudinfo.CatalogChangesKeyTemplate, provider, service, region), &res)
+
+	return res, ok
+}
+
 func (rps *redisProductStore) StoreImage(provider, service, regionId string, val []types.Image) {
 	rps.set(rps.getKey(cloudinfo.ImageKeyTemplate, provider, service, regionId), val)
 }
@@ -255,6 +436,19 @@ func (rps *redisProductStore) GetServices(provider string) ([]types.Service, boo
 	return res, ok
 }
 
+func (rps *redisProductStore) StoreScrapeErrors(provider string, val []types.ScrapeError) {
+	rps.set(rps.getKey(cloudinfo.ScrapeErrorsKeyTemplate, provider), val)
+}
+
+func (rps *redisProductStore) GetScrapeErrors(provider string) ([]types.ScrapeError, bool) {
+	var (
+		res = make([]types.ScrapeError, 0)
+	)
+	_, ok := rps.get(rps.getKey(cloudinfo.ScrapeErrorsKeyTemplate, provider), &res)
+
+	return res, ok
+}
+
 func (rps *redisProductStore) getKey(keyTemplate string, args ...interface{}) string {
 	key := fmt.Sprintf(keyTemplate, args...)
 
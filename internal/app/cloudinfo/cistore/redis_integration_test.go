@@ -0,0 +1,70 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build integration
+// +build integration
+
+package cistore
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"logur.dev/logur"
+
+	"github.com/banzaicloud/cloudinfo/internal/cloudinfo/cloudinfoadapter"
+	"github.com/banzaicloud/cloudinfo/internal/cloudinfo/types"
+	"github.com/banzaicloud/cloudinfo/internal/platform/redis"
+)
+
+// TestRedisProductStore_VmRoundTrip requires a running redis instance
+// use the attached docker-compose.yaml compose file and run with: go test -tags integration
+func TestRedisProductStore_VmRoundTrip(t *testing.T) {
+	cfg := redis.Config{
+		Host:       "localhost",
+		Port:       6379,
+		Expiration: time.Second,
+	}
+
+	ps := NewRedisProductStore(cfg, cloudinfoadapter.NewLogger(&logur.TestLogger{}))
+
+	vms := []types.VMInfo{
+		{
+			Category:      "General purpose",
+			Type:          "m5.large",
+			OnDemandPrice: 0.096,
+			SpotPrice:     []types.ZonePrice{{Zone: "us-east-1a", Price: 0.035}},
+			Cpus:          2,
+			Mem:           8,
+			NtwPerf:       "Up to 10 Gigabit",
+			NtwPerfCat:    "high",
+			Zones:         []string{"us-east-1a"},
+			Attributes:    map[string]string{"cpu": "2", "memory": "8"},
+			CurrentGen:    true,
+		},
+	}
+
+	ps.StoreVm("amazon", "compute", "us-east-1", vms)
+
+	cached, ok := ps.GetVm("amazon", "compute", "us-east-1")
+	assert.True(t, ok, "the vms should be cached")
+	assert.Equal(t, vms, cached)
+
+	// the entry expires after the configured ttl
+	time.Sleep(2 * cfg.Expiration)
+
+	_, ok = ps.GetVm("amazon", "compute", "us-east-1")
+	assert.False(t, ok, "the vms should have expired")
+}
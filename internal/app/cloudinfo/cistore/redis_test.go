@@ -17,6 +17,7 @@ package cistore
 import (
 	"testing"
 
+	redigo "github.com/gomodule/redigo/redis"
 	"github.com/stretchr/testify/assert"
 	"logur.dev/logur"
 
@@ -42,3 +43,20 @@ func testRedisStore(t *testing.T) {
 	assert.True(t, ok)
 	assert.Equal(t, "status", status)
 }
+
+// scanReplyConn is a redis connection replying the same reply to every command
+type scanReplyConn struct {
+	redigo.Conn
+	reply interface{}
+}
+
+func (c scanReplyConn) Do(commandName string, args ...interface{}) (interface{}, error) {
+	return c.reply, nil
+}
+
+func TestScanKeys_malformedReply(t *testing.T) {
+	keys, err := scanKeys(scanReplyConn{reply: []interface{}{[]byte("0")}}, "amazon")
+
+	assert.Error(t, err, "a malformed SCAN reply should be reported")
+	assert.Nil(t, keys)
+}
@@ -0,0 +1,277 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cistore
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"emperror.dev/errors"
+
+	"github.com/banzaicloud/cloudinfo/internal/cloudinfo"
+	"github.com/banzaicloud/cloudinfo/internal/cloudinfo/types"
+)
+
+// keyPrefix is the common prefix of the keys generated from the cloudinfo key templates
+const keyPrefix = "/banzaicloud.com/cloudinfo/providers/"
+
+// storeSnapshot is the JSON document the content of the stores is exported to and imported from
+type storeSnapshot struct {
+	Providers map[string]*providerSnapshot `json:"providers"`
+}
+
+type providerSnapshot struct {
+	Status   string          `json:"status,omitempty"`
+	Services []types.Service `json:"services,omitempty"`
+	// ScrapeErrors holds the last scrape failures of the provider
+	ScrapeErrors []types.ScrapeError `json:"scrapeErrors,omitempty"`
+	// Prices holds the instance type prices per region
+	Prices map[string]map[string]types.Price `json:"prices,omitempty"`
+	// SpotPriceHistory holds the instance type spot price history per region
+	SpotPriceHistory map[string]map[string][]types.TimestampedPrice `json:"spotPriceHistory,omitempty"`
+	// ServiceDetails holds the service specific information per service
+	ServiceDetails map[string]*serviceSnapshot `json:"serviceDetails,omitempty"`
+}
+
+type serviceSnapshot struct {
+	Regions map[string]string `json:"regions,omitempty"`
+	// RegionDetails holds the region specific information per region
+	RegionDetails map[string]*regionSnapshot `json:"regionDetails,omitempty"`
+}
+
+type regionSnapshot struct {
+	Zones    []string                `json:"zones,omitempty"`
+	Vms      []types.VMInfo          `json:"vms,omitempty"`
+	Images   []types.Image           `json:"images,omitempty"`
+	Versions []types.LocationVersion `json:"versions,omitempty"`
+	Products *types.RegionProducts   `json:"products,omitempty"`
+	// CatalogChanges holds the instance types added and removed by the latest scrape
+	CatalogChanges *types.CatalogChanges `json:"catalogChanges,omitempty"`
+}
+
+func newStoreSnapshot() *storeSnapshot {
+	return &storeSnapshot{Providers: make(map[string]*providerSnapshot)}
+}
+
+// add decodes the raw (JSON) value of the store key into the snapshot
+// it returns false for keys not generated from the cloudinfo key templates
+func (s *storeSnapshot) add(key string, raw []byte) (bool, error) {
+	target := s.target(key)
+	if target == nil {
+		return false, nil
+	}
+
+	if err := json.Unmarshal(raw, target); err != nil {
+		return true, errors.WrapIfWithDetails(err, "failed to decode store entry", "key", key)
+	}
+
+	return true, nil
+}
+
+// target returns the snapshot field the value of the key is to be decoded into
+func (s *storeSnapshot) target(key string) interface{} {
+	if !strings.HasPrefix(key, keyPrefix) {
+		return nil
+	}
+
+	parts := strings.Split(strings.TrimSuffix(strings.TrimPrefix(key, keyPrefix), "/"), "/")
+	provider := parts[0]
+
+	switch {
+	case len(parts) == 2 && parts[1] == "status":
+		return &s.provider(provider).Status
+	case len(parts) == 2 && parts[1] == "services":
+		return &s.provider(provider).Services
+	case len(parts) == 2 && parts[1] == "scrapeerrors":
+		return &s.provider(provider).ScrapeErrors
+	case len(parts) == 4 && parts[1] == "services" && parts[3] == "regions":
+		return &s.service(provider, parts[2]).Regions
+	case len(parts) == 5 && parts[1] == "regions" && parts[3] == "prices":
+		ps := s.provider(provider)
+		if ps.Prices == nil {
+			ps.Prices = make(map[string]map[string]types.Price)
+		}
+		if ps.Prices[parts[2]] == nil {
+			ps.Prices[parts[2]] = make(map[string]types.Price)
+		}
+		return &priceTarget{prices: ps.Prices[parts[2]], instanceType: parts[4]}
+	case len(parts) == 5 && parts[1] == "regions" && parts[3] == "spotpricehistory":
+		ps := s.provider(provider)
+		if ps.SpotPriceHistory == nil {
+			ps.SpotPriceHistory = make(map[string]map[string][]types.TimestampedPrice)
+		}
+		if ps.SpotPriceHistory[parts[2]] == nil {
+			ps.SpotPriceHistory[parts[2]] = make(map[string][]types.TimestampedPrice)
+		}
+		return &historyTarget{history: ps.SpotPriceHistory[parts[2]], instanceType: parts[4]}
+	case len(parts) == 6 && parts[1] == "services" && parts[3] == "regions":
+		rs := s.region(provider, parts[2], parts[4])
+		switch parts[5] {
+		case "zones":
+			return &rs.Zones
+		case "vms":
+			return &rs.Vms
+		case "images":
+			return &rs.Images
+		case "versions":
+			return &rs.Versions
+		case "products":
+			return &rs.Products
+		case "catalogchanges":
+			return &rs.CatalogChanges
+		}
+	}
+
+	return nil
+}
+
+func (s *storeSnapshot) provider(provider string) *providerSnapshot {
+	if _, ok := s.Providers[provider]; !ok {
+		s.Providers[provider] = &providerSnapshot{}
+	}
+
+	return s.Providers[provider]
+}
+
+func (s *storeSnapshot) service(provider, service string) *serviceSnapshot {
+	ps := s.provider(provider)
+	if ps.ServiceDetails == nil {
+		ps.ServiceDetails = make(map[string]*serviceSnapshot)
+	}
+	if _, ok := ps.ServiceDetails[service]; !ok {
+		ps.ServiceDetails[service] = &serviceSnapshot{}
+	}
+
+	return ps.ServiceDetails[service]
+}
+
+func (s *storeSnapshot) region(provider, service, region string) *regionSnapshot {
+	ss := s.service(provider, service)
+	if ss.RegionDetails == nil {
+		ss.RegionDetails = make(map[string]*regionSnapshot)
+	}
+	if _, ok := ss.RegionDetails[region]; !ok {
+		ss.RegionDetails[region] = &regionSnapshot{}
+	}
+
+	return ss.RegionDetails[region]
+}
+
+// priceTarget decodes a price into the region's price map
+type priceTarget struct {
+	prices       map[string]types.Price
+	instanceType string
+}
+
+func (pt *priceTarget) UnmarshalJSON(data []byte) error {
+	var price types.Price
+	if err := json.Unmarshal(data, &price); err != nil {
+		return err
+	}
+
+	pt.prices[pt.instanceType] = price
+
+	return nil
+}
+
+// historyTarget decodes a spot price history into the region's history map
+type historyTarget struct {
+	history      map[string][]types.TimestampedPrice
+	instanceType string
+}
+
+func (ht *historyTarget) UnmarshalJSON(data []byte) error {
+	var history []types.TimestampedPrice
+	if err := json.Unmarshal(data, &history); err != nil {
+		return err
+	}
+
+	ht.history[ht.instanceType] = history
+
+	return nil
+}
+
+// entries returns the content of the snapshot as store keys and values
+func (s *storeSnapshot) entries() map[string]interface{} {
+	entries := make(map[string]interface{})
+
+	for provider, ps := range s.Providers {
+		if ps.Status != "" {
+			entries[fmt.Sprintf(cloudinfo.StatusKeyTemplate, provider)] = ps.Status
+		}
+		if ps.Services != nil {
+			entries[fmt.Sprintf(cloudinfo.ServicesKeyTemplate, provider)] = ps.Services
+		}
+		if ps.ScrapeErrors != nil {
+			entries[fmt.Sprintf(cloudinfo.ScrapeErrorsKeyTemplate, provider)] = ps.ScrapeErrors
+		}
+		for region, prices := range ps.Prices {
+			for instanceType, price := range prices {
+				entries[fmt.Sprintf(cloudinfo.PriceKeyTemplate, provider, region, instanceType)] = price
+			}
+		}
+		for region, histories := range ps.SpotPriceHistory {
+			for instanceType, history := range histories {
+				entries[fmt.Sprintf(cloudinfo.SpotPriceHistoryKeyTemplate, provider, region, instanceType)] = history
+			}
+		}
+
+		for service, ss := range ps.ServiceDetails {
+			if ss.Regions != nil {
+				entries[fmt.Sprintf(cloudinfo.RegionKeyTemplate, provider, service)] = ss.Regions
+			}
+
+			for region, rs := range ss.RegionDetails {
+				if rs.Zones != nil {
+					entries[fmt.Sprintf(cloudinfo.ZoneKeyTemplate, provider, service, region)] = rs.Zones
+				}
+				if rs.Vms != nil {
+					entries[fmt.Sprintf(cloudinfo.VmKeyTemplate, provider, service, region)] = rs.Vms
+				}
+				if rs.Images != nil {
+					entries[fmt.Sprintf(cloudinfo.ImageKeyTemplate, provider, service, region)] = rs.Images
+				}
+				if rs.Versions != nil {
+					entries[fmt.Sprintf(cloudinfo.VersionKeyTemplate, provider, service, region)] = rs.Versions
+				}
+				if rs.Products != nil {
+					entries[fmt.Sprintf(cloudinfo.ProductsKeyTemplate, provider, service, region)] = *rs.Products
+				}
+				if rs.CatalogChanges != nil {
+					entries[fmt.Sprintf(cloudinfo.CatalogChangesKeyTemplate, provider, service, region)] = *rs.CatalogChanges
+				}
+			}
+		}
+	}
+
+	return entries
+}
+
+// write encodes the snapshot as JSON into the writer
+func (s *storeSnapshot) write(w io.Writer) error {
+	return errors.WrapIf(json.NewEncoder(w).Encode(s), "failed to encode the store snapshot")
+}
+
+// readStoreSnapshot decodes a JSON snapshot from the reader
+func readStoreSnapshot(r io.Reader) (*storeSnapshot, error) {
+	s := newStoreSnapshot()
+	if err := json.NewDecoder(r).Decode(s); err != nil {
+		return nil, errors.WrapIf(err, "failed to decode the store snapshot")
+	}
+
+	return s, nil
+}
@@ -186,9 +186,10 @@ func (sl *storeCloudInfoLoader) LoadZones(provider string, service string, regio
 			return
 		}
 
+		excludedZones := cloudinfo.NewZoneSet(region.Data.Zones.Data)
 		filteredZones := make([]string, 0, len(zones))
 		for _, zone := range zones {
-			if cloudinfo.Contains(region.Data.Zones.Data, zone) {
+			if excludedZones.Has(zone) {
 				continue
 			}
 			filteredZones = append(filteredZones, zone)
@@ -203,9 +204,10 @@ func (sl *storeCloudInfoLoader) LoadZones(provider string, service string, regio
 			return
 		}
 
+		sourceZones := cloudinfo.NewZoneSet(zones)
 		filteredZones := make([]string, 0, len(zones))
 		for _, zone := range region.Data.Zones.Data {
-			if cloudinfo.Contains(zones, zone) {
+			if sourceZones.Has(zone) {
 				filteredZones = append(filteredZones, zone)
 			}
 		}
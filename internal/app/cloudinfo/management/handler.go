@@ -19,6 +19,7 @@ import (
 	"net/http"
 
 	"emperror.dev/emperror"
+	"emperror.dev/errors"
 	"github.com/gin-gonic/gin"
 	"github.com/mitchellh/mapstructure"
 
@@ -38,7 +39,7 @@ func (mrh *mngmntRouteHandler) Export() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		mrh.log.Info("exporting cloud information")
 		if err := mrh.cis.Export(c.Writer); err != nil {
-			c.JSON(http.StatusInternalServerError, err.Error())
+			c.JSON(snapshotErrorStatus(err), err.Error())
 			return
 		}
 
@@ -59,7 +60,7 @@ func (mrh *mngmntRouteHandler) Import() gin.HandlerFunc {
 
 		mrh.log.Info("loading cloud info", map[string]interface{}{"file": fh.Filename, "size": fh.Size})
 		if err := mrh.cis.Import(f); err != nil {
-			c.JSON(http.StatusInternalServerError, err)
+			c.JSON(snapshotErrorStatus(err), err)
 			return
 		}
 
@@ -67,6 +68,15 @@ func (mrh *mngmntRouteHandler) Import() gin.HandlerFunc {
 	}
 }
 
+// snapshotErrorStatus returns the status code of the failed export or import
+func snapshotErrorStatus(err error) int {
+	if errors.Is(err, cloudinfo.ErrSnapshotNotSupported) {
+		return http.StatusNotImplemented
+	}
+
+	return http.StatusInternalServerError
+}
+
 // Refresh handler that triggers the refresh process for a provider
 func (mrh *mngmntRouteHandler) Refresh() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -19,6 +19,8 @@ import (
 
 	"emperror.dev/emperror"
 	evbus "github.com/asaskevich/EventBus"
+
+	"github.com/banzaicloud/cloudinfo/internal/cloudinfo/types"
 )
 
 // EventBus event bus abstraction for the application to decouple vendor or lib specifics
@@ -29,10 +31,19 @@ type EventBus interface {
 
 	// SubscribeScrapingComplete
 	SubscribeScrapingComplete(provider string, callback interface{})
+
+	// PublishPriceChange emits a "price changed" message
+	PublishPriceChange(change types.PriceChangeEvent)
+
+	// SubscribePriceChanges registers the callback for the price changes of every provider
+	// the callback is run by the publisher, so it must not block
+	SubscribePriceChanges(callback func(change types.PriceChangeEvent))
 }
 
 const (
 	topicPrefix = "load:service"
+
+	priceChangeTopic = "price:change"
 )
 
 // defaultEventBus default EventBus component implementation backed by https://github.com/asaskevich/EventBus
@@ -51,6 +62,16 @@ func (eb *defaultEventBus) SubscribeScrapingComplete(provider string, callback i
 	}
 }
 
+func (eb *defaultEventBus) PublishPriceChange(change types.PriceChangeEvent) {
+	eb.eventBus.Publish(priceChangeTopic, change)
+}
+
+func (eb *defaultEventBus) SubscribePriceChanges(callback func(change types.PriceChangeEvent)) {
+	if err := eb.eventBus.Subscribe(priceChangeTopic, callback); err != nil {
+		eb.errorHandler.Handle(err)
+	}
+}
+
 func (eb *defaultEventBus) providerScrapingTopic(provider string) string {
 	return strings.Join([]string{topicPrefix, provider}, ":")
 }
@@ -0,0 +1,77 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudinfo
+
+import (
+	"strconv"
+
+	"github.com/banzaicloud/cloudinfo/internal/cloudinfo/types"
+)
+
+// AttributeInfoer is an optional interface implemented by the infoers supporting attributes beyond cpu and memory
+type AttributeInfoer interface {
+	// Attributes returns the names of the attributes the products of the provider can be filtered by
+	Attributes() []string
+}
+
+// supportedAttributes returns the attributes declared by the infoer, cpu and memory if it doesn't declare any
+func supportedAttributes(infoer CloudInfoer) []string {
+	if attributeInfoer, ok := infoer.(AttributeInfoer); ok {
+		if attributes := attributeInfoer.Attributes(); len(attributes) > 0 {
+			return attributes
+		}
+	}
+
+	return []string{types.CPU, types.Memory}
+}
+
+// fillAttributes sets the declared attributes of the vm that are backed by a vm field but not yet set by the infoer
+func fillAttributes(vm *types.VMInfo, attributes []string) {
+	for _, attribute := range attributes {
+		if attribute != types.GPU {
+			// cpu and memory are read from the vm fields, the rest is expected to be set by the infoer
+			continue
+		}
+		if _, ok := vm.Attributes[attribute]; ok {
+			continue
+		}
+		if vm.Attributes == nil {
+			vm.Attributes = make(map[string]string)
+		}
+		vm.Attributes[attribute] = strconv.FormatFloat(vm.Gpus, 'f', -1, 64)
+	}
+}
+
+// attributeValue returns the value of the attribute of the vm, false if the vm doesn't have the attribute
+func attributeValue(vm types.VMInfo, attribute string) (float64, bool) {
+	switch attribute {
+	case types.CPU:
+		return vm.Cpus, true
+	case types.Memory:
+		return vm.Mem, true
+	}
+
+	raw, ok := vm.Attributes[attribute]
+	if !ok {
+		return 0, false
+	}
+
+	value, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return 0, false
+	}
+
+	return value, true
+}
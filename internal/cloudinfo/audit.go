@@ -0,0 +1,95 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudinfo
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+
+	"emperror.dev/errors"
+
+	"github.com/banzaicloud/cloudinfo/internal/cloudinfo/types"
+)
+
+// AuditSink records the prices observed by the scraping managers, eg.: to retain a price history for compliance
+type AuditSink interface {
+	// RecordPrice records the price of the instance type in the region observed at the given time
+	RecordPrice(ts time.Time, provider, region, instanceType string, price types.Price)
+}
+
+// NewNoOpAuditSink creates an audit sink discarding the prices
+func NewNoOpAuditSink() AuditSink {
+	return noOpAuditSink{}
+}
+
+type noOpAuditSink struct{}
+
+func (noOpAuditSink) RecordPrice(ts time.Time, provider, region, instanceType string, price types.Price) {
+}
+
+// priceRecord a line of the price audit log
+type priceRecord struct {
+	Time         time.Time   `json:"time"`
+	Provider     string      `json:"provider"`
+	Region       string      `json:"region"`
+	InstanceType string      `json:"instanceType"`
+	Price        types.Price `json:"price"`
+}
+
+// FileAuditSink appends the prices to a file as JSON lines
+type FileAuditSink struct {
+	file *os.File
+	log  Logger
+
+	// serializes the writes of the scrape goroutines, so that the lines are not interleaved
+	mu      sync.Mutex
+	encoder *json.Encoder
+}
+
+// NewFileAuditSink creates an audit sink appending the prices to the file, the file is created if it doesn't exist
+func NewFileAuditSink(path string, log Logger) (*FileAuditSink, error) {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(err, "failed to open the price audit log", "path", path)
+	}
+
+	return &FileAuditSink{
+		file:    file,
+		log:     log.WithFields(map[string]interface{}{"component": "price-audit", "path": path}),
+		encoder: json.NewEncoder(file),
+	}, nil
+}
+
+// RecordPrice appends the price to the audit log, failures are logged as the scrapes shouldn't fail because of them
+func (s *FileAuditSink) RecordPrice(ts time.Time, provider, region, instanceType string, price types.Price) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record := priceRecord{Time: ts, Provider: provider, Region: region, InstanceType: instanceType, Price: price}
+	if err := s.encoder.Encode(record); err != nil {
+		s.log.Error("failed to record price", map[string]interface{}{"provider": provider, "region": region,
+			"instanceType": instanceType, "error": err})
+	}
+}
+
+// Close closes the audit log file
+func (s *FileAuditSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return errors.WrapIf(s.file.Close(), "failed to close the price audit log")
+}
@@ -0,0 +1,57 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudinfo
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/banzaicloud/cloudinfo/internal/cloudinfo/types"
+)
+
+func TestFileAuditSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "prices.log")
+	ts := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	// the records of the reopened log are appended
+	for _, instanceType := range []string{"m5.large", "m5.xlarge"} {
+		sink, err := NewFileAuditSink(path, cloudinfoLogger)
+		assert.Nil(t, err, "the error should be nil")
+		sink.RecordPrice(ts, "amazon", "us-east-1", instanceType, types.Price{OnDemandPrice: 0.096})
+		assert.Nil(t, sink.Close(), "the error should be nil")
+	}
+
+	f, err := os.Open(path)
+	assert.Nil(t, err, "the error should be nil")
+	defer f.Close()
+
+	var records []priceRecord
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var record priceRecord
+		assert.Nil(t, json.Unmarshal(scanner.Bytes(), &record), "every line should be a record")
+		records = append(records, record)
+	}
+
+	assert.Len(t, records, 2)
+	assert.Equal(t, priceRecord{Time: ts, Provider: "amazon", Region: "us-east-1", InstanceType: "m5.xlarge",
+		Price: types.Price{OnDemandPrice: 0.096}}, records[1])
+}
@@ -0,0 +1,130 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudinfo
+
+import (
+	"sync"
+	"time"
+)
+
+// circuitState the state of a circuit breaker, the values are reported as metrics
+type circuitState int
+
+const (
+	// circuitClosed calls are let through
+	circuitClosed circuitState = iota
+	// circuitHalfOpen a single call is let through to test whether the calls succeed again
+	circuitHalfOpen
+	// circuitOpen calls are rejected until the cooldown passes
+	circuitOpen
+)
+
+// circuitBreaker stops calling a failing endpoint after a number of consecutive failures
+// a nil breaker lets every call through
+type circuitBreaker struct {
+	// the number of consecutive failures opening the breaker
+	threshold int
+	// the time the breaker stays open before letting a test call through
+	cooldown time.Duration
+
+	mu       sync.Mutex
+	state    circuitState
+	failures int
+	openedAt time.Time
+	// set while the test call of the half open breaker is in progress
+	probing bool
+
+	now func() time.Time
+}
+
+// newCircuitBreaker creates a circuit breaker opening after threshold consecutive failures
+// nil is returned if the threshold is not positive, disabling the breaker
+func newCircuitBreaker(threshold int, cooldown time.Duration) *circuitBreaker {
+	if threshold < 1 {
+		return nil
+	}
+
+	return &circuitBreaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+		now:       time.Now,
+	}
+}
+
+// allow checks whether a call can be made, the open breaker turns half open once the cooldown passes
+func (cb *circuitBreaker) allow() bool {
+	if cb == nil {
+		return true
+	}
+
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	if cb.state == circuitOpen && cb.now().Sub(cb.openedAt) >= cb.cooldown {
+		cb.state = circuitHalfOpen
+	}
+
+	switch cb.state {
+	case circuitClosed:
+		return true
+	case circuitHalfOpen:
+		if cb.probing {
+			return false
+		}
+		cb.probing = true
+		return true
+	default:
+		return false
+	}
+}
+
+// success records a successful call, closing the breaker
+func (cb *circuitBreaker) success() {
+	if cb == nil {
+		return
+	}
+
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	cb.state, cb.failures, cb.probing = circuitClosed, 0, false
+}
+
+// failure records a failed call, the breaker is opened if the threshold is reached or the test call failed
+func (cb *circuitBreaker) failure() {
+	if cb == nil {
+		return
+	}
+
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	cb.failures++
+	if cb.state == circuitHalfOpen || cb.failures >= cb.threshold {
+		cb.state, cb.openedAt, cb.probing = circuitOpen, cb.now(), false
+	}
+}
+
+// currentState returns the state of the breaker
+func (cb *circuitBreaker) currentState() circuitState {
+	if cb == nil {
+		return circuitClosed
+	}
+
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	return cb.state
+}
@@ -0,0 +1,65 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudinfo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	now := time.Now()
+	cb := newCircuitBreaker(2, time.Minute)
+	cb.now = func() time.Time { return now }
+
+	assert.True(t, cb.allow(), "the closed breaker should let the calls through")
+	cb.failure()
+	assert.Equal(t, circuitClosed, cb.currentState(), "the breaker should stay closed below the threshold")
+	cb.success()
+	cb.failure()
+	assert.Equal(t, circuitClosed, cb.currentState(), "the success should reset the consecutive failures")
+
+	cb.failure()
+	assert.Equal(t, circuitOpen, cb.currentState(), "the breaker should open at the threshold")
+	assert.False(t, cb.allow(), "the open breaker should reject the calls")
+
+	now = now.Add(time.Minute)
+	assert.True(t, cb.allow(), "a test call should be let through after the cooldown")
+	assert.Equal(t, circuitHalfOpen, cb.currentState())
+	assert.False(t, cb.allow(), "only a single test call should be let through")
+
+	cb.failure()
+	assert.Equal(t, circuitOpen, cb.currentState(), "the failed test call should reopen the breaker")
+	assert.False(t, cb.allow(), "the reopened breaker should reject the calls")
+
+	now = now.Add(time.Minute)
+	assert.True(t, cb.allow(), "a test call should be let through after the cooldown")
+	cb.success()
+	assert.Equal(t, circuitClosed, cb.currentState(), "the successful test call should close the breaker")
+	assert.True(t, cb.allow(), "the closed breaker should let the calls through")
+}
+
+func TestCircuitBreaker_disabled(t *testing.T) {
+	cb := newCircuitBreaker(0, time.Minute)
+	assert.Nil(t, cb, "the breaker should be disabled")
+
+	for i := 0; i < 10; i++ {
+		cb.failure()
+	}
+	assert.True(t, cb.allow(), "the disabled breaker should let the calls through")
+	assert.Equal(t, circuitClosed, cb.currentState())
+}
@@ -15,10 +15,19 @@
 package cloudinfo
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"emperror.dev/errors"
 
+	"github.com/banzaicloud/cloudinfo/internal/app/cloudinfo/messaging"
+	"github.com/banzaicloud/cloudinfo/internal/cloudinfo/metrics"
 	"github.com/banzaicloud/cloudinfo/internal/cloudinfo/types"
 )
 
@@ -29,39 +38,98 @@ type cloudInfo struct {
 	log            Logger
 	providers      []string
 	cloudInfoStore CloudInfoStore
+	// data older than this is flagged as stale, zero disables the check
+	staleThreshold time.Duration
+	// product details older than this are not served, zero disables the check
+	maxAge time.Duration
+	// converts the stored prices into other currencies
+	currencyConverter CurrencyConverter
+	// providers able to retrieve spot price history
+	spotPriceHistoryProviders []string
+	// reports the cache hits and misses of the read methods
+	metrics metrics.Reporter
+
+	priceSubscriptionsMu sync.Mutex
+	// the channels of the price change subscribers keyed by provider and region
+	priceSubscriptions map[string]map[chan types.PriceChangeEvent]struct{}
+}
+
+// CloudInfoOptions holds the optional settings of the cloud info, the zero value disables all of them
+type CloudInfoOptions struct {
+	// StaleThreshold is the age above which the data is flagged as stale, zero disables the check
+	StaleThreshold time.Duration
+	// MaxAge is the age above which the product details are not served (ErrDataTooStale is returned instead), zero disables the check
+	MaxAge time.Duration
+	// CurrencyConverter converts the stored prices into other currencies, the identity converter is used if it's nil
+	CurrencyConverter CurrencyConverter
+	// SpotPriceHistoryProviders lists the providers able to retrieve spot price history
+	SpotPriceHistoryProviders []string
+	// EventBus delivers the published price changes to the price subscribers, no changes are delivered if it's nil
+	EventBus messaging.EventBus
+	// Reporter reports the cache hits and misses of the read methods, these are not reported if it's nil
+	Reporter metrics.Reporter
 }
 
-// NewCloudInfo creates a new cloudInfo instance
+// NewCloudInfo creates a new cloudInfo instance with the default options
 func NewCloudInfo(providers []string, ciStore CloudInfoStore, logger Logger) (*cloudInfo, error) {
+	return NewCloudInfoWithOptions(providers, ciStore, logger, CloudInfoOptions{})
+}
+
+// NewCloudInfoWithOptions creates a new cloudInfo instance with the given options, see CloudInfoOptions
+func NewCloudInfoWithOptions(providers []string, ciStore CloudInfoStore, logger Logger, options CloudInfoOptions) (*cloudInfo, error) {
 	if providers == nil || ciStore == nil {
 		return nil, errors.New("could not create product infoer")
 	}
 
+	converter := options.CurrencyConverter
+	if converter == nil {
+		converter = NewIdentityCurrencyConverter()
+	}
+
+	reporter := options.Reporter
+	if reporter == nil {
+		reporter = metrics.NewNoOpMetricsReporter()
+	}
+
 	pi := cloudInfo{
-		providers:      providers,
-		cloudInfoStore: ciStore,
-		log:            logger.WithFields(map[string]interface{}{"component": "cloudInfo"}),
+		providers:                 providers,
+		cloudInfoStore:            ciStore,
+		staleThreshold:            options.StaleThreshold,
+		maxAge:                    options.MaxAge,
+		currencyConverter:         converter,
+		spotPriceHistoryProviders: options.SpotPriceHistoryProviders,
+		metrics:                   reporter,
+		priceSubscriptions:        make(map[string]map[chan types.PriceChangeEvent]struct{}),
+		log:                       logger.WithFields(map[string]interface{}{"component": "cloudInfo"}),
+	}
+
+	if options.EventBus != nil {
+		options.EventBus.SubscribePriceChanges(pi.deliverPriceChange)
 	}
+
 	return &pi, nil
 }
 
-// GetProviders returns the supported providers
+// GetProviders returns the supported providers ordered by name
+// providers with no cached service information (eg.: not yet scraped) are skipped
 func (cpi *cloudInfo) GetProviders() ([]types.Provider, error) {
-	var (
-		providers []types.Provider
-		provider  types.Provider
-		err       error
-	)
+	providers := make([]types.Provider, 0, len(cpi.providers))
 
 	// iterate over supported provider names only
 	for _, pn := range cpi.providers {
-		if provider, err = cpi.GetProvider(pn); err != nil {
-			return nil, err
+		provider, err := cpi.GetProvider(pn)
+		if err != nil {
+			cpi.log.Debug("skipping provider", map[string]interface{}{"provider": pn, "error": err.Error()})
+			continue
 		}
 
 		providers = append(providers, provider)
 	}
 
+	sort.Slice(providers, func(i, j int) bool {
+		return providers[i].Provider < providers[j].Provider
+	})
+
 	return providers, nil
 }
 
@@ -73,7 +141,7 @@ func (cpi *cloudInfo) GetProvider(provider string) (types.Provider, error) {
 	)
 
 	if !cpi.providerEnabled(provider) {
-		return types.Provider{}, errors.NewWithDetails("unsupported provider", "provider", provider)
+		return types.Provider{}, errors.WithDetails(ErrProviderNotSupported, "provider", provider)
 	}
 
 	if srvcs, err = cpi.GetServices(provider); err != nil {
@@ -100,50 +168,295 @@ func (cpi *cloudInfo) providerEnabled(provider string) bool {
 	return enabled
 }
 
+// reportCacheLookup reports the outcome of reading the cached information of the service
+func (cpi *cloudInfo) reportCacheLookup(provider, service string, hit bool) {
+	if hit {
+		cpi.metrics.ReportCacheHit(provider, service)
+		return
+	}
+
+	cpi.metrics.ReportCacheMiss(provider, service)
+}
+
+// validate checks whether the provider, service and region are supported
+// empty service and region are not checked; neither are the service and region if the lists they're checked against are not cached
+func (cpi *cloudInfo) validate(provider, service, region string) error {
+	if !cpi.providerEnabled(provider) {
+		return errors.WithDetails(ErrProviderNotSupported, "provider", provider)
+	}
+
+	if service == "" {
+		return nil
+	}
+
+	if services, ok := cpi.cloudInfoStore.GetServices(provider); ok {
+		supported := false
+		for _, s := range services {
+			if s.ServiceName() == service {
+				supported = true
+				break
+			}
+		}
+
+		if !supported {
+			return errors.WithDetails(ErrServiceNotSupported, "provider", provider, "service", service)
+		}
+	}
+
+	if region == "" {
+		return nil
+	}
+
+	if regions, ok := cpi.cloudInfoStore.GetRegions(provider, service); ok {
+		if _, supported := regions[region]; !supported {
+			return errors.WithDetails(ErrRegionNotSupported, "provider", provider, "service", service, "region", region)
+		}
+	}
+
+	return nil
+}
+
 // GetZones returns the availability zones in a region
 func (cpi *cloudInfo) GetZones(provider, service, region string) ([]string, error) {
-	if cachedVal, ok := cpi.cloudInfoStore.GetZones(provider, service, region); ok {
+	if err := cpi.validate(provider, service, region); err != nil {
+		return nil, err
+	}
+
+	cachedVal, ok := cpi.cloudInfoStore.GetZones(provider, service, region)
+	cpi.reportCacheLookup(provider, service, ok)
+	if ok {
 		return cachedVal, nil
 	}
 
-	return nil, errors.NewWithDetails("zones not yet cached", "provider", provider, "region", region)
+	return nil, newNotCachedError("zones", "provider", provider, "region", region)
 }
 
 // GetRegions gets the regions for the provided provider
 func (cpi *cloudInfo) GetRegions(provider, service string) (map[string]string, error) {
-	if cachedVal, ok := cpi.cloudInfoStore.GetRegions(provider, service); ok {
+	if err := cpi.validate(provider, service, ""); err != nil {
+		return nil, err
+	}
+
+	cachedVal, ok := cpi.cloudInfoStore.GetRegions(provider, service)
+	cpi.reportCacheLookup(provider, service, ok)
+	if ok {
 		return cachedVal, nil
 	}
 
-	return nil, errors.NewWithDetails("regions not yet cached", "provider", provider, "services", service)
+	return nil, newNotCachedError("regions", "provider", provider, "services", service)
+}
+
+// GetRegionInfo returns the regions of the service along with their continent and approximate coordinates
+func (cpi *cloudInfo) GetRegionInfo(provider, service string) ([]types.RegionInfo, error) {
+	regions, err := cpi.GetRegions(provider, service)
+	if err != nil {
+		return nil, err
+	}
+
+	return regionInfos(provider, regions), nil
+}
+
+// FindNearestRegion returns the region of the service closest to the location by great-circle distance
+func (cpi *cloudInfo) FindNearestRegion(provider, service string, lat, lng float64) (types.RegionInfo, error) {
+	regions, err := cpi.GetRegions(provider, service)
+	if err != nil {
+		return types.RegionInfo{}, err
+	}
+
+	region, ok := nearestRegion(provider, regions, lat, lng)
+	if !ok {
+		return types.RegionInfo{}, errors.WithDetails(ErrRegionLocationUnknown, "provider", provider, "service", service)
+	}
+
+	return region, nil
+}
+
+// FindInstanceTypeRegions returns the regions of the service having the instance type cached, ordered by region id
+func (cpi *cloudInfo) FindInstanceTypeRegions(provider, service, instanceType string) ([]string, error) {
+	regions, err := cpi.GetRegions(provider, service)
+	if err != nil {
+		return nil, err
+	}
+
+	found := make([]string, 0)
+	for regionId := range regions {
+		vms, _ := cpi.cloudInfoStore.GetVm(provider, service, regionId)
+		for _, vm := range vms {
+			if vm.Type == instanceType {
+				found = append(found, regionId)
+				break
+			}
+		}
+	}
+
+	sort.Strings(found)
+
+	return found, nil
+}
+
+// GetRegionsWithCounts returns the number of cached instance types keyed by the regions of the service
+// the count is zero for the regions with no cached instance types
+func (cpi *cloudInfo) GetRegionsWithCounts(provider, service string) (map[string]int, error) {
+	regions, err := cpi.GetRegions(provider, service)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int, len(regions))
+	for regionId := range regions {
+		vms, _ := cpi.cloudInfoStore.GetVm(provider, service, regionId)
+		counts[regionId] = len(vms)
+	}
+
+	return counts, nil
 }
 
 func (cpi *cloudInfo) GetServices(provider string) ([]types.Service, error) {
-	if cachedVal, ok := cpi.cloudInfoStore.GetServices(provider); ok {
-		return cachedVal, nil
+	if err := cpi.validate(provider, "", ""); err != nil {
+		return nil, err
+	}
+
+	cachedVal, ok := cpi.cloudInfoStore.GetServices(provider)
+	cpi.reportCacheLookup(provider, "N/A", ok)
+	if ok {
+		// the cached services are left intact, the display names are set on a copy
+		services := make([]types.Service, len(cachedVal))
+		for i, service := range cachedVal {
+			service.Label = types.GetServiceDisplayName(provider, service.Service)
+			services[i] = service
+		}
+
+		return services, nil
+	}
+
+	return nil, newNotCachedError("services", "provider", provider)
+}
+
+// ServiceCapability a feature of a service derived from the cached information of the service
+type ServiceCapability string
+
+const (
+	// ServiceImagesCapability the service has images cached in at least one of its regions
+	ServiceImagesCapability ServiceCapability = "images"
+	// ServiceVersionsCapability the service has kubernetes versions cached in at least one of its regions
+	ServiceVersionsCapability ServiceCapability = "versions"
+)
+
+// GetServicesWithImages returns the services of the provider having images, eg.: the ones node pools can be created for
+func (cpi *cloudInfo) GetServicesWithImages(provider string) ([]types.Service, error) {
+	return cpi.GetServicesWithCapabilities(provider, ServiceImagesCapability)
+}
+
+// GetServicesWithCapabilities returns the services of the provider having all the capabilities
+func (cpi *cloudInfo) GetServicesWithCapabilities(provider string, capabilities ...ServiceCapability) ([]types.Service, error) {
+	services, err := cpi.GetServices(provider)
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := make([]types.Service, 0, len(services))
+	for _, service := range services {
+		if cpi.hasCapabilities(provider, service.ServiceName(), capabilities) {
+			filtered = append(filtered, service)
+		}
+	}
+
+	return filtered, nil
+}
+
+// hasCapabilities checks whether the service has every capability in any of its cached regions
+func (cpi *cloudInfo) hasCapabilities(provider, service string, capabilities []ServiceCapability) bool {
+	regions, _ := cpi.cloudInfoStore.GetRegions(provider, service)
+
+	for _, capability := range capabilities {
+		found := false
+		for region := range regions {
+			if cpi.hasCapability(provider, service, region, capability) {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
 	}
 
-	return nil, errors.NewWithDetails("services not yet cached", "provider", provider)
+	return true
+}
+
+func (cpi *cloudInfo) hasCapability(provider, service, region string, capability ServiceCapability) bool {
+	switch capability {
+	case ServiceImagesCapability:
+		images, _ := cpi.cloudInfoStore.GetImage(provider, service, region)
+		return len(images) > 0
+	case ServiceVersionsCapability:
+		versions, _ := cpi.cloudInfoStore.GetVersion(provider, service, region)
+		return len(versions) > 0
+	default:
+		return false
+	}
 }
 
 // GetProductDetails retrieves product details form the given provider and region
 func (cpi *cloudInfo) GetProductDetails(provider, service, region string) ([]types.ProductDetails, error) {
-	vms, ok := cpi.cloudInfoStore.GetVm(provider, service, region)
+	return cpi.FilterProductDetails(provider, service, region, ProductFilter{})
+}
+
+// FilterProductDetails retrieves the product details form the given provider and region that satisfy the filter
+func (cpi *cloudInfo) FilterProductDetails(provider, service, region string, filter ProductFilter) ([]types.ProductDetails, error) {
+	if err := cpi.validate(provider, service, region); err != nil {
+		return nil, err
+	}
+
+	if err := cpi.checkMaxAge(provider); err != nil {
+		return nil, err
+	}
+
+	products, ok := cpi.regionProducts(provider, service, region)
+	cpi.reportCacheLookup(provider, service, ok)
 	if !ok {
 		cpi.log.Debug("VMs not yet cached")
-		return nil, errors.NewWithDetails("VMs not yet cached", "provider", provider, "service", service, "region", region)
+		return nil, newNotCachedError("VMs", "provider", provider, "service", service, "region", region)
 	}
 
-	details := make([]types.ProductDetails, 0, len(vms))
-	for _, vm := range vms {
+	zones, _ := cpi.cloudInfoStore.GetZones(provider, service, region)
+	reportsGen := reportsCurrentGen(products.Vms)
+
+	details := make([]types.ProductDetails, 0, len(products.Vms))
+	for _, vm := range products.Vms {
+		if !reportsGen {
+			// every instance type is considered current if the provider doesn't report generations
+			vm.CurrentGen = true
+		}
+
+		if !applyProductFilter(vm, filter) {
+			continue
+		}
+
 		pd := types.NewProductDetails(vm)
-		cachedVal, ok := cpi.cloudInfoStore.GetPrice(provider, region, vm.Type)
+		cachedVal, ok := products.Prices[vm.Type]
 		if !ok {
 			cpi.log.Debug("price info not yet cached", map[string]interface{}{"instanceType": vm.Type})
 		}
+		pd.PriceAvailable = ok
+
+		var spotPrices types.SpotPriceInfo
+		if filter.includesPricing(SpotPricing) {
+			spotPrices = cachedVal.SpotPrice
+			for zone, price := range cachedVal.SpotPrice {
+				pd.SpotPrice = append(pd.SpotPrice, *types.NewZonePrice(zone, price))
+			}
+			for zone, price := range cachedVal.SmoothedSpotPrice {
+				pd.SpotPriceSmoothed = append(pd.SpotPriceSmoothed, *types.NewZonePrice(zone, price))
+			}
+		} else {
+			pd.SpotPrice = nil
+		}
 
-		for zone, price := range cachedVal.SpotPrice {
-			pd.SpotPrice = append(pd.SpotPrice, *types.NewZonePrice(zone, price))
+		pd.ZonePrices = zonePrices(pd.OnDemandPrice, spotPrices, zones)
+		if filter.includesPricing(ReservedPricing) {
+			pd.ReservedPrice = cachedVal.ReservedPrice
 		}
 
 		details = append(details, *pd)
@@ -152,31 +465,637 @@ func (cpi *cloudInfo) GetProductDetails(provider, service, region string) ([]typ
 	return details, nil
 }
 
+// GetProductDetailsMultiRegion retrieves the product details of the service in the given regions keyed by region
+// regions with no cached products are skipped, an error is returned only if none of the regions is cached
+func (cpi *cloudInfo) GetProductDetailsMultiRegion(provider, service string, regions []string) (map[string][]types.ProductDetails, error) {
+	if err := cpi.validate(provider, service, ""); err != nil {
+		return nil, err
+	}
+
+	details := make(map[string][]types.ProductDetails, len(regions))
+	missing := make([]string, 0)
+	for _, region := range regions {
+		regionDetails, err := cpi.GetProductDetails(provider, service, region)
+		if errors.Is(err, ErrNotCached) || errors.Is(err, ErrRegionNotSupported) {
+			missing = append(missing, region)
+			continue
+		}
+		if err != nil {
+			return nil, errors.WithDetails(err, "region", region)
+		}
+
+		details[region] = regionDetails
+	}
+
+	if len(missing) > 0 {
+		cpi.log.Debug("skipped regions with no cached products", map[string]interface{}{"provider": provider, "service": service, "regions": missing})
+	}
+
+	if len(details) == 0 && len(regions) > 0 {
+		return nil, newNotCachedError("VMs", "provider", provider, "service", service, "regions", missing)
+	}
+
+	return details, nil
+}
+
+// GetProductDetailsETag returns a hash of the cached products, prices and zones of the region
+// the hash changes whenever the product details served for the region change, so it can be used as an HTTP ETag
+func (cpi *cloudInfo) GetProductDetailsETag(provider, service, region string) (string, error) {
+	if err := cpi.validate(provider, service, region); err != nil {
+		return "", err
+	}
+
+	products, ok := cpi.regionProducts(provider, service, region)
+	if !ok {
+		return "", newNotCachedError("VMs", "provider", provider, "service", service, "region", region)
+	}
+
+	// the VMs are ordered by type, the map keys are ordered by the encoder
+	vms := make([]types.VMInfo, len(products.Vms))
+	copy(vms, products.Vms)
+	sort.SliceStable(vms, func(i, j int) bool {
+		return vms[i].Type < vms[j].Type
+	})
+
+	// the zone prices of the product details are broken down by the zones of the region
+	cachedZones, _ := cpi.cloudInfoStore.GetZones(provider, service, region)
+	zones := make([]string, len(cachedZones))
+	copy(zones, cachedZones)
+	sort.Strings(zones)
+
+	raw, err := json.Marshal(struct {
+		types.RegionProducts
+		Zones []string `json:"zones"`
+	}{
+		RegionProducts: types.RegionProducts{Vms: vms, Prices: products.Prices},
+		Zones:          zones,
+	})
+	if err != nil {
+		return "", errors.WrapIfWithDetails(err, "failed to encode products", "provider", provider, "service", service, "region", region)
+	}
+
+	hash := sha256.Sum256(raw)
+
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// reportsCurrentGen checks whether the generation of the VMs is reported, that is any of them is flagged as current
+func reportsCurrentGen(vms []types.VMInfo) bool {
+	for _, vm := range vms {
+		if vm.CurrentGen {
+			return true
+		}
+	}
+
+	return false
+}
+
+// regionProducts retrieves the VMs of the region along with their prices
+// the products stored by the scraper are read in a single lookup, so they're consistent with their prices;
+// VMs stored otherwise (eg.: by the service loader) are joined with the prices here
+func (cpi *cloudInfo) regionProducts(provider, service, region string) (types.RegionProducts, bool) {
+	if products, ok := cpi.cloudInfoStore.GetRegionProducts(provider, service, region); ok {
+		return products, true
+	}
+
+	vms, ok := cpi.cloudInfoStore.GetVm(provider, service, region)
+	if !ok {
+		return types.RegionProducts{}, false
+	}
+
+	prices := make(map[string]types.Price, len(vms))
+	for _, vm := range vms {
+		if price, ok := cpi.cloudInfoStore.GetPrice(provider, region, vm.Type); ok {
+			prices[vm.Type] = price
+		}
+	}
+
+	return types.RegionProducts{Vms: vms, Prices: prices}, true
+}
+
+// zonePrices breaks down the regional price per zone
+// the on demand price is the same in every zone, the spot price is set only for the zones it's available in
+func zonePrices(onDemandPrice float64, spotPrices types.SpotPriceInfo, zones []string) map[string]types.Price {
+	prices := make(map[string]types.Price, len(zones))
+	for _, zone := range zones {
+		prices[zone] = types.Price{OnDemandPrice: onDemandPrice, SpotPrice: types.SpotPriceInfo{}}
+	}
+
+	for zone, spotPrice := range spotPrices {
+		prices[zone] = types.Price{OnDemandPrice: onDemandPrice, SpotPrice: types.SpotPriceInfo{zone: spotPrice}}
+	}
+
+	return prices
+}
+
+// ProductDetailsWithMeta holds the product details along with information on the freshness of the data
+type ProductDetailsWithMeta struct {
+	Products []types.ProductDetails
+	// DataAge the time passed since the last successful scrape of the provider
+	DataAge time.Duration
+	// Stale signals that the data is older than the configured threshold
+	Stale bool
+}
+
+// GetProductDetailsWithMeta retrieves the product details form the given provider and region decorated with staleness information
+func (cpi *cloudInfo) GetProductDetailsWithMeta(provider, service, region string) (ProductDetailsWithMeta, error) {
+	details, err := cpi.GetProductDetails(provider, service, region)
+	if err != nil {
+		return ProductDetailsWithMeta{}, err
+	}
+
+	meta := ProductDetailsWithMeta{Products: details}
+
+	lastScrape, err := cpi.GetLastScrapeTime(provider)
+	if err != nil {
+		// the age of the data can't be determined, it can't be considered fresh
+		cpi.log.Debug("failed to determine data age", map[string]interface{}{"provider": provider, "error": err.Error()})
+		meta.Stale = cpi.staleThreshold > 0
+		return meta, nil
+	}
+
+	meta.DataAge = time.Since(lastScrape)
+	meta.Stale = cpi.staleThreshold > 0 && meta.DataAge > cpi.staleThreshold
+
+	return meta, nil
+}
+
+// GetSortedProductDetails retrieves the product details form the given provider and region in the requested order
+func (cpi *cloudInfo) GetSortedProductDetails(provider, service, region string, sortBy SortBy) ([]types.ProductDetails, error) {
+	details, err := cpi.GetProductDetails(provider, service, region)
+	if err != nil {
+		return nil, err
+	}
+
+	sortProductDetails(details, sortBy)
+
+	return details, nil
+}
+
+// GetSpotProductDetails retrieves the product details having spot prices form the given provider and region
+// the products are ordered by their cheapest spot zone price
+func (cpi *cloudInfo) GetSpotProductDetails(provider, service, region string) ([]types.ProductDetails, error) {
+	details, err := cpi.GetProductDetails(provider, service, region)
+	if err != nil {
+		return nil, err
+	}
+
+	spotDetails := make([]types.ProductDetails, 0, len(details))
+	for _, pd := range details {
+		if len(pd.SpotPrice) > 0 {
+			spotDetails = append(spotDetails, pd)
+		}
+	}
+
+	sortProductDetails(spotDetails, SpotPriceAsc)
+
+	return spotDetails, nil
+}
+
+// GetProductFamilies retrieves product details form the given provider and region grouped by instance type family
+func (cpi *cloudInfo) GetProductFamilies(provider, service, region string) (map[string][]types.ProductDetails, error) {
+	details, err := cpi.GetProductDetails(provider, service, region)
+	if err != nil {
+		return nil, err
+	}
+
+	family := familyFunc(provider)
+	families := make(map[string][]types.ProductDetails)
+	for _, pd := range details {
+		f := family(pd.Type)
+		families[f] = append(families[f], pd)
+	}
+
+	return families, nil
+}
+
+// GetProductDetailsInCurrency retrieves product details form the given provider and region with prices in the requested currency
+func (cpi *cloudInfo) GetProductDetailsInCurrency(provider, service, region, currency string) ([]types.ProductDetails, error) {
+	rate, err := cpi.currencyConverter.Rate(BaseCurrency, currency)
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(err, "failed to retrieve exchange rate", "currency", currency)
+	}
+
+	details, err := cpi.GetProductDetails(provider, service, region)
+	if err != nil {
+		return nil, err
+	}
+
+	convertProductDetails(details, rate)
+
+	return details, nil
+}
+
+// GetProductDetailsPage retrieves a page of the product details form the given provider and region along with the total count
+// products are ordered by instance type, so pages are stable across calls; a zero limit returns all the remaining products
+func (cpi *cloudInfo) GetProductDetailsPage(provider, service, region string, offset, limit int) ([]types.ProductDetails, int, error) {
+	if offset < 0 || limit < 0 {
+		return nil, 0, errors.NewWithDetails("offset and limit must not be negative", "offset", offset, "limit", limit)
+	}
+
+	details, err := cpi.GetProductDetails(provider, service, region)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	sort.SliceStable(details, func(i, j int) bool {
+		return details[i].Type < details[j].Type
+	})
+
+	total := len(details)
+	if offset >= total {
+		return []types.ProductDetails{}, total, nil
+	}
+
+	end := total
+	if limit > 0 && offset+limit < total {
+		end = offset + limit
+	}
+
+	return details[offset:end], total, nil
+}
+
+// GetCatalogChanges retrieves the instance types added to and removed from the catalog of the region by the latest scrape
+func (cpi *cloudInfo) GetCatalogChanges(provider, service, region string) (added, removed []string, err error) {
+	if err := cpi.validate(provider, service, region); err != nil {
+		return nil, nil, err
+	}
+
+	changes, ok := cpi.cloudInfoStore.GetCatalogChanges(provider, service, region)
+	if !ok {
+		return nil, nil, newNotCachedError("catalog changes", "provider", provider, "service", service, "region", region)
+	}
+
+	return changes.Added, changes.Removed, nil
+}
+
+// GetProduct retrieves the product details of the instance type in the given region
+// ErrProductNotFound is returned if the instance type is not among the cached products of the region
+func (cpi *cloudInfo) GetProduct(provider, service, region, instanceType string) (types.ProductDetails, error) {
+	details, err := cpi.FilterProductDetails(provider, service, region, ProductFilter{InstanceType: instanceType})
+	if err != nil {
+		return types.ProductDetails{}, err
+	}
+
+	if len(details) == 0 {
+		return types.ProductDetails{}, errors.WithDetails(ErrProductNotFound, "provider", provider, "service", service,
+			"region", region, "instanceType", instanceType)
+	}
+
+	return details[0], nil
+}
+
+// GetCheapestProduct retrieves the cheapest product in the given region having at least the requested cpu and memory
+// the spot flag signals whether the cheapest spot zone price is compared instead of the on demand price
+func (cpi *cloudInfo) GetCheapestProduct(provider, service, region string, minCpu, minMem float64, spot bool) (types.ProductDetails, error) {
+	details, err := cpi.FilterProductDetails(provider, service, region, ProductFilter{MinCpu: minCpu, MinMem: minMem})
+	if err != nil {
+		return types.ProductDetails{}, err
+	}
+
+	sortBy := PriceAsc
+	if spot {
+		sortBy = SpotPriceAsc
+	}
+
+	var (
+		cheapest      types.ProductDetails
+		cheapestPrice float64
+		found         bool
+	)
+
+	for _, pd := range details {
+		price, ok := sortKey(pd, sortBy)
+		if !ok {
+			continue
+		}
+
+		if !found || price < cheapestPrice {
+			cheapest, cheapestPrice, found = pd, price, true
+		}
+	}
+
+	if !found {
+		return types.ProductDetails{}, errors.NewWithDetails("no product matches the requirements", "provider", provider,
+			"service", service, "region", region, "minCpu", minCpu, "minMem", minMem, "spot", spot)
+	}
+
+	return cheapest, nil
+}
+
+// FindCheapestRegion finds the region offering the cheapest product having at least the requested cpu and memory
+// regions without cached or matching products are skipped
+func (cpi *cloudInfo) FindCheapestRegion(provider, service string, minCpu, minMem float64, spot bool) (string, types.ProductDetails, error) {
+	regions, err := cpi.GetRegions(provider, service)
+	if err != nil {
+		return "", types.ProductDetails{}, err
+	}
+
+	regionIds := make([]string, 0, len(regions))
+	for regionId := range regions {
+		regionIds = append(regionIds, regionId)
+	}
+	// keep the result deterministic when several regions offer the same price
+	sort.Strings(regionIds)
+
+	sortBy := PriceAsc
+	if spot {
+		sortBy = SpotPriceAsc
+	}
+
+	var (
+		cheapestRegion  string
+		cheapestProduct types.ProductDetails
+		cheapestPrice   float64
+		found           bool
+	)
+
+	for _, regionId := range regionIds {
+		product, err := cpi.GetCheapestProduct(provider, service, regionId, minCpu, minMem, spot)
+		if err != nil {
+			cpi.log.Debug("skipping region", map[string]interface{}{"region": regionId, "reason": err.Error()})
+			continue
+		}
+
+		price, _ := sortKey(product, sortBy)
+		if !found || price < cheapestPrice {
+			cheapestRegion, cheapestProduct, cheapestPrice, found = regionId, product, price, true
+		}
+	}
+
+	if !found {
+		return "", types.ProductDetails{}, errors.NewWithDetails("no product matches the requirements in any region", "provider", provider,
+			"service", service, "minCpu", minCpu, "minMem", minMem, "spot", spot)
+	}
+
+	return cheapestRegion, cheapestProduct, nil
+}
+
+// GetScrapeErrors retrieves the last scrape failures of the provider per service and region
+// an empty list is returned if no failure is recorded
+func (cpi *cloudInfo) GetScrapeErrors(provider string) ([]types.ScrapeError, error) {
+	if err := cpi.validate(provider, "", ""); err != nil {
+		return nil, err
+	}
+
+	if cachedVal, ok := cpi.cloudInfoStore.GetScrapeErrors(provider); ok {
+		return cachedVal, nil
+	}
+
+	return []types.ScrapeError{}, nil
+}
+
+// GetAttrRange summarizes the values of the attribute (cpu, memory or any attribute declared by the infoer) of the
+// cached products of the service in all regions; the minimum, the maximum and the number of distinct values are returned
+func (cpi *cloudInfo) GetAttrRange(provider, service, attribute string) (float64, float64, int, error) {
+	regions, err := cpi.GetRegions(provider, service)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	values := make(map[float64]bool)
+	cached := false
+	for regionId := range regions {
+		vms, ok := cpi.cloudInfoStore.GetVm(provider, service, regionId)
+		if !ok {
+			continue
+		}
+
+		for _, vm := range vms {
+			cached = true
+			if value, ok := attributeValue(vm, attribute); ok {
+				values[value] = true
+			}
+		}
+	}
+
+	if cached && len(values) == 0 {
+		return 0, 0, 0, errors.NewWithDetails("unsupported attribute", "attribute", attribute)
+	}
+	if len(values) == 0 {
+		return 0, 0, 0, newNotCachedError("attribute values", "provider", provider, "service", service, "attribute", attribute)
+	}
+
+	var min, max float64
+	first := true
+	for value := range values {
+		if first || value < min {
+			min = value
+		}
+		if first || value > max {
+			max = value
+		}
+		first = false
+	}
+
+	return min, max, len(values), nil
+}
+
+// GetSpotPriceHistory retrieves the stored spot price history of the instance type in the given region
+func (cpi *cloudInfo) GetSpotPriceHistory(provider, region, instanceType string) ([]types.TimestampedPrice, error) {
+	if err := cpi.validate(provider, "", ""); err != nil {
+		return nil, err
+	}
+
+	if !Contains(cpi.spotPriceHistoryProviders, provider) {
+		return nil, errors.WithDetails(ErrSpotPriceHistoryNotSupported, "provider", provider)
+	}
+
+	if cachedVal, ok := cpi.cloudInfoStore.GetSpotPriceHistory(provider, region, instanceType); ok {
+		return cachedVal, nil
+	}
+
+	return nil, newNotCachedError("spot price history", "provider", provider, "region", region, "instanceType", instanceType)
+}
+
+// GetZoneSpotPrice retrieves the cached spot price of the instance type in the availability zone of the region
+func (cpi *cloudInfo) GetZoneSpotPrice(provider, region, instanceType, zone string) (float64, error) {
+	if err := cpi.validate(provider, "", ""); err != nil {
+		return 0, err
+	}
+
+	price, ok := cpi.cloudInfoStore.GetPrice(provider, region, instanceType)
+	if !ok {
+		return 0, newNotCachedError("price", "provider", provider, "region", region, "instanceType", instanceType)
+	}
+
+	spotPrice, ok := price.SpotPrice[zone]
+	if !ok {
+		return 0, errors.WithDetails(ErrZoneSpotPriceNotFound, "provider", provider, "region", region,
+			"instanceType", instanceType, "zone", zone)
+	}
+
+	return spotPrice, nil
+}
+
 // GetStatus retrieves status form the given provider
 func (cpi *cloudInfo) GetStatus(provider string) (string, error) {
+	if err := cpi.validate(provider, "", ""); err != nil {
+		return "", err
+	}
+
 	if cachedStatus, ok := cpi.cloudInfoStore.GetStatus(provider); ok {
 		return cachedStatus, nil
 	}
-	return "", errors.NewWithDetails("status not yet cached", "provider", provider)
+	return "", newNotCachedError("status", "provider", provider)
+}
+
+// GetLastScrapeTime retrieves the time of the last successful scrape for the given provider
+func (cpi *cloudInfo) GetLastScrapeTime(provider string) (time.Time, error) {
+	status, err := cpi.GetStatus(provider)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	scrapeTime, err := parseStatus(status)
+	if err != nil {
+		return time.Time{}, errors.WithDetails(err, "provider", provider)
+	}
+
+	return scrapeTime, nil
+}
+
+// checkMaxAge returns ErrDataTooStale if the last scrape of the provider is older than the configured max age
+// data of unknown age (eg.: the provider's status is missing) is considered too stale as well
+func (cpi *cloudInfo) checkMaxAge(provider string) error {
+	if cpi.maxAge <= 0 {
+		return nil
+	}
+
+	lastScrape, err := cpi.GetLastScrapeTime(provider)
+	if err != nil {
+		return errors.WithDetails(ErrDataTooStale, "provider", provider, "reason", err.Error())
+	}
+
+	if age := time.Since(lastScrape); age > cpi.maxAge {
+		return errors.WithDetails(ErrDataTooStale, "provider", provider, "age", age.String(), "maxAge", cpi.maxAge.String())
+	}
+
+	return nil
+}
+
+// IsReady tells whether every configured provider completed at least one scrape, along with the readiness per provider
+// a provider is considered ready once its status (the timestamp of the last scrape) is cached
+func (cpi *cloudInfo) IsReady() (bool, map[string]bool) {
+	ready := true
+	providers := make(map[string]bool, len(cpi.providers))
+	for _, provider := range cpi.providers {
+		_, ok := cpi.cloudInfoStore.GetStatus(provider)
+		providers[provider] = ok
+		ready = ready && ok
+	}
+
+	return ready, providers
+}
+
+// ProviderHealth summarizes the state of the cached information of a provider
+type ProviderHealth struct {
+	// Ready signals that the provider completed at least one scrape
+	Ready bool `json:"ready"`
+	// LastScrape the time of the last completed scrape, zero if the provider was never scraped
+	LastScrape time.Time `json:"lastScrape,omitempty"`
+	// CachedRegions the number of distinct regions cached for the services of the provider
+	CachedRegions int `json:"cachedRegions"`
+	// ErrorCount the number of service regions the last scrape failed in
+	ErrorCount int `json:"errorCount"`
+	// LastError the message of the most recent scrape failure
+	LastError string `json:"lastError,omitempty"`
+}
+
+// GetHealth summarizes the state of the cached information per configured provider
+func (cpi *cloudInfo) GetHealth() map[string]ProviderHealth {
+	health := make(map[string]ProviderHealth, len(cpi.providers))
+	for _, provider := range cpi.providers {
+		var ph ProviderHealth
+
+		if status, ok := cpi.cloudInfoStore.GetStatus(provider); ok {
+			ph.Ready = true
+			if scrapeTime, err := parseStatus(status); err == nil {
+				ph.LastScrape = scrapeTime
+			}
+		}
+
+		if services, ok := cpi.cloudInfoStore.GetServices(provider); ok {
+			regions := make(map[string]struct{})
+			for _, service := range services {
+				cachedRegions, _ := cpi.cloudInfoStore.GetRegions(provider, service.ServiceName())
+				for region := range cachedRegions {
+					regions[region] = struct{}{}
+				}
+			}
+			ph.CachedRegions = len(regions)
+		}
+
+		if scrapeErrors, ok := cpi.cloudInfoStore.GetScrapeErrors(provider); ok {
+			ph.ErrorCount = len(scrapeErrors)
+			var last time.Time
+			for _, scrapeError := range scrapeErrors {
+				if scrapeError.Time.After(last) || ph.LastError == "" {
+					last, ph.LastError = scrapeError.Time, scrapeError.Message
+				}
+			}
+		}
+
+		health[provider] = ph
+	}
+
+	return health
+}
+
+// parseStatus parses the provider status holding the time of the last scrape in milliseconds
+func parseStatus(status string) (time.Time, error) {
+	millis, err := strconv.ParseInt(status, 10, 64)
+	if err != nil {
+		return time.Time{}, errors.WrapIfWithDetails(err, "failed to parse status", "status", status)
+	}
+
+	return time.Unix(0, millis*int64(time.Millisecond)), nil
 }
 
 // GetServiceImages retrieves available images for the given provider, service and region
 func (cpi *cloudInfo) GetServiceImages(provider, service, region string) ([]types.Image, error) {
-	if cachedImages, ok := cpi.cloudInfoStore.GetImage(provider, service, region); ok {
+	if err := cpi.validate(provider, service, region); err != nil {
+		return nil, err
+	}
+
+	cachedImages, ok := cpi.cloudInfoStore.GetImage(provider, service, region)
+	cpi.reportCacheLookup(provider, service, ok)
+	if ok {
 		return cachedImages, nil
 	}
 
-	return nil, errors.NewWithDetails("images not yet cached", "provider", provider,
-		"service", service, "region", region)
+	return nil, newNotCachedError("images", "provider", provider, "service", service, "region", region)
+}
+
+// FilterServiceImages retrieves the images of the given provider, service and region that satisfy the filter
+func (cpi *cloudInfo) FilterServiceImages(provider, service, region string, filter ImageFilter) ([]types.Image, error) {
+	images, err := cpi.GetServiceImages(provider, service, region)
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := make([]types.Image, 0, len(images))
+	for _, image := range images {
+		if applyImageFilter(image, filter) {
+			filtered = append(filtered, image)
+		}
+	}
+
+	return filtered, nil
 }
 
 // GetVersions retrieves available versions for the given provider, service and region
 func (cpi *cloudInfo) GetVersions(provider, service, region string) ([]types.LocationVersion, error) {
-	if cachedVersions, ok := cpi.cloudInfoStore.GetVersion(provider, service, region); ok {
+	if err := cpi.validate(provider, service, region); err != nil {
+		return nil, err
+	}
+
+	cachedVersions, ok := cpi.cloudInfoStore.GetVersion(provider, service, region)
+	cpi.reportCacheLookup(provider, service, ok)
+	if ok {
 		return cachedVersions, nil
 	}
-	return nil, errors.NewWithDetails("versions not yet cached", "provider", provider,
-		"service", service, "region", region)
+	return nil, newNotCachedError("versions", "provider", provider, "service", service, "region", region)
 }
 
 // GetContinents retrieves available continents
@@ -186,6 +1105,10 @@ func (cpi *cloudInfo) GetContinents() []string {
 
 // GetContinents gets the continents and regions for the provided provider
 func (cpi *cloudInfo) GetContinentsData(provider, service string) (map[string][]types.Region, error) {
+	if err := cpi.validate(provider, service, ""); err != nil {
+		return nil, err
+	}
+
 	if cachedVal, ok := cpi.cloudInfoStore.GetRegions(provider, service); ok {
 		continents := make(map[string][]types.Region)
 		for id, name := range cachedVal {
@@ -198,7 +1121,7 @@ func (cpi *cloudInfo) GetContinentsData(provider, service string) (map[string][]
 		return continents, nil
 	}
 
-	return nil, errors.NewWithDetails("regions not yet cached", "provider", provider, "services", service)
+	return nil, newNotCachedError("regions", "provider", provider, "services", service)
 }
 
 // getContinent categorizes regions by continents
@@ -15,10 +15,14 @@
 package cloudinfo
 
 import (
+	"strconv"
 	"testing"
+	"time"
 
+	"emperror.dev/errors"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/banzaicloud/cloudinfo/internal/cloudinfo/metrics"
 	"github.com/banzaicloud/cloudinfo/internal/cloudinfo/types"
 )
 
@@ -30,7 +34,11 @@ type DummyCloudInfoStore struct {
 	CloudInfoStore
 }
 
-const notCached = "error"
+const (
+	notCached        = "error"
+	timestampStatus  = "timestamp"
+	instanceFamilies = "instanceFamilies"
+)
 
 var cloudinfoLogger = NoOpLogger()
 
@@ -43,6 +51,7 @@ func (dcis *DummyCloudInfoStore) GetRegions(provider, service string) (map[strin
 				"US West (Oregon)": "us-west-2",
 				"EU (Frankfurt)":   "eu-central-1",
 				"EU (Ireland)":     "eu-west-1",
+				"dummyRegion":      "Dummy Region",
 			},
 			true
 	}
@@ -103,6 +112,8 @@ func (dcis *DummyCloudInfoStore) GetStatus(provider string) (string, bool) {
 	switch dcis.TcId {
 	case notCached:
 		return "", false
+	case timestampStatus:
+		return "1546300800000", true
 	default:
 		return "dummyStatus", true
 	}
@@ -122,11 +133,110 @@ func (dcis *DummyCloudInfoStore) GetServices(provider string) ([]types.Service,
 					Service:  "dummy2",
 					IsStatic: false,
 				},
+				{
+					Service:  "dummyService",
+					IsStatic: false,
+				},
+			},
+			true
+	}
+}
+
+func (dcis *DummyCloudInfoStore) GetVm(provider, service, region string) ([]types.VMInfo, bool) {
+	switch dcis.TcId {
+	case notCached:
+		return nil, false
+	case instanceFamilies:
+		return []types.VMInfo{
+				{Type: "m5.large", OnDemandPrice: 0.096, Cpus: 2, Mem: 8},
+				{Type: "m5.xlarge", OnDemandPrice: 0.192, Cpus: 4, Mem: 16},
+				{Type: "c4.2xlarge", OnDemandPrice: 0.398, Cpus: 8, Mem: 15},
+			},
+			true
+	default:
+		ntwPerfCat, _ := dummyNetworkMapper{}.MapNetworkPerf("dummyNtwPerf")
+		return []types.VMInfo{
+				{
+					Type:          "dummyType1",
+					OnDemandPrice: 0.32,
+					Cpus:          2,
+					Mem:           32,
+					NtwPerfCat:    ntwPerfCat,
+					Architecture:  types.ArchX86_64,
+				},
+				{
+					Type:          "dummyType2",
+					OnDemandPrice: 0.52,
+					Cpus:          4,
+					Mem:           16,
+					NtwPerfCat:    ntwPerfCat,
+					Architecture:  types.ArchArm64,
+					// storage optimized instance type with local NVMe disks
+					InstanceStorageGB:   475,
+					InstanceStorageType: "NVMe SSD",
+				},
+				{
+					Type:          "dummyType3",
+					OnDemandPrice: 1.2,
+					Cpus:          8,
+					Mem:           64,
+					Gpus:          4,
+					NtwPerfCat:    ntwPerfCat,
+				},
 			},
 			true
 	}
 }
 
+// GetRegionProducts returns no region products, so the VMs and prices are looked up one by one
+func (dcis *DummyCloudInfoStore) GetRegionProducts(provider, service, region string) (types.RegionProducts, bool) {
+	return types.RegionProducts{}, false
+}
+
+func (dcis *DummyCloudInfoStore) GetSpotPriceHistory(provider, region, instanceType string) ([]types.TimestampedPrice, bool) {
+	switch dcis.TcId {
+	case notCached:
+		return nil, false
+	default:
+		return []types.TimestampedPrice{
+			{Time: time.Date(2019, 5, 1, 10, 0, 0, 0, time.UTC), Price: 0.112},
+			{Time: time.Date(2019, 5, 1, 11, 0, 0, 0, time.UTC), Price: 0.121},
+		}, true
+	}
+}
+
+// dummyNetworkMapper maps every network performance to the "high" category
+type dummyNetworkMapper struct{}
+
+func (dummyNetworkMapper) MapNetworkPerf(ntwPerf string) (string, error) {
+	return "high", nil
+}
+
+func (dcis *DummyCloudInfoStore) GetPrice(provider, region, instanceType string) (types.Price, bool) {
+	switch dcis.TcId {
+	case notCached:
+		return types.Price{}, false
+	default:
+		prices := map[string]types.Price{
+			"dummyType1": {
+				OnDemandPrice: 0.32,
+				SpotPrice:     types.SpotPriceInfo{"dummyZone1": 0.112, "dummyZone2": 0.121},
+				ReservedPrice: map[string]float64{"1yr-all-upfront": 0.2, "3yr-no-upfront": 0.15},
+			},
+			"dummyType2": {
+				OnDemandPrice: 0.52,
+				SpotPrice:     types.SpotPriceInfo{"dummyZone1": 0.164, "dummyZone2": 0.17},
+			},
+			"dummyType3": {
+				OnDemandPrice: 1.2,
+				SpotPrice:     types.SpotPriceInfo{},
+			},
+		}
+		price, ok := prices[instanceType]
+		return price, ok
+	}
+}
+
 func TestNewCachingCloudInfo(t *testing.T) {
 	tests := []struct {
 		Name        string
@@ -171,7 +281,8 @@ func TestCachingCloudInfo_GetRegions(t *testing.T) {
 				assert.Equal(t, map[string]string{
 					"US West (Oregon)": "us-west-2",
 					"EU (Frankfurt)":   "eu-central-1",
-					"EU (Ireland)":     "eu-west-1"}, regions)
+					"EU (Ireland)":     "eu-west-1",
+					"dummyRegion":      "Dummy Region"}, regions)
 				assert.Nil(t, err, "the error should be nil")
 			},
 		},
@@ -186,7 +297,7 @@ func TestCachingCloudInfo_GetRegions(t *testing.T) {
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			info, _ := NewCloudInfo([]string{}, &DummyCloudInfoStore{}, cloudinfoLogger)
+			info, _ := NewCloudInfo([]string{"dummyProvider"}, &DummyCloudInfoStore{}, cloudinfoLogger)
 			info.cloudInfoStore = test.ciStore
 			test.checker(info.GetRegions("dummyProvider", "dummyService"))
 		})
@@ -218,7 +329,7 @@ func TestCachingCloudInfo_GetVersions(t *testing.T) {
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			info, _ := NewCloudInfo([]string{}, &DummyCloudInfoStore{}, cloudinfoLogger)
+			info, _ := NewCloudInfo([]string{"dummyProvider"}, &DummyCloudInfoStore{}, cloudinfoLogger)
 			info.cloudInfoStore = test.ciStore
 			test.checker(info.GetVersions("dummyProvider", "dummyService", "dummyRegion"))
 		})
@@ -250,7 +361,7 @@ func TestCachingCloudInfo_GetServiceImages(t *testing.T) {
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			info, _ := NewCloudInfo([]string{}, &DummyCloudInfoStore{}, cloudinfoLogger)
+			info, _ := NewCloudInfo([]string{"dummyProvider"}, &DummyCloudInfoStore{}, cloudinfoLogger)
 			info.cloudInfoStore = test.ciStore
 			test.checker(info.GetServiceImages("dummyProvider", "dummyService", "dummyRegion"))
 		})
@@ -282,7 +393,7 @@ func TestCachingCloudInfo_GetZones(t *testing.T) {
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			info, _ := NewCloudInfo([]string{}, &DummyCloudInfoStore{}, cloudinfoLogger)
+			info, _ := NewCloudInfo([]string{"dummyProvider"}, &DummyCloudInfoStore{}, cloudinfoLogger)
 			info.cloudInfoStore = test.ciStore
 			test.checker(info.GetZones("dummyProvider", "dummyService", "dummyRegion"))
 		})
@@ -299,7 +410,7 @@ func TestCachingCloudInfo_GetServices(t *testing.T) {
 			name:    "successfully retrieved the services",
 			ciStore: &DummyCloudInfoStore{},
 			checker: func(services []types.Service, err error) {
-				assert.Equal(t, 2, len(services))
+				assert.Equal(t, 3, len(services))
 				assert.Nil(t, err, "the error should be nil")
 			},
 		},
@@ -314,13 +425,89 @@ func TestCachingCloudInfo_GetServices(t *testing.T) {
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			info, _ := NewCloudInfo([]string{}, &DummyCloudInfoStore{}, cloudinfoLogger)
+			info, _ := NewCloudInfo([]string{"dummyProvider"}, &DummyCloudInfoStore{}, cloudinfoLogger)
 			info.cloudInfoStore = test.ciStore
 			test.checker(info.GetServices("dummyProvider"))
 		})
 	}
 }
 
+func TestCachingCloudInfo_GetServices_displayNames(t *testing.T) {
+	store := newTestCloudInfoStore()
+	store.StoreServices("amazon", []types.Service{{Service: "eks"}, {Service: "unknown"}})
+
+	info, _ := NewCloudInfo([]string{"amazon"}, store, cloudinfoLogger)
+
+	services, err := info.GetServices("amazon")
+	assert.Nil(t, err, "the error should be nil")
+	assert.Equal(t, "Elastic Kubernetes Service", services[0].DisplayName())
+	assert.Equal(t, "unknown", services[1].DisplayName(), "the service name should be the fallback")
+
+	provider, err := info.GetProvider("amazon")
+	assert.Nil(t, err, "the error should be nil")
+	assert.Equal(t, "Elastic Kubernetes Service", provider.Services[0].Label)
+
+	cached, _ := store.GetServices("amazon")
+	assert.Empty(t, cached[0].Label, "the cached services should be left intact")
+}
+
+func TestCachingCloudInfo_GetServicesWithCapabilities(t *testing.T) {
+	store := newTestCloudInfoStore()
+	store.StoreServices("dummyProvider", []types.Service{{Service: "compute"}, {Service: "eks"}, {Service: "gke"}})
+	for _, service := range []string{"compute", "eks", "gke"} {
+		store.StoreRegions("dummyProvider", service, map[string]string{"dummyRegion1": "dummyRegion1", "dummyRegion2": "dummyRegion2"})
+	}
+	store.StoreImage("dummyProvider", "eks", "dummyRegion2", []types.Image{{Name: "dummyImage"}})
+	store.StoreImage("dummyProvider", "gke", "dummyRegion1", []types.Image{})
+	store.StoreVersion("dummyProvider", "gke", "dummyRegion1", []types.LocationVersion{{Location: "dummyRegion1", Versions: []string{"1.18"}}})
+
+	info, _ := NewCloudInfo([]string{"dummyProvider"}, store, cloudinfoLogger)
+
+	services, err := info.GetServicesWithImages("dummyProvider")
+	assert.Nil(t, err, "the error should be nil")
+	assert.Equal(t, []types.Service{{Service: "eks", Label: "eks"}}, services, "only the service with images should be returned")
+
+	services, err = info.GetServicesWithCapabilities("dummyProvider", ServiceVersionsCapability)
+	assert.Nil(t, err, "the error should be nil")
+	assert.Equal(t, []types.Service{{Service: "gke", Label: "gke"}}, services)
+
+	services, err = info.GetServicesWithCapabilities("dummyProvider", ServiceImagesCapability, ServiceVersionsCapability)
+	assert.Nil(t, err, "the error should be nil")
+	assert.Empty(t, services, "no service has both images and versions")
+}
+
+// cacheLookupReporter counts the reported cache hits and misses
+type cacheLookupReporter struct {
+	metrics.Reporter
+	hits   int
+	misses int
+}
+
+func (r *cacheLookupReporter) ReportCacheHit(provider, service string) {
+	r.hits++
+}
+
+func (r *cacheLookupReporter) ReportCacheMiss(provider, service string) {
+	r.misses++
+}
+
+func TestCachingCloudInfo_cacheLookupMetrics(t *testing.T) {
+	reporter := &cacheLookupReporter{Reporter: metrics.NewNoOpMetricsReporter()}
+	store := newTestCloudInfoStore()
+	info, _ := NewCloudInfoWithOptions([]string{"dummyProvider"}, store, cloudinfoLogger, CloudInfoOptions{Reporter: reporter})
+
+	_, err := info.GetProductDetails("dummyProvider", "dummyService", "dummyRegion")
+	assert.True(t, errors.Is(err, ErrNotCached), "the error should be not cached for an empty store")
+	assert.Equal(t, 0, reporter.hits)
+	assert.Equal(t, 1, reporter.misses, "the miss should be reported")
+
+	store.StoreVm("dummyProvider", "dummyService", "dummyRegion", []types.VMInfo{{Type: "dummyType1", OnDemandPrice: 0.32}})
+	_, err = info.GetProductDetails("dummyProvider", "dummyService", "dummyRegion")
+	assert.Nil(t, err, "the error should be nil")
+	assert.Equal(t, 1, reporter.hits, "the hit should be reported")
+	assert.Equal(t, 1, reporter.misses)
+}
+
 func TestCachingCloudInfo_GetStatus(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -346,9 +533,1736 @@ func TestCachingCloudInfo_GetStatus(t *testing.T) {
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			info, _ := NewCloudInfo([]string{}, &DummyCloudInfoStore{}, cloudinfoLogger)
+			info, _ := NewCloudInfo([]string{"dummyProvider"}, &DummyCloudInfoStore{}, cloudinfoLogger)
 			info.cloudInfoStore = test.ciStore
 			test.checker(info.GetStatus("dummyProvider"))
 		})
 	}
 }
+
+func TestCachingCloudInfo_FilterProductDetails(t *testing.T) {
+	tests := []struct {
+		name    string
+		ciStore CloudInfoStore
+		filter  ProductFilter
+		checker func(details []types.ProductDetails, err error)
+	}{
+		{
+			name:    "empty filter returns all the products",
+			ciStore: &DummyCloudInfoStore{},
+			filter:  ProductFilter{},
+			checker: func(details []types.ProductDetails, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, 3, len(details))
+			},
+		},
+		{
+			name:    "products below the minimum cpu and memory are dropped",
+			ciStore: &DummyCloudInfoStore{},
+			filter:  ProductFilter{MinCpu: 4, MinMem: 16},
+			checker: func(details []types.ProductDetails, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, 2, len(details))
+				for _, pd := range details {
+					assert.NotEqual(t, "dummyType1", pd.Type)
+				}
+			},
+		},
+		{
+			name:    "products outside the maximum bounds are dropped",
+			ciStore: &DummyCloudInfoStore{},
+			filter:  ProductFilter{MaxCpu: 4, MaxMem: 16},
+			checker: func(details []types.ProductDetails, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, 1, len(details))
+				assert.Equal(t, "dummyType2", details[0].Type)
+			},
+		},
+		{
+			name:    "products in the requested network categories are kept",
+			ciStore: &DummyCloudInfoStore{},
+			filter:  ProductFilter{NtwPerfCats: []string{"medium", "high"}},
+			checker: func(details []types.ProductDetails, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, 3, len(details))
+			},
+		},
+		{
+			name:    "products outside the requested network categories are dropped",
+			ciStore: &DummyCloudInfoStore{},
+			filter:  ProductFilter{NtwPerfCats: []string{"low"}},
+			checker: func(details []types.ProductDetails, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, 0, len(details))
+			},
+		},
+		{
+			name:    "products of other architectures are dropped",
+			ciStore: &DummyCloudInfoStore{},
+			filter:  ProductFilter{Architecture: types.ArchX86_64},
+			checker: func(details []types.ProductDetails, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				// the architecture of dummyType3 is unknown, so it matches any
+				assert.Equal(t, []string{"dummyType1", "dummyType3"}, productTypes(details))
+			},
+		},
+		{
+			name:    "products without enough local storage are dropped",
+			ciStore: &DummyCloudInfoStore{},
+			filter:  ProductFilter{MinInstanceStorage: 400},
+			checker: func(details []types.ProductDetails, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, []string{"dummyType2"}, productTypes(details))
+				assert.Equal(t, "NVMe SSD", details[0].InstanceStorageType)
+			},
+		},
+		{
+			name:    "no product has enough local storage",
+			ciStore: &DummyCloudInfoStore{},
+			filter:  ProductFilter{MinInstanceStorage: 1000},
+			checker: func(details []types.ProductDetails, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, 0, len(details))
+			},
+		},
+		{
+			name:    "products without gpu are dropped",
+			ciStore: &DummyCloudInfoStore{},
+			filter:  ProductFilter{GpuOnly: true},
+			checker: func(details []types.ProductDetails, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, []string{"dummyType3"}, productTypes(details))
+				assert.True(t, details[0].HasGpu(), "the product should have gpus")
+			},
+		},
+		{
+			name:    "products below the minimum gpu are dropped",
+			ciStore: &DummyCloudInfoStore{},
+			filter:  ProductFilter{MinGpu: 1},
+			checker: func(details []types.ProductDetails, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, 1, len(details))
+				assert.Equal(t, "dummyType3", details[0].Type)
+			},
+		},
+		{
+			name:    "failed to retrieve product details",
+			ciStore: &DummyCloudInfoStore{TcId: notCached},
+			filter:  ProductFilter{MinCpu: 4},
+			checker: func(details []types.ProductDetails, err error) {
+				assert.Nil(t, details, "the details should be nil")
+				assert.EqualError(t, err, "VMs not yet cached")
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			info, _ := NewCloudInfo([]string{"dummyProvider"}, &DummyCloudInfoStore{}, cloudinfoLogger)
+			info.cloudInfoStore = test.ciStore
+			test.checker(info.FilterProductDetails("dummyProvider", "dummyService", "dummyRegion", test.filter))
+		})
+	}
+}
+
+// productTypes returns the instance types of the product details
+func productTypes(details []types.ProductDetails) []string {
+	var ts []string
+	for _, pd := range details {
+		ts = append(ts, pd.Type)
+	}
+	return ts
+}
+
+func TestCachingCloudInfo_GetSortedProductDetails(t *testing.T) {
+	tests := []struct {
+		name    string
+		ciStore CloudInfoStore
+		sortBy  SortBy
+		checker func(details []types.ProductDetails, err error)
+	}{
+		{
+			name:    "products sorted by on demand price descending",
+			ciStore: &DummyCloudInfoStore{},
+			sortBy:  PriceDesc,
+			checker: func(details []types.ProductDetails, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, []string{"dummyType3", "dummyType2", "dummyType1"}, productTypes(details))
+			},
+		},
+		{
+			name:    "products sorted by memory ascending",
+			ciStore: &DummyCloudInfoStore{},
+			sortBy:  MemAsc,
+			checker: func(details []types.ProductDetails, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, []string{"dummyType2", "dummyType1", "dummyType3"}, productTypes(details))
+			},
+		},
+		{
+			name:    "products sorted by spot savings descending",
+			ciStore: &DummyCloudInfoStore{},
+			sortBy:  SavingsDesc,
+			checker: func(details []types.ProductDetails, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, []string{"dummyType2", "dummyType1", "dummyType3"}, productTypes(details))
+				assert.InDelta(t, 68.46, details[0].SpotSavings(), 0.01)
+				assert.InDelta(t, 65, details[1].SpotSavings(), 0.01)
+				assert.Equal(t, float64(0), details[2].SpotSavings(), "no savings without spot price")
+			},
+		},
+		{
+			name:    "products sorted by cpu per dollar descending",
+			ciStore: &DummyCloudInfoStore{},
+			sortBy:  CpuPerDollarDesc,
+			checker: func(details []types.ProductDetails, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, []string{"dummyType2", "dummyType3", "dummyType1"}, productTypes(details))
+				assert.Equal(t, 6.25, details[2].CpuPerDollar(), "2 cpus for $0.32 should yield 6.25 cpus per dollar")
+			},
+		},
+		{
+			name:    "products sorted by memory per dollar descending",
+			ciStore: &DummyCloudInfoStore{},
+			sortBy:  MemPerDollarDesc,
+			checker: func(details []types.ProductDetails, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, []string{"dummyType1", "dummyType3", "dummyType2"}, productTypes(details))
+				assert.Equal(t, 100.0, details[0].MemPerDollar())
+			},
+		},
+		{
+			name:    "products without spot price are pushed to the end",
+			ciStore: &DummyCloudInfoStore{},
+			sortBy:  SpotPriceAsc,
+			checker: func(details []types.ProductDetails, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, []string{"dummyType1", "dummyType2", "dummyType3"}, productTypes(details))
+			},
+		},
+		{
+			name:    "failed to retrieve product details",
+			ciStore: &DummyCloudInfoStore{TcId: notCached},
+			sortBy:  PriceAsc,
+			checker: func(details []types.ProductDetails, err error) {
+				assert.Nil(t, details, "the details should be nil")
+				assert.EqualError(t, err, "VMs not yet cached")
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			info, _ := NewCloudInfo([]string{"dummyProvider"}, &DummyCloudInfoStore{}, cloudinfoLogger)
+			info.cloudInfoStore = test.ciStore
+			test.checker(info.GetSortedProductDetails("dummyProvider", "dummyService", "dummyRegion", test.sortBy))
+		})
+	}
+}
+
+func TestCachingCloudInfo_GetCheapestProduct(t *testing.T) {
+	tests := []struct {
+		name    string
+		ciStore CloudInfoStore
+		minCpu  float64
+		minMem  float64
+		spot    bool
+		checker func(product types.ProductDetails, err error)
+	}{
+		{
+			name:    "cheapest on demand product returned",
+			ciStore: &DummyCloudInfoStore{},
+			minCpu:  4,
+			checker: func(product types.ProductDetails, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, "dummyType2", product.Type)
+			},
+		},
+		{
+			name:    "cheapest spot product returned",
+			ciStore: &DummyCloudInfoStore{},
+			minMem:  16,
+			spot:    true,
+			checker: func(product types.ProductDetails, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, "dummyType1", product.Type)
+			},
+		},
+		{
+			name:    "no product matches the requirements",
+			ciStore: &DummyCloudInfoStore{},
+			minCpu:  8,
+			spot:    true,
+			checker: func(product types.ProductDetails, err error) {
+				assert.Equal(t, types.ProductDetails{}, product)
+				assert.EqualError(t, err, "no product matches the requirements")
+			},
+		},
+		{
+			name:    "failed to retrieve product details",
+			ciStore: &DummyCloudInfoStore{TcId: notCached},
+			checker: func(product types.ProductDetails, err error) {
+				assert.EqualError(t, err, "VMs not yet cached")
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			info, _ := NewCloudInfo([]string{"dummyProvider"}, &DummyCloudInfoStore{}, cloudinfoLogger)
+			info.cloudInfoStore = test.ciStore
+			test.checker(info.GetCheapestProduct("dummyProvider", "dummyService", "dummyRegion", test.minCpu, test.minMem, test.spot))
+		})
+	}
+}
+
+func TestCachingCloudInfo_GetLastScrapeTime(t *testing.T) {
+	tests := []struct {
+		name    string
+		ciStore CloudInfoStore
+		checker func(lastScrape time.Time, err error)
+	}{
+		{
+			name:    "successfully retrieved the last scrape time",
+			ciStore: &DummyCloudInfoStore{TcId: timestampStatus},
+			checker: func(lastScrape time.Time, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.True(t, lastScrape.Equal(time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)))
+			},
+		},
+		{
+			name:    "failed to parse status",
+			ciStore: &DummyCloudInfoStore{},
+			checker: func(lastScrape time.Time, err error) {
+				assert.True(t, lastScrape.IsZero())
+				assert.NotNil(t, err, "the error should not be nil")
+			},
+		},
+		{
+			name:    "failed to retrieve status",
+			ciStore: &DummyCloudInfoStore{TcId: notCached},
+			checker: func(lastScrape time.Time, err error) {
+				assert.True(t, lastScrape.IsZero())
+				assert.EqualError(t, err, "status not yet cached")
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			info, _ := NewCloudInfo([]string{"dummyProvider"}, &DummyCloudInfoStore{}, cloudinfoLogger)
+			info.cloudInfoStore = test.ciStore
+			test.checker(info.GetLastScrapeTime("dummyProvider"))
+		})
+	}
+}
+
+// statusCloudInfoStore overrides the status of the dummy store with a fixed value
+type statusCloudInfoStore struct {
+	DummyCloudInfoStore
+	status string
+}
+
+func (scis *statusCloudInfoStore) GetStatus(provider string) (string, bool) {
+	return scis.status, true
+}
+
+func TestCachingCloudInfo_GetProductDetailsWithMeta(t *testing.T) {
+	scrapedAgo := func(d time.Duration) string {
+		return strconv.FormatInt(time.Now().Add(-d).UnixNano()/int64(time.Millisecond), 10)
+	}
+
+	tests := []struct {
+		name    string
+		ciStore CloudInfoStore
+		checker func(meta ProductDetailsWithMeta, err error)
+	}{
+		{
+			name:    "fresh data is not flagged",
+			ciStore: &statusCloudInfoStore{status: scrapedAgo(10 * time.Minute)},
+			checker: func(meta ProductDetailsWithMeta, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, 3, len(meta.Products))
+				assert.False(t, meta.Stale)
+				assert.True(t, meta.DataAge >= 10*time.Minute)
+			},
+		},
+		{
+			name:    "stale data is flagged but still returned",
+			ciStore: &statusCloudInfoStore{status: scrapedAgo(2 * time.Hour)},
+			checker: func(meta ProductDetailsWithMeta, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, 3, len(meta.Products))
+				assert.True(t, meta.Stale)
+				assert.True(t, meta.DataAge >= 2*time.Hour)
+			},
+		},
+		{
+			name:    "data of unknown age is flagged",
+			ciStore: &DummyCloudInfoStore{},
+			checker: func(meta ProductDetailsWithMeta, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, 3, len(meta.Products))
+				assert.True(t, meta.Stale)
+			},
+		},
+		{
+			name:    "failed to retrieve product details",
+			ciStore: &DummyCloudInfoStore{TcId: notCached},
+			checker: func(meta ProductDetailsWithMeta, err error) {
+				assert.Nil(t, meta.Products, "the details should be nil")
+				assert.EqualError(t, err, "VMs not yet cached")
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			info, _ := NewCloudInfoWithOptions([]string{"dummyProvider"}, test.ciStore, cloudinfoLogger, CloudInfoOptions{StaleThreshold: time.Hour})
+			test.checker(info.GetProductDetailsWithMeta("dummyProvider", "dummyService", "dummyRegion"))
+		})
+	}
+}
+
+func TestCachingCloudInfo_GetProductDetails_maxAge(t *testing.T) {
+	scrapedAgo := func(d time.Duration) string {
+		return strconv.FormatInt(time.Now().Add(-d).UnixNano()/int64(time.Millisecond), 10)
+	}
+
+	tests := []struct {
+		name    string
+		ciStore CloudInfoStore
+		maxAge  time.Duration
+		checker func(details []types.ProductDetails, err error)
+	}{
+		{
+			name:    "data within the max age is served",
+			ciStore: &statusCloudInfoStore{status: scrapedAgo(10 * time.Minute)},
+			maxAge:  30 * time.Minute,
+			checker: func(details []types.ProductDetails, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, 3, len(details))
+			},
+		},
+		{
+			name:    "data exceeding the max age is not served",
+			ciStore: &statusCloudInfoStore{status: scrapedAgo(time.Hour)},
+			maxAge:  30 * time.Minute,
+			checker: func(details []types.ProductDetails, err error) {
+				assert.Nil(t, details, "the details should be nil")
+				assert.True(t, errors.Is(err, ErrDataTooStale), "the error should signal the stale data")
+			},
+		},
+		{
+			name:    "data of unknown age is not served",
+			ciStore: &DummyCloudInfoStore{},
+			maxAge:  30 * time.Minute,
+			checker: func(details []types.ProductDetails, err error) {
+				assert.True(t, errors.Is(err, ErrDataTooStale), "the error should signal the stale data")
+			},
+		},
+		{
+			name:    "the max age is not checked if disabled",
+			ciStore: &statusCloudInfoStore{status: scrapedAgo(time.Hour)},
+			checker: func(details []types.ProductDetails, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, 3, len(details))
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			info, _ := NewCloudInfoWithOptions([]string{"dummyProvider"}, test.ciStore, cloudinfoLogger, CloudInfoOptions{MaxAge: test.maxAge})
+			test.checker(info.GetProductDetails("dummyProvider", "dummyService", "dummyRegion"))
+		})
+	}
+}
+
+func TestCachingCloudInfo_GetProviders(t *testing.T) {
+	tests := []struct {
+		name    string
+		ciStore CloudInfoStore
+		checker func(providers []types.Provider, err error)
+	}{
+		{
+			name:    "successfully retrieved the providers",
+			ciStore: &DummyCloudInfoStore{},
+			checker: func(providers []types.Provider, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, 1, len(providers))
+				assert.Equal(t, "dummyProvider", providers[0].Provider)
+				assert.Equal(t, 3, len(providers[0].Services))
+				assert.Equal(t, "dummyProvider", providers[0].Metadata.DisplayName, "the provider should be enriched with its metadata")
+			},
+		},
+		{
+			name:    "providers with no cached data are skipped",
+			ciStore: &DummyCloudInfoStore{TcId: notCached},
+			checker: func(providers []types.Provider, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, []types.Provider{}, providers)
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			info, _ := NewCloudInfo([]string{"dummyProvider"}, test.ciStore, cloudinfoLogger)
+			test.checker(info.GetProviders())
+		})
+	}
+}
+
+func TestCachingCloudInfo_GetProviders_ordering(t *testing.T) {
+	store := newTestCloudInfoStore()
+	for _, provider := range []string{"google", "amazon", "azure"} {
+		store.StoreServices(provider, []types.Service{{Service: "compute"}})
+	}
+
+	info, _ := NewCloudInfo([]string{"google", "amazon", "azure"}, store, cloudinfoLogger)
+
+	providers, err := info.GetProviders()
+	assert.Nil(t, err, "the error should be nil")
+	names := make([]string, 0, len(providers))
+	for _, provider := range providers {
+		names = append(names, provider.Provider)
+	}
+	assert.Equal(t, []string{"amazon", "azure", "google"}, names, "the providers should be ordered by name")
+}
+
+func TestCachingCloudInfo_GetProductDetailsPage(t *testing.T) {
+	tests := []struct {
+		name    string
+		ciStore CloudInfoStore
+		offset  int
+		limit   int
+		checker func(details []types.ProductDetails, total int, err error)
+	}{
+		{
+			name:    "first page",
+			ciStore: &DummyCloudInfoStore{},
+			offset:  0,
+			limit:   2,
+			checker: func(details []types.ProductDetails, total int, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, []string{"dummyType1", "dummyType2"}, productTypes(details))
+				assert.Equal(t, 3, total)
+			},
+		},
+		{
+			name:    "last, partial page",
+			ciStore: &DummyCloudInfoStore{},
+			offset:  2,
+			limit:   2,
+			checker: func(details []types.ProductDetails, total int, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, []string{"dummyType3"}, productTypes(details))
+				assert.Equal(t, 3, total)
+			},
+		},
+		{
+			name:    "zero limit returns the remaining products",
+			ciStore: &DummyCloudInfoStore{},
+			offset:  1,
+			limit:   0,
+			checker: func(details []types.ProductDetails, total int, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, []string{"dummyType2", "dummyType3"}, productTypes(details))
+			},
+		},
+		{
+			name:    "offset past the end",
+			ciStore: &DummyCloudInfoStore{},
+			offset:  5,
+			limit:   2,
+			checker: func(details []types.ProductDetails, total int, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, 0, len(details))
+				assert.Equal(t, 3, total)
+			},
+		},
+		{
+			name:    "negative offset",
+			ciStore: &DummyCloudInfoStore{},
+			offset:  -1,
+			limit:   2,
+			checker: func(details []types.ProductDetails, total int, err error) {
+				assert.Nil(t, details, "the details should be nil")
+				assert.EqualError(t, err, "offset and limit must not be negative")
+			},
+		},
+		{
+			name:    "negative limit",
+			ciStore: &DummyCloudInfoStore{},
+			offset:  0,
+			limit:   -2,
+			checker: func(details []types.ProductDetails, total int, err error) {
+				assert.Nil(t, details, "the details should be nil")
+				assert.EqualError(t, err, "offset and limit must not be negative")
+			},
+		},
+		{
+			name:    "failed to retrieve product details",
+			ciStore: &DummyCloudInfoStore{TcId: notCached},
+			offset:  0,
+			limit:   2,
+			checker: func(details []types.ProductDetails, total int, err error) {
+				assert.Nil(t, details, "the details should be nil")
+				assert.EqualError(t, err, "VMs not yet cached")
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			info, _ := NewCloudInfo([]string{"dummyProvider"}, &DummyCloudInfoStore{}, cloudinfoLogger)
+			info.cloudInfoStore = test.ciStore
+			test.checker(info.GetProductDetailsPage("dummyProvider", "dummyService", "dummyRegion", test.offset, test.limit))
+		})
+	}
+}
+
+// dummyCurrencyConverter converts USD to EUR with a fixed rate
+type dummyCurrencyConverter struct{}
+
+func (dummyCurrencyConverter) Rate(from, to string) (float64, error) {
+	if from == "USD" && to == "EUR" {
+		return 0.9, nil
+	}
+	return 0, errors.New("unknown currency")
+}
+
+func TestCachingCloudInfo_GetProductDetailsInCurrency(t *testing.T) {
+	tests := []struct {
+		name      string
+		converter CurrencyConverter
+		currency  string
+		checker   func(details []types.ProductDetails, err error)
+	}{
+		{
+			name:      "prices are converted",
+			converter: dummyCurrencyConverter{},
+			currency:  "EUR",
+			checker: func(details []types.ProductDetails, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, "dummyType1", details[0].Type)
+				assert.InDelta(t, 0.288, details[0].OnDemandPrice, 1e-9)
+				spotPrice, _ := cheapestSpotPrice(details[0])
+				assert.InDelta(t, 0.1008, spotPrice, 1e-9)
+				assert.Equal(t, float64(2), details[0].Cpus, "the cpus should be untouched")
+				assert.Equal(t, float64(32), details[0].Mem, "the memory should be untouched")
+			},
+		},
+		{
+			name:      "reserved prices are converted",
+			converter: dummyCurrencyConverter{},
+			currency:  "EUR",
+			checker: func(details []types.ProductDetails, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, "dummyType1", details[0].Type)
+				assert.InDelta(t, 0.18, details[0].ReservedPrice["1yr-all-upfront"], 1e-9)
+				assert.InDelta(t, 0.135, details[0].ReservedPrice["3yr-no-upfront"], 1e-9)
+			},
+		},
+		{
+			name:      "the identity converter is used by default",
+			converter: nil,
+			currency:  "USD",
+			checker: func(details []types.ProductDetails, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, 0.32, details[0].OnDemandPrice)
+			},
+		},
+		{
+			name:      "unsupported currency",
+			converter: nil,
+			currency:  "EUR",
+			checker: func(details []types.ProductDetails, err error) {
+				assert.Nil(t, details, "the details should be nil")
+				assert.EqualError(t, err, "failed to retrieve exchange rate: currency conversion not supported")
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			info, _ := NewCloudInfoWithOptions([]string{"dummyProvider"}, &DummyCloudInfoStore{}, cloudinfoLogger, CloudInfoOptions{CurrencyConverter: test.converter})
+			test.checker(info.GetProductDetailsInCurrency("dummyProvider", "dummyService", "dummyRegion", test.currency))
+		})
+	}
+}
+
+func TestCachingCloudInfo_validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		ciStore  CloudInfoStore
+		provider string
+		service  string
+		region   string
+		checker  func(err error)
+	}{
+		{
+			name:     "unsupported provider",
+			ciStore:  &DummyCloudInfoStore{},
+			provider: "unknownProvider",
+			service:  "dummyService",
+			region:   "dummyRegion",
+			checker: func(err error) {
+				assert.True(t, errors.Is(err, ErrProviderNotSupported), "the error should be ErrProviderNotSupported")
+			},
+		},
+		{
+			name:     "unsupported service",
+			ciStore:  &DummyCloudInfoStore{},
+			provider: "dummyProvider",
+			service:  "unknownService",
+			region:   "dummyRegion",
+			checker: func(err error) {
+				assert.True(t, errors.Is(err, ErrServiceNotSupported), "the error should be ErrServiceNotSupported")
+			},
+		},
+		{
+			name:     "unsupported region",
+			ciStore:  &DummyCloudInfoStore{},
+			provider: "dummyProvider",
+			service:  "dummyService",
+			region:   "unknownRegion",
+			checker: func(err error) {
+				assert.True(t, errors.Is(err, ErrRegionNotSupported), "the error should be ErrRegionNotSupported")
+			},
+		},
+		{
+			name:     "not cached",
+			ciStore:  &DummyCloudInfoStore{TcId: notCached},
+			provider: "dummyProvider",
+			service:  "dummyService",
+			region:   "dummyRegion",
+			checker: func(err error) {
+				assert.True(t, errors.Is(err, ErrNotCached), "the error should be ErrNotCached")
+				assert.EqualError(t, err, "zones not yet cached")
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			info, _ := NewCloudInfo([]string{"dummyProvider"}, test.ciStore, cloudinfoLogger)
+			_, err := info.GetZones(test.provider, test.service, test.region)
+			test.checker(err)
+		})
+	}
+}
+
+func TestCachingCloudInfo_GetSpotPriceHistory(t *testing.T) {
+	tests := []struct {
+		name                      string
+		ciStore                   CloudInfoStore
+		spotPriceHistoryProviders []string
+		checker                   func(history []types.TimestampedPrice, err error)
+	}{
+		{
+			name:                      "the stored spot price history is returned",
+			ciStore:                   &DummyCloudInfoStore{},
+			spotPriceHistoryProviders: []string{"dummyProvider"},
+			checker: func(history []types.TimestampedPrice, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, 2, len(history))
+				assert.Equal(t, 0.121, history[1].Price)
+			},
+		},
+		{
+			name:    "the provider doesn't support spot price history",
+			ciStore: &DummyCloudInfoStore{},
+			checker: func(history []types.TimestampedPrice, err error) {
+				assert.Nil(t, history, "the history should be nil")
+				assert.True(t, errors.Is(err, ErrSpotPriceHistoryNotSupported), "the error should be ErrSpotPriceHistoryNotSupported")
+			},
+		},
+		{
+			name:                      "the spot price history is not yet cached",
+			ciStore:                   &DummyCloudInfoStore{TcId: notCached},
+			spotPriceHistoryProviders: []string{"dummyProvider"},
+			checker: func(history []types.TimestampedPrice, err error) {
+				assert.Nil(t, history, "the history should be nil")
+				assert.True(t, errors.Is(err, ErrNotCached), "the error should be ErrNotCached")
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			info, _ := NewCloudInfoWithOptions([]string{"dummyProvider"}, test.ciStore, cloudinfoLogger,
+				CloudInfoOptions{SpotPriceHistoryProviders: test.spotPriceHistoryProviders})
+			test.checker(info.GetSpotPriceHistory("dummyProvider", "dummyRegion", "dummyType1"))
+		})
+	}
+}
+
+func TestCachingCloudInfo_GetZoneSpotPrice(t *testing.T) {
+	tests := []struct {
+		name         string
+		ciStore      CloudInfoStore
+		instanceType string
+		zone         string
+		checker      func(price float64, err error)
+	}{
+		{
+			name:         "the spot price of the zone is returned",
+			ciStore:      &DummyCloudInfoStore{},
+			instanceType: "dummyType1",
+			zone:         "dummyZone2",
+			checker: func(price float64, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, 0.121, price)
+			},
+		},
+		{
+			name:         "the zone has no spot price",
+			ciStore:      &DummyCloudInfoStore{},
+			instanceType: "dummyType3",
+			zone:         "dummyZone1",
+			checker: func(price float64, err error) {
+				assert.Equal(t, 0.0, price)
+				assert.True(t, errors.Is(err, ErrZoneSpotPriceNotFound), "the error should be ErrZoneSpotPriceNotFound")
+			},
+		},
+		{
+			name:         "the price is not yet cached",
+			ciStore:      &DummyCloudInfoStore{TcId: notCached},
+			instanceType: "dummyType1",
+			zone:         "dummyZone1",
+			checker: func(price float64, err error) {
+				assert.True(t, errors.Is(err, ErrNotCached), "the error should be ErrNotCached")
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			info, _ := NewCloudInfo([]string{"dummyProvider"}, test.ciStore, cloudinfoLogger)
+			test.checker(info.GetZoneSpotPrice("dummyProvider", "dummyRegion", test.instanceType, test.zone))
+		})
+	}
+}
+
+func TestCachingCloudInfo_GetProductFamilies(t *testing.T) {
+	tests := []struct {
+		name    string
+		ciStore CloudInfoStore
+		checker func(families map[string][]types.ProductDetails, err error)
+	}{
+		{
+			name:    "the products are grouped by instance type family",
+			ciStore: &DummyCloudInfoStore{TcId: instanceFamilies},
+			checker: func(families map[string][]types.ProductDetails, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, 2, len(families))
+				assert.Equal(t, []string{"m5.large", "m5.xlarge"}, productTypes(families["m5"]))
+				assert.Equal(t, []string{"c4.2xlarge"}, productTypes(families["c4"]))
+			},
+		},
+		{
+			name:    "error - the products are not yet cached",
+			ciStore: &DummyCloudInfoStore{TcId: notCached},
+			checker: func(families map[string][]types.ProductDetails, err error) {
+				assert.Nil(t, families, "the families should be nil")
+				assert.True(t, errors.Is(err, ErrNotCached), "the error should be ErrNotCached")
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			info, _ := NewCloudInfo([]string{"dummyProvider"}, test.ciStore, cloudinfoLogger)
+			test.checker(info.GetProductFamilies("dummyProvider", "dummyService", "dummyRegion"))
+		})
+	}
+}
+
+func TestFamilyFunc(t *testing.T) {
+	tests := []struct {
+		provider     string
+		instanceType string
+		family       string
+	}{
+		{provider: "amazon", instanceType: "m5.xlarge", family: "m5"},
+		{provider: "alibaba", instanceType: "ecs.g5.large", family: "ecs.g5"},
+		{provider: "oracle", instanceType: "VM.Standard2.1", family: "VM.Standard2"},
+		{provider: "google", instanceType: "n1-standard-4", family: "n1-standard"},
+		{provider: "azure", instanceType: "Standard_D2s_v3", family: "Standard_Ds_v3"},
+		{provider: "unknown", instanceType: "c4.2xlarge", family: "c4"},
+	}
+	for _, test := range tests {
+		t.Run(test.provider, func(t *testing.T) {
+			assert.Equal(t, test.family, familyFunc(test.provider)(test.instanceType))
+		})
+	}
+}
+
+func TestCachingCloudInfo_GetSpotProductDetails(t *testing.T) {
+	tests := []struct {
+		name    string
+		ciStore CloudInfoStore
+		checker func(details []types.ProductDetails, err error)
+	}{
+		{
+			name:    "only the products with spot prices are returned ordered by the cheapest zone",
+			ciStore: &DummyCloudInfoStore{},
+			checker: func(details []types.ProductDetails, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, []string{"dummyType1", "dummyType2"}, productTypes(details))
+			},
+		},
+		{
+			name:    "error - the products are not yet cached",
+			ciStore: &DummyCloudInfoStore{TcId: notCached},
+			checker: func(details []types.ProductDetails, err error) {
+				assert.Nil(t, details, "the details should be nil")
+				assert.True(t, errors.Is(err, ErrNotCached), "the error should be ErrNotCached")
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			info, _ := NewCloudInfo([]string{"dummyProvider"}, test.ciStore, cloudinfoLogger)
+			test.checker(info.GetSpotProductDetails("dummyProvider", "dummyService", "dummyRegion"))
+		})
+	}
+}
+
+func TestZonePrices(t *testing.T) {
+	tests := []struct {
+		name       string
+		spotPrices types.SpotPriceInfo
+		zones      []string
+		checker    func(prices map[string]types.Price)
+	}{
+		{
+			name:       "the regional on demand price is repeated in every zone",
+			spotPrices: types.SpotPriceInfo{"zoneA": 0.1},
+			zones:      []string{"zoneA", "zoneB"},
+			checker: func(prices map[string]types.Price) {
+				assert.Equal(t, map[string]types.Price{
+					"zoneA": {OnDemandPrice: 0.32, SpotPrice: types.SpotPriceInfo{"zoneA": 0.1}},
+					"zoneB": {OnDemandPrice: 0.32, SpotPrice: types.SpotPriceInfo{}},
+				}, prices)
+			},
+		},
+		{
+			name:       "the spot zones are included when the zones are not cached",
+			spotPrices: types.SpotPriceInfo{"zoneA": 0.1},
+			checker: func(prices map[string]types.Price) {
+				assert.Equal(t, map[string]types.Price{
+					"zoneA": {OnDemandPrice: 0.32, SpotPrice: types.SpotPriceInfo{"zoneA": 0.1}},
+				}, prices)
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.checker(zonePrices(0.32, test.spotPrices, test.zones))
+		})
+	}
+}
+
+func TestCachingCloudInfo_FindCheapestRegion(t *testing.T) {
+	store := newTestCloudInfoStore()
+	store.StoreRegions("dummyProvider", "dummyService", map[string]string{
+		"region1": "Region 1",
+		"region2": "Region 2",
+		"region3": "Region 3",
+	})
+	store.StoreVm("dummyProvider", "dummyService", "region1", []types.VMInfo{
+		{Type: "type1", OnDemandPrice: 0.4, Cpus: 2, Mem: 8},
+		{Type: "type2", OnDemandPrice: 0.1, Cpus: 1, Mem: 2},
+	})
+	store.StoreVm("dummyProvider", "dummyService", "region2", []types.VMInfo{
+		{Type: "type1", OnDemandPrice: 0.3, Cpus: 2, Mem: 8},
+	})
+	store.StorePrice("dummyProvider", "region1", "type1", types.Price{OnDemandPrice: 0.4, SpotPrice: types.SpotPriceInfo{"region1a": 0.05}})
+	store.StorePrice("dummyProvider", "region2", "type1", types.Price{OnDemandPrice: 0.3, SpotPrice: types.SpotPriceInfo{"region2a": 0.09}})
+
+	tests := []struct {
+		name    string
+		minCpu  float64
+		minMem  float64
+		spot    bool
+		checker func(region string, product types.ProductDetails, err error)
+	}{
+		{
+			name:   "the region with the cheapest on demand price is found",
+			minCpu: 2,
+			minMem: 4,
+			checker: func(region string, product types.ProductDetails, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, "region2", region)
+				assert.Equal(t, "type1", product.Type)
+			},
+		},
+		{
+			name:   "the region with the cheapest spot price is found",
+			minCpu: 2,
+			minMem: 4,
+			spot:   true,
+			checker: func(region string, product types.ProductDetails, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, "region1", region)
+				assert.Equal(t, "type1", product.Type)
+			},
+		},
+		{
+			name:   "error - no product matches the requirements",
+			minCpu: 64,
+			checker: func(region string, product types.ProductDetails, err error) {
+				assert.EqualError(t, err, "no product matches the requirements in any region")
+				assert.Equal(t, "", region)
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			info, _ := NewCloudInfo([]string{"dummyProvider"}, store, cloudinfoLogger)
+			test.checker(info.FindCheapestRegion("dummyProvider", "dummyService", test.minCpu, test.minMem, test.spot))
+		})
+	}
+}
+
+func TestCachingCloudInfo_GetScrapeErrors(t *testing.T) {
+	store := newTestCloudInfoStore()
+	info, _ := NewCloudInfo([]string{"dummyProvider"}, store, cloudinfoLogger)
+
+	scrapeErrors, err := info.GetScrapeErrors("dummyProvider")
+	assert.Nil(t, err, "the error should be nil")
+	assert.Equal(t, []types.ScrapeError{}, scrapeErrors, "no scrape errors should be returned when none is recorded")
+
+	recorded := []types.ScrapeError{{Service: "compute", Region: "dummyRegion", Message: "failed to retrieve zones"}}
+	store.StoreScrapeErrors("dummyProvider", recorded)
+
+	scrapeErrors, err = info.GetScrapeErrors("dummyProvider")
+	assert.Nil(t, err, "the error should be nil")
+	assert.Equal(t, recorded, scrapeErrors)
+
+	_, err = info.GetScrapeErrors("unknownProvider")
+	assert.True(t, errors.Is(err, ErrProviderNotSupported), "the error should be ErrProviderNotSupported")
+}
+
+func TestCachingCloudInfo_GetProductDetails_burstable(t *testing.T) {
+	store := newTestCloudInfoStore()
+	store.StoreVm("dummyProvider", "dummyService", "dummyRegion", []types.VMInfo{
+		{Type: "t3.medium", OnDemandPrice: 0.0416, Cpus: 2, Mem: 4, Burstable: true, BaselinePerf: 20},
+		{Type: "t2.micro", OnDemandPrice: 0.0116, Cpus: 1, Mem: 1},
+		{Type: "m5.large", OnDemandPrice: 0.096, Cpus: 2, Mem: 8},
+	})
+
+	info, _ := NewCloudInfo([]string{"dummyProvider"}, store, cloudinfoLogger)
+	details, err := info.GetProductDetails("dummyProvider", "dummyService", "dummyRegion")
+	assert.Nil(t, err, "the error should be nil")
+	assert.Equal(t, 3, len(details))
+
+	assert.True(t, details[0].Burstable, "the reported burstable flag should be kept")
+	assert.Equal(t, 20.0, details[0].BaselinePerf)
+	assert.True(t, details[1].Burstable, "the burstable flag should be derived from the instance type")
+	assert.Equal(t, 0.0, details[1].BaselinePerf)
+	assert.False(t, details[2].Burstable, "the instance type should not be burstable")
+}
+
+func TestCachingCloudInfo_GetProductDetails_reserved(t *testing.T) {
+	info, _ := NewCloudInfo([]string{"dummyProvider"}, &DummyCloudInfoStore{}, cloudinfoLogger)
+	details, err := info.GetProductDetails("dummyProvider", "dummyService", "dummyRegion")
+	assert.Nil(t, err, "the error should be nil")
+
+	reserved := make(map[string]map[string]float64)
+	for _, pd := range details {
+		reserved[pd.Type] = pd.ReservedPrice
+	}
+	assert.Equal(t, map[string]float64{"1yr-all-upfront": 0.2, "3yr-no-upfront": 0.15}, reserved["dummyType1"])
+	assert.Nil(t, reserved["dummyType2"], "the reserved prices should be empty for instance types without reserved terms")
+}
+
+func TestCachingCloudInfo_FilterProductDetails_ntwGbps(t *testing.T) {
+	store := newTestCloudInfoStore()
+	store.StoreVm("dummyProvider", "dummyService", "dummyRegion", []types.VMInfo{
+		{Type: "dummyType1", OnDemandPrice: 0.32, NtwGbps: 1},
+		{Type: "dummyType2", OnDemandPrice: 0.64, NtwGbps: 10},
+		{Type: "dummyType3", OnDemandPrice: 1.28},
+	})
+
+	info, _ := NewCloudInfo([]string{"dummyProvider"}, store, cloudinfoLogger)
+	details, err := info.FilterProductDetails("dummyProvider", "dummyService", "dummyRegion", ProductFilter{MinNtwGbps: 5})
+	assert.Nil(t, err, "the error should be nil")
+	assert.Equal(t, []string{"dummyType2"}, productTypes(details))
+}
+
+func TestCachingCloudInfo_FilterProductDetails_memPerCpu(t *testing.T) {
+	store := newTestCloudInfoStore()
+	store.StoreVm("dummyProvider", "dummyService", "dummyRegion", []types.VMInfo{
+		{Type: "dummyType1", OnDemandPrice: 0.32, Cpus: 2, Mem: 32},
+		{Type: "dummyType2", OnDemandPrice: 0.52, Cpus: 4, Mem: 16},
+		{Type: "dummyType3", OnDemandPrice: 1.2, Mem: 64},
+	})
+
+	tests := []struct {
+		name   string
+		filter ProductFilter
+		types  []string
+	}{
+		{
+			name:   "the memory optimized products match the minimum ratio",
+			filter: ProductFilter{MinMemPerCpu: 8},
+			types:  []string{"dummyType1"},
+		},
+		{
+			name:   "the memory optimized products are excluded by the maximum ratio",
+			filter: ProductFilter{MaxMemPerCpu: 8},
+			types:  []string{"dummyType2"},
+		},
+		{
+			name:   "the products without cpus are kept without ratio bounds",
+			filter: ProductFilter{},
+			types:  []string{"dummyType1", "dummyType2", "dummyType3"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			info, _ := NewCloudInfo([]string{"dummyProvider"}, store, cloudinfoLogger)
+			details, err := info.FilterProductDetails("dummyProvider", "dummyService", "dummyRegion", test.filter)
+			assert.Nil(t, err, "the error should be nil")
+			assert.Equal(t, test.types, productTypes(details))
+		})
+	}
+}
+
+func TestCachingCloudInfo_GetRegionsWithCounts(t *testing.T) {
+	store := newTestCloudInfoStore()
+	store.StoreRegions("dummyProvider", "dummyService", map[string]string{
+		"dummyRegion1": "Dummy Region 1",
+		"dummyRegion2": "Dummy Region 2",
+		"dummyRegion3": "Dummy Region 3",
+	})
+	store.StoreVm("dummyProvider", "dummyService", "dummyRegion1", []types.VMInfo{{Type: "dummyType1"}})
+	store.StoreVm("dummyProvider", "dummyService", "dummyRegion2", []types.VMInfo{{Type: "dummyType1"}, {Type: "dummyType2"}})
+
+	info, _ := NewCloudInfo([]string{"dummyProvider"}, store, cloudinfoLogger)
+	counts, err := info.GetRegionsWithCounts("dummyProvider", "dummyService")
+	assert.Nil(t, err, "the error should be nil")
+	assert.Equal(t, map[string]int{"dummyRegion1": 1, "dummyRegion2": 2, "dummyRegion3": 0}, counts)
+
+	_, err = info.GetRegionsWithCounts("dummyProvider", "otherService")
+	assert.True(t, errors.Is(err, ErrNotCached), "the error should be not cached if the regions are not cached")
+}
+
+func TestCachingCloudInfo_FindInstanceTypeRegions(t *testing.T) {
+	store := newTestCloudInfoStore()
+	store.StoreRegions("dummyProvider", "dummyService", map[string]string{
+		"dummyRegion1": "Dummy Region 1",
+		"dummyRegion2": "Dummy Region 2",
+		"dummyRegion3": "Dummy Region 3",
+	})
+	store.StoreVm("dummyProvider", "dummyService", "dummyRegion1", []types.VMInfo{{Type: "dummyType1"}, {Type: "dummyType2"}})
+	store.StoreVm("dummyProvider", "dummyService", "dummyRegion2", []types.VMInfo{{Type: "dummyType2"}})
+	store.StoreVm("dummyProvider", "dummyService", "dummyRegion3", []types.VMInfo{{Type: "dummyType1"}, {Type: "dummyType22"}})
+
+	info, _ := NewCloudInfo([]string{"dummyProvider"}, store, cloudinfoLogger)
+
+	regions, err := info.FindInstanceTypeRegions("dummyProvider", "dummyService", "dummyType2")
+	assert.Nil(t, err, "the error should be nil")
+	assert.Equal(t, []string{"dummyRegion1", "dummyRegion2"}, regions, "the instance type should be matched exactly")
+
+	regions, err = info.FindInstanceTypeRegions("dummyProvider", "dummyService", "unknownType")
+	assert.Nil(t, err, "the error should be nil")
+	assert.Empty(t, regions)
+
+	_, err = info.FindInstanceTypeRegions("dummyProvider", "otherService", "dummyType2")
+	assert.True(t, errors.Is(err, ErrNotCached), "the error should be not cached if the regions are not cached")
+}
+
+func TestCachingCloudInfo_GetProduct(t *testing.T) {
+	tests := []struct {
+		name         string
+		instanceType string
+		checker      func(product types.ProductDetails, err error)
+	}{
+		{
+			name:         "the product of the instance type is returned with its prices",
+			instanceType: "dummyType2",
+			checker: func(product types.ProductDetails, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, "dummyType2", product.Type)
+				assert.Equal(t, 0.64, product.OnDemandPrice)
+				assert.Equal(t, 4.0, product.Cpus)
+				assert.True(t, product.PriceAvailable, "the price should be available")
+			},
+		},
+		{
+			name:         "not found error is returned for unknown instance types",
+			instanceType: "unknownType",
+			checker: func(product types.ProductDetails, err error) {
+				assert.True(t, errors.Is(err, ErrProductNotFound), "the error should be ErrProductNotFound")
+				assert.Equal(t, types.ProductDetails{}, product)
+			},
+		},
+	}
+
+	store := newTestCloudInfoStore()
+	store.StoreVm("dummyProvider", "dummyService", "dummyRegion", []types.VMInfo{
+		{Type: "dummyType1", OnDemandPrice: 0.32, Cpus: 2, Mem: 8},
+		{Type: "dummyType2", OnDemandPrice: 0.64, Cpus: 4, Mem: 16},
+	})
+	store.StorePrice("dummyProvider", "dummyRegion", "dummyType1", types.Price{OnDemandPrice: 0.32})
+	store.StorePrice("dummyProvider", "dummyRegion", "dummyType2", types.Price{OnDemandPrice: 0.64})
+
+	info, _ := NewCloudInfo([]string{"dummyProvider"}, store, cloudinfoLogger)
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.checker(info.GetProduct("dummyProvider", "dummyService", "dummyRegion", test.instanceType))
+		})
+	}
+}
+
+func TestCachingCloudInfo_GetProductDetails_priceAvailable(t *testing.T) {
+	store := newTestCloudInfoStore()
+	store.StoreVm("dummyProvider", "dummyService", "dummyRegion", []types.VMInfo{
+		{Type: "dummyType1", Cpus: 2, Mem: 8},
+		{Type: "dummyType2", Cpus: 4, Mem: 16},
+	})
+	store.StorePrice("dummyProvider", "dummyRegion", "dummyType1", types.Price{OnDemandPrice: 0})
+
+	info, _ := NewCloudInfo([]string{"dummyProvider"}, store, cloudinfoLogger)
+	details, err := info.GetProductDetails("dummyProvider", "dummyService", "dummyRegion")
+	assert.Nil(t, err, "the error should be nil")
+	assert.Equal(t, 2, len(details), "the products without price should be returned as well")
+
+	assert.True(t, details[0].PriceAvailable, "the cached zero price should be available")
+	assert.False(t, details[1].PriceAvailable, "the price should not be available if it's not cached")
+}
+
+func TestProductDetails_perDollar(t *testing.T) {
+	pd := types.NewProductDetails(types.VMInfo{Type: "dummyType1", OnDemandPrice: 0.32, Cpus: 2, Mem: 8})
+	assert.Equal(t, 6.25, pd.CpuPerDollar())
+	assert.Equal(t, 25.0, pd.MemPerDollar())
+
+	free := types.NewProductDetails(types.VMInfo{Type: "dummyType2", Cpus: 2, Mem: 8})
+	assert.Equal(t, 0.0, free.CpuPerDollar(), "zero should be returned without on demand price")
+	assert.Equal(t, 0.0, free.MemPerDollar(), "zero should be returned without on demand price")
+}
+
+func TestProductDetails_unitPrices(t *testing.T) {
+	tests := []struct {
+		name        string
+		vm          types.VMInfo
+		pricePerCpu float64
+		pricePerGB  float64
+	}{
+		{
+			name:        "the on demand price is divided by the cpus and memory",
+			vm:          types.VMInfo{Type: "dummyType1", OnDemandPrice: 0.32, Cpus: 2, Mem: 32},
+			pricePerCpu: 0.16,
+			pricePerGB:  0.01,
+		},
+		{
+			name:        "zero is returned for products without cpus and memory",
+			vm:          types.VMInfo{Type: "dummyType2", OnDemandPrice: 0.32},
+			pricePerCpu: 0,
+			pricePerGB:  0,
+		},
+		{
+			name:        "zero is returned for products without on demand price",
+			vm:          types.VMInfo{Type: "dummyType3", Cpus: 2, Mem: 32},
+			pricePerCpu: 0,
+			pricePerGB:  0,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pd := types.NewProductDetails(test.vm)
+			assert.InDelta(t, test.pricePerCpu, pd.PricePerCpu, 1e-9)
+			assert.InDelta(t, test.pricePerGB, pd.PricePerGB, 1e-9)
+		})
+	}
+}
+
+func TestProductDetails_SpotStats(t *testing.T) {
+	pd := types.NewProductDetails(types.VMInfo{Type: "dummyType1", OnDemandPrice: 0.32})
+	pd.SpotPrice = []types.ZonePrice{
+		*types.NewZonePrice("dummyZone1", 0.112),
+		*types.NewZonePrice("dummyZone2", 0.121),
+		*types.NewZonePrice("dummyZone3", 0.103),
+	}
+
+	min, avg, max, zones := pd.SpotStats()
+	assert.Equal(t, 0.103, min)
+	assert.InDelta(t, 0.112, avg, 1e-9)
+	assert.Equal(t, 0.121, max)
+	assert.Equal(t, 3, zones)
+
+	min, avg, max, zones = types.NewProductDetails(types.VMInfo{Type: "dummyType3", OnDemandPrice: 1.2}).SpotStats()
+	assert.Equal(t, []float64{0, 0, 0}, []float64{min, avg, max}, "zeros should be returned without spot price")
+	assert.Equal(t, 0, zones)
+}
+
+func TestCachingCloudInfo_IsReady(t *testing.T) {
+	store := newTestCloudInfoStore()
+	info, _ := NewCloudInfo([]string{"dummyProvider", "otherProvider"}, store, cloudinfoLogger)
+
+	ready, providers := info.IsReady()
+	assert.False(t, ready, "no provider should be ready before scraping")
+	assert.Equal(t, map[string]bool{"dummyProvider": false, "otherProvider": false}, providers)
+
+	store.StoreStatus("dummyProvider", "1546300800000")
+	ready, providers = info.IsReady()
+	assert.False(t, ready, "not every provider should be ready")
+	assert.Equal(t, map[string]bool{"dummyProvider": true, "otherProvider": false}, providers)
+
+	store.StoreStatus("otherProvider", "1546300800000")
+	ready, providers = info.IsReady()
+	assert.True(t, ready, "every provider should be ready")
+	assert.Equal(t, map[string]bool{"dummyProvider": true, "otherProvider": true}, providers)
+}
+
+func TestCachingCloudInfo_GetHealth(t *testing.T) {
+	store := newTestCloudInfoStore()
+	store.StoreStatus("dummyProvider", "1546300800000")
+	store.StoreServices("dummyProvider", []types.Service{{Service: "compute"}, {Service: "dummyService"}})
+	store.StoreRegions("dummyProvider", "compute", map[string]string{"region1": "Region 1", "region2": "Region 2"})
+	store.StoreRegions("dummyProvider", "dummyService", map[string]string{"region2": "Region 2", "region3": "Region 3"})
+	store.StoreScrapeErrors("dummyProvider", []types.ScrapeError{
+		{Service: "compute", Region: "region1", Message: "older failure", Time: time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{Service: "dummyService", Region: "region3", Message: "latest failure", Time: time.Date(2019, 1, 1, 1, 0, 0, 0, time.UTC)},
+	})
+
+	info, _ := NewCloudInfo([]string{"dummyProvider", "otherProvider"}, store, cloudinfoLogger)
+	health := info.GetHealth()
+
+	assert.Equal(t, 2, len(health))
+	assert.Equal(t, ProviderHealth{
+		Ready:         true,
+		LastScrape:    time.Unix(1546300800, 0),
+		CachedRegions: 3,
+		ErrorCount:    2,
+		LastError:     "latest failure",
+	}, health["dummyProvider"])
+	assert.Equal(t, ProviderHealth{}, health["otherProvider"], "the never scraped provider should not be ready")
+}
+
+func TestCachingCloudInfo_GetProductDetailsMultiRegion(t *testing.T) {
+	store := newTestCloudInfoStore()
+	store.StoreVm("dummyProvider", "dummyService", "region1", []types.VMInfo{{Type: "type1", OnDemandPrice: 0.1, Cpus: 2, Mem: 8}})
+	store.StoreVm("dummyProvider", "dummyService", "region2", []types.VMInfo{
+		{Type: "type1", OnDemandPrice: 0.12, Cpus: 2, Mem: 8},
+		{Type: "type2", OnDemandPrice: 0.24, Cpus: 4, Mem: 16},
+	})
+
+	tests := []struct {
+		name     string
+		provider string
+		regions  []string
+		checker  func(details map[string][]types.ProductDetails, err error)
+	}{
+		{
+			name:     "the regions not cached are skipped",
+			provider: "dummyProvider",
+			regions:  []string{"region1", "region2", "region3"},
+			checker: func(details map[string][]types.ProductDetails, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, 2, len(details))
+				assert.Equal(t, 1, len(details["region1"]))
+				assert.Equal(t, 2, len(details["region2"]))
+				_, ok := details["region3"]
+				assert.False(t, ok, "the missing region should be skipped")
+			},
+		},
+		{
+			name:     "none of the regions is cached",
+			provider: "dummyProvider",
+			regions:  []string{"region3"},
+			checker: func(details map[string][]types.ProductDetails, err error) {
+				assert.Nil(t, details, "the details should be nil")
+				assert.True(t, errors.Is(err, ErrNotCached), "the error should be ErrNotCached")
+			},
+		},
+		{
+			name:     "unsupported provider",
+			provider: "unknownProvider",
+			regions:  []string{"region1"},
+			checker: func(details map[string][]types.ProductDetails, err error) {
+				assert.Nil(t, details, "the details should be nil")
+				assert.True(t, errors.Is(err, ErrProviderNotSupported), "the error should be ErrProviderNotSupported")
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			info, _ := NewCloudInfo([]string{"dummyProvider"}, store, cloudinfoLogger)
+			test.checker(info.GetProductDetailsMultiRegion(test.provider, "dummyService", test.regions))
+		})
+	}
+}
+
+func TestCachingCloudInfo_FilterServiceImages(t *testing.T) {
+	store := newTestCloudInfoStore()
+	store.StoreImage("dummyProvider", "dummyService", "dummyRegion", []types.Image{
+		{Name: "image1", Os: "ubuntu", OsVersion: "20.04"},
+		{Name: "image2", Os: "Ubuntu", OsVersion: "18.04"},
+		{Name: "image3", Os: "centos", OsVersion: "7"},
+		{Name: "image4"},
+	})
+
+	tests := []struct {
+		name    string
+		filter  ImageFilter
+		checker func(images []types.Image, err error)
+	}{
+		{
+			name:   "the operating system is matched case insensitively",
+			filter: ImageFilter{Os: "UBUNTU"},
+			checker: func(images []types.Image, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, []string{"image1", "image2"}, imageNames(images))
+			},
+		},
+		{
+			name:   "the operating system version is matched",
+			filter: ImageFilter{Os: "ubuntu", OsVersion: "20.04"},
+			checker: func(images []types.Image, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, []string{"image1"}, imageNames(images))
+			},
+		},
+		{
+			name:   "empty filter matches every image",
+			filter: ImageFilter{},
+			checker: func(images []types.Image, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, []string{"image1", "image2", "image3", "image4"}, imageNames(images))
+			},
+		},
+		{
+			name:   "no image matches",
+			filter: ImageFilter{Os: "windows"},
+			checker: func(images []types.Image, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Empty(t, images)
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			info, _ := NewCloudInfo([]string{"dummyProvider"}, store, cloudinfoLogger)
+			test.checker(info.FilterServiceImages("dummyProvider", "dummyService", "dummyRegion", test.filter))
+		})
+	}
+}
+
+func imageNames(images []types.Image) []string {
+	var names []string
+	for _, image := range images {
+		names = append(names, image.Name)
+	}
+	return names
+}
+
+func TestCachingCloudInfo_FilterProductDetails_currentGen(t *testing.T) {
+	store := newTestCloudInfoStore()
+	store.StoreVm("dummyProvider", "dummyService", "genRegion", []types.VMInfo{
+		{Type: "m5.large", OnDemandPrice: 0.096, Cpus: 2, Mem: 8, CurrentGen: true},
+		{Type: "m4.large", OnDemandPrice: 0.1, Cpus: 2, Mem: 8},
+		{Type: "m3.large", OnDemandPrice: 0.133, Cpus: 2, Mem: 7.5},
+	})
+	store.StoreVm("dummyProvider", "dummyService", "noGenRegion", []types.VMInfo{
+		{Type: "type1", OnDemandPrice: 0.1, Cpus: 2, Mem: 8},
+		{Type: "type2", OnDemandPrice: 0.2, Cpus: 4, Mem: 16},
+	})
+
+	tests := []struct {
+		name    string
+		region  string
+		filter  ProductFilter
+		checker func(details []types.ProductDetails, err error)
+	}{
+		{
+			name:   "previous generation products are dropped",
+			region: "genRegion",
+			filter: ProductFilter{CurrentGenOnly: true},
+			checker: func(details []types.ProductDetails, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, []string{"m5.large"}, productTypes(details))
+				assert.True(t, details[0].CurrentGen)
+			},
+		},
+		{
+			name:   "previous generation products are kept without the filter",
+			region: "genRegion",
+			filter: ProductFilter{},
+			checker: func(details []types.ProductDetails, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, []string{"m5.large", "m4.large", "m3.large"}, productTypes(details))
+				assert.False(t, details[1].CurrentGen)
+			},
+		},
+		{
+			name:   "every product is current if generations are not reported",
+			region: "noGenRegion",
+			filter: ProductFilter{CurrentGenOnly: true},
+			checker: func(details []types.ProductDetails, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, []string{"type1", "type2"}, productTypes(details))
+				assert.True(t, details[0].CurrentGen)
+				assert.True(t, details[1].CurrentGen)
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			info, _ := NewCloudInfo([]string{"dummyProvider"}, store, cloudinfoLogger)
+			test.checker(info.FilterProductDetails("dummyProvider", "dummyService", test.region, test.filter))
+		})
+	}
+}
+
+func TestCachingCloudInfo_FilterProductDetails_zone(t *testing.T) {
+	store := newTestCloudInfoStore()
+	store.StoreVm("dummyProvider", "dummyService", "dummyRegion", []types.VMInfo{
+		{Type: "dummyType1", OnDemandPrice: 0.32, Cpus: 2, Mem: 32, Zones: []string{"dummyZone1"}},
+		{Type: "dummyType2", OnDemandPrice: 0.52, Cpus: 4, Mem: 16, Zones: []string{"dummyZone1", "dummyZone2"}},
+		{Type: "dummyType3", OnDemandPrice: 1.2, Cpus: 8, Mem: 64},
+	})
+
+	tests := []struct {
+		name    string
+		filter  ProductFilter
+		checker func(details []types.ProductDetails, err error)
+	}{
+		{
+			name:   "every product is available in the zone",
+			filter: ProductFilter{Zone: "dummyZone1"},
+			checker: func(details []types.ProductDetails, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, []string{"dummyType1", "dummyType2", "dummyType3"}, productTypes(details))
+			},
+		},
+		{
+			name:   "products limited to other zones are dropped",
+			filter: ProductFilter{Zone: "dummyZone2"},
+			checker: func(details []types.ProductDetails, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, []string{"dummyType2", "dummyType3"}, productTypes(details))
+			},
+		},
+		{
+			name:   "products without zones match an unknown zone",
+			filter: ProductFilter{Zone: "unknownZone"},
+			checker: func(details []types.ProductDetails, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, []string{"dummyType3"}, productTypes(details))
+			},
+		},
+		{
+			name:   "every product is returned without the filter",
+			filter: ProductFilter{},
+			checker: func(details []types.ProductDetails, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, []string{"dummyType1", "dummyType2", "dummyType3"}, productTypes(details))
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			info, _ := NewCloudInfo([]string{"dummyProvider"}, store, cloudinfoLogger)
+			test.checker(info.FilterProductDetails("dummyProvider", "dummyService", "dummyRegion", test.filter))
+		})
+	}
+}
+
+func TestCachingCloudInfo_FilterProductDetails_pricingModels(t *testing.T) {
+	store := newTestCloudInfoStore()
+	store.StoreVm("dummyProvider", "dummyService", "dummyRegion", []types.VMInfo{
+		{Type: "dummyType1", OnDemandPrice: 0.32, Cpus: 2, Mem: 32},
+	})
+	store.StorePrice("dummyProvider", "dummyRegion", "dummyType1", types.Price{OnDemandPrice: 0.32,
+		SpotPrice:     types.SpotPriceInfo{"dummyZone1": 0.112},
+		ReservedPrice: map[string]float64{"1yr-all-upfront": 0.2}})
+
+	tests := []struct {
+		name    string
+		filter  ProductFilter
+		checker func(details []types.ProductDetails, err error)
+	}{
+		{
+			name:   "on demand and spot prices",
+			filter: ProductFilter{PricingModels: []PricingModel{OnDemandPricing, SpotPricing}},
+			checker: func(details []types.ProductDetails, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, 0.32, details[0].OnDemandPrice)
+				assert.Equal(t, []types.ZonePrice{{Zone: "dummyZone1", Price: 0.112}}, details[0].SpotPrice)
+				assert.Nil(t, details[0].ReservedPrice, "the reserved prices should not be returned")
+			},
+		},
+		{
+			name:   "on demand prices only",
+			filter: ProductFilter{PricingModels: []PricingModel{OnDemandPricing}},
+			checker: func(details []types.ProductDetails, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, 0.32, details[0].OnDemandPrice)
+				assert.Nil(t, details[0].SpotPrice, "the spot prices should not be returned")
+				assert.Nil(t, details[0].ReservedPrice, "the reserved prices should not be returned")
+			},
+		},
+		{
+			name:   "every price is returned without pricing models",
+			filter: ProductFilter{},
+			checker: func(details []types.ProductDetails, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, []types.ZonePrice{{Zone: "dummyZone1", Price: 0.112}}, details[0].SpotPrice)
+				assert.Equal(t, map[string]float64{"1yr-all-upfront": 0.2}, details[0].ReservedPrice)
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			info, _ := NewCloudInfo([]string{"dummyProvider"}, store, cloudinfoLogger)
+			test.checker(info.FilterProductDetails("dummyProvider", "dummyService", "dummyRegion", test.filter))
+		})
+	}
+}
+
+func TestCachingCloudInfo_GetProductDetailsETag(t *testing.T) {
+	store := newTestCloudInfoStore()
+	store.StoreVm("dummyProvider", "dummyService", "dummyRegion", []types.VMInfo{
+		{Type: "type1", OnDemandPrice: 0.1, Cpus: 2, Mem: 8},
+		{Type: "type2", OnDemandPrice: 0.2, Cpus: 4, Mem: 16},
+	})
+	store.StorePrice("dummyProvider", "dummyRegion", "type1", types.Price{OnDemandPrice: 0.1,
+		SpotPrice: types.SpotPriceInfo{"zone1": 0.03, "zone2": 0.04, "zone3": 0.05}})
+	store.StorePrice("dummyProvider", "dummyRegion", "type2", types.Price{OnDemandPrice: 0.2})
+
+	info, _ := NewCloudInfo([]string{"dummyProvider"}, store, cloudinfoLogger)
+
+	etag, err := info.GetProductDetailsETag("dummyProvider", "dummyService", "dummyRegion")
+	assert.Nil(t, err, "the error should be nil")
+	assert.NotEmpty(t, etag)
+
+	for i := 0; i < 10; i++ {
+		again, _ := info.GetProductDetailsETag("dummyProvider", "dummyService", "dummyRegion")
+		assert.Equal(t, etag, again, "the etag should be stable while the products don't change")
+	}
+
+	store.StorePrice("dummyProvider", "dummyRegion", "type1", types.Price{OnDemandPrice: 0.1,
+		SpotPrice: types.SpotPriceInfo{"zone1": 0.03, "zone2": 0.045, "zone3": 0.05}})
+	changed, err := info.GetProductDetailsETag("dummyProvider", "dummyService", "dummyRegion")
+	assert.Nil(t, err, "the error should be nil")
+	assert.NotEqual(t, etag, changed, "the etag should change with the price")
+
+	store.StoreZones("dummyProvider", "dummyService", "dummyRegion", []string{"zone1", "zone2", "zone3"})
+	zoned, err := info.GetProductDetailsETag("dummyProvider", "dummyService", "dummyRegion")
+	assert.Nil(t, err, "the error should be nil")
+	assert.NotEqual(t, changed, zoned, "the etag should change with the zones")
+
+	_, err = info.GetProductDetailsETag("dummyProvider", "dummyService", "otherRegion")
+	assert.True(t, errors.Is(err, ErrNotCached), "the error should be ErrNotCached")
+}
+
+func TestCachingCloudInfo_RecommendProducts(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     Requirement
+		checker func(products []ScoredProduct, err error)
+	}{
+		{
+			name: "cheap products are preferred",
+			req:  Requirement{MinCpu: 2, PriceWeight: 2, CpuWeight: 1},
+			checker: func(products []ScoredProduct, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, []string{"dummyType1", "dummyType2", "dummyType3"}, scoredProductTypes(products))
+				assert.InDelta(t, 0.667, products[0].Score, 0.001)
+				assert.InDelta(t, 0.626, products[1].Score, 0.001)
+				assert.InDelta(t, 0.333, products[2].Score, 0.001)
+			},
+		},
+		{
+			name: "products failing the minimums are excluded",
+			req:  Requirement{MinCpu: 4, PriceWeight: 1, MemWeight: 2},
+			checker: func(products []ScoredProduct, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, []string{"dummyType3", "dummyType2"}, scoredProductTypes(products))
+				assert.InDelta(t, 0.667, products[0].Score, 0.001)
+				assert.InDelta(t, 0.333, products[1].Score, 0.001)
+			},
+		},
+		{
+			name: "weights are required",
+			req:  Requirement{MinCpu: 2},
+			checker: func(products []ScoredProduct, err error) {
+				assert.Nil(t, products, "the products should be nil")
+				assert.EqualError(t, err, "invalid requirement weights")
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			info, _ := NewCloudInfo([]string{"dummyProvider"}, &DummyCloudInfoStore{}, cloudinfoLogger)
+			test.checker(info.RecommendProducts("dummyProvider", "dummyService", "dummyRegion", test.req))
+		})
+	}
+}
+
+func scoredProductTypes(products []ScoredProduct) []string {
+	var ts []string
+	for _, product := range products {
+		ts = append(ts, product.Type)
+	}
+	return ts
+}
+
+func TestCachingCloudInfo_GetAttrRange(t *testing.T) {
+	store := newTestCloudInfoStore()
+	store.StoreRegions("dummyProvider", "dummyService", map[string]string{"region1": "Region 1", "region2": "Region 2"})
+	store.StoreVm("dummyProvider", "dummyService", "region1", []types.VMInfo{
+		{Type: "type1", Cpus: 2, Mem: 8},
+		{Type: "type2", Cpus: 4, Mem: 16},
+	})
+	store.StoreVm("dummyProvider", "dummyService", "region2", []types.VMInfo{
+		{Type: "type1", Cpus: 2, Mem: 8},
+		{Type: "type3", Cpus: 8, Mem: 32},
+		{Type: "type4", Cpus: 16, Mem: 64},
+	})
+
+	tests := []struct {
+		name      string
+		service   string
+		attribute string
+		checker   func(min, max float64, count int, err error)
+	}{
+		{
+			name:      "the cpu range is summarized",
+			service:   "dummyService",
+			attribute: types.CPU,
+			checker: func(min, max float64, count int, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, 2.0, min)
+				assert.Equal(t, 16.0, max)
+				assert.Equal(t, 4, count)
+			},
+		},
+		{
+			name:      "the memory range is summarized",
+			service:   "dummyService",
+			attribute: types.Memory,
+			checker: func(min, max float64, count int, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, 8.0, min)
+				assert.Equal(t, 64.0, max)
+				assert.Equal(t, 4, count)
+			},
+		},
+		{
+			name:      "error - unsupported attribute",
+			service:   "dummyService",
+			attribute: "gpu",
+			checker: func(min, max float64, count int, err error) {
+				assert.EqualError(t, err, "unsupported attribute")
+			},
+		},
+		{
+			name:      "error - nothing is cached",
+			service:   "otherService",
+			attribute: types.CPU,
+			checker: func(min, max float64, count int, err error) {
+				assert.True(t, errors.Is(err, ErrNotCached), "the error should be ErrNotCached")
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			info, _ := NewCloudInfo([]string{"dummyProvider"}, store, cloudinfoLogger)
+			test.checker(info.GetAttrRange("dummyProvider", test.service, test.attribute))
+		})
+	}
+}
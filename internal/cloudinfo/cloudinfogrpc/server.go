@@ -0,0 +1,248 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cloudinfogrpc serves the cached cloud information over gRPC.
+package cloudinfogrpc
+
+import (
+	"context"
+
+	"emperror.dev/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/banzaicloud/cloudinfo/api/grpc/cloudinfov1"
+	"github.com/banzaicloud/cloudinfo/internal/cloudinfo"
+	"github.com/banzaicloud/cloudinfo/internal/cloudinfo/types"
+)
+
+// Server implements the CloudInfo gRPC service by delegating to the cloud info
+type Server struct {
+	cloudinfov1.UnimplementedCloudInfoServer
+
+	cloudInfo types.CloudInfo
+	log       cloudinfo.Logger
+}
+
+// NewServer creates a new gRPC server serving the information of the cloud info
+func NewServer(cloudInfo types.CloudInfo, log cloudinfo.Logger) *Server {
+	return &Server{
+		cloudInfo: cloudInfo,
+		log:       log,
+	}
+}
+
+// Register registers the server to the gRPC service registrar (eg.: a grpc.Server)
+func (s *Server) Register(registrar grpc.ServiceRegistrar) {
+	cloudinfov1.RegisterCloudInfoServer(registrar, s)
+}
+
+// GetProviders returns the supported providers along with their services
+func (s *Server) GetProviders(_ context.Context, _ *cloudinfov1.GetProvidersRequest) (*cloudinfov1.GetProvidersResponse, error) {
+	providers, err := s.cloudInfo.GetProviders()
+	if err != nil {
+		return nil, s.statusError(err)
+	}
+
+	response := &cloudinfov1.GetProvidersResponse{
+		Providers: make([]*cloudinfov1.Provider, 0, len(providers)),
+	}
+	for _, provider := range providers {
+		response.Providers = append(response.Providers, &cloudinfov1.Provider{
+			Provider: provider.Provider,
+			Services: newServices(provider.Services),
+		})
+	}
+
+	return response, nil
+}
+
+// GetServices returns the supported services of the provider
+func (s *Server) GetServices(_ context.Context, req *cloudinfov1.GetServicesRequest) (*cloudinfov1.GetServicesResponse, error) {
+	services, err := s.cloudInfo.GetServices(req.GetProvider())
+	if err != nil {
+		return nil, s.statusError(err)
+	}
+
+	return &cloudinfov1.GetServicesResponse{Services: newServices(services)}, nil
+}
+
+// GetRegions returns the regions of the service keyed by region id
+func (s *Server) GetRegions(_ context.Context, req *cloudinfov1.GetRegionsRequest) (*cloudinfov1.GetRegionsResponse, error) {
+	regions, err := s.cloudInfo.GetRegions(req.GetProvider(), req.GetService())
+	if err != nil {
+		return nil, s.statusError(err)
+	}
+
+	return &cloudinfov1.GetRegionsResponse{Regions: regions}, nil
+}
+
+// GetZones returns the availability zones of the region
+func (s *Server) GetZones(_ context.Context, req *cloudinfov1.GetZonesRequest) (*cloudinfov1.GetZonesResponse, error) {
+	zones, err := s.cloudInfo.GetZones(req.GetProvider(), req.GetService(), req.GetRegion())
+	if err != nil {
+		return nil, s.statusError(err)
+	}
+
+	return &cloudinfov1.GetZonesResponse{Zones: zones}, nil
+}
+
+// GetProductDetails returns the product details of the region in a single response
+func (s *Server) GetProductDetails(_ context.Context, req *cloudinfov1.GetProductDetailsRequest) (*cloudinfov1.GetProductDetailsResponse, error) {
+	details, err := s.cloudInfo.GetProductDetails(req.GetProvider(), req.GetService(), req.GetRegion())
+	if err != nil {
+		return nil, s.statusError(err)
+	}
+
+	response := &cloudinfov1.GetProductDetailsResponse{
+		Products: make([]*cloudinfov1.ProductDetails, 0, len(details)),
+	}
+	for _, pd := range details {
+		response.Products = append(response.Products, newProductDetails(pd))
+	}
+
+	return response, nil
+}
+
+// StreamProductDetails sends the product details of the region one by one
+// the stream is ended early if the client cancels it
+func (s *Server) StreamProductDetails(req *cloudinfov1.GetProductDetailsRequest, stream cloudinfov1.CloudInfo_StreamProductDetailsServer) error {
+	details, err := s.cloudInfo.GetProductDetails(req.GetProvider(), req.GetService(), req.GetRegion())
+	if err != nil {
+		return s.statusError(err)
+	}
+
+	for _, pd := range details {
+		if err := stream.Context().Err(); err != nil {
+			return status.FromContextError(err).Err()
+		}
+
+		if err := stream.Send(newProductDetails(pd)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// statusError translates the error of the cloud info into a gRPC status error
+// errors that are not caused by the request are logged and reported as internal errors
+func (s *Server) statusError(err error) error {
+	var code codes.Code
+
+	switch {
+	case errors.Is(err, cloudinfo.ErrProviderNotSupported),
+		errors.Is(err, cloudinfo.ErrServiceNotSupported),
+		errors.Is(err, cloudinfo.ErrRegionNotSupported),
+		errors.Is(err, cloudinfo.ErrProductNotFound):
+		code = codes.NotFound
+	case errors.Is(err, cloudinfo.ErrNotCached),
+		errors.Is(err, cloudinfo.ErrDataTooStale):
+		code = codes.Unavailable
+	default:
+		s.log.Error("failed to serve gRPC request", map[string]interface{}{"error": err.Error()})
+
+		return status.Error(codes.Internal, "internal error")
+	}
+
+	return status.Error(code, err.Error())
+}
+
+// newServices maps the services to their gRPC representation
+func newServices(services []types.Service) []*cloudinfov1.Service {
+	mapped := make([]*cloudinfov1.Service, 0, len(services))
+	for _, service := range services {
+		mapped = append(mapped, &cloudinfov1.Service{
+			Service:  service.Service,
+			IsStatic: service.IsStatic,
+		})
+	}
+
+	return mapped
+}
+
+// newVmInfo maps the virtual machine details to their gRPC representation
+func newVmInfo(vm types.VMInfo) *cloudinfov1.VmInfo {
+	return &cloudinfov1.VmInfo{
+		Category:            vm.Category,
+		Type:                vm.Type,
+		OnDemandPrice:       vm.OnDemandPrice,
+		SpotPrice:           newZonePrices(vm.SpotPrice),
+		CpusPerVm:           vm.Cpus,
+		MemPerVm:            vm.Mem,
+		GpusPerVm:           vm.Gpus,
+		NtwPerf:             vm.NtwPerf,
+		NtwPerfCategory:     vm.NtwPerfCat,
+		NtwGbps:             vm.NtwGbps,
+		Zones:               vm.Zones,
+		Attributes:          vm.Attributes,
+		CurrentGen:          vm.CurrentGen,
+		Architecture:        vm.Architecture,
+		Burstable:           vm.Burstable,
+		BaselinePerf:        vm.BaselinePerf,
+		InstanceStorageGb:   vm.InstanceStorageGB,
+		InstanceStorageType: vm.InstanceStorageType,
+		NormalizedUnits:     vm.NormalizedUnits,
+	}
+}
+
+// newProductDetails maps the product details to their gRPC representation
+func newProductDetails(pd types.ProductDetails) *cloudinfov1.ProductDetails {
+	mapped := &cloudinfov1.ProductDetails{
+		Vm:                newVmInfo(pd.VMInfo),
+		Burst:             pd.Burst,
+		ReservedPrice:     pd.ReservedPrice,
+		PricePerCpu:       pd.PricePerCpu,
+		PricePerGb:        pd.PricePerGB,
+		PriceAvailable:    pd.PriceAvailable,
+		SpotPriceSmoothed: newZonePrices(pd.SpotPriceSmoothed),
+	}
+
+	if len(pd.ZonePrices) > 0 {
+		mapped.ZonePrices = make(map[string]*cloudinfov1.Price, len(pd.ZonePrices))
+		for zone, price := range pd.ZonePrices {
+			mapped.ZonePrices[zone] = newPrice(price)
+		}
+	}
+
+	return mapped
+}
+
+// newPrice maps the price to its gRPC representation
+func newPrice(price types.Price) *cloudinfov1.Price {
+	return &cloudinfov1.Price{
+		OnDemandPrice:     price.OnDemandPrice,
+		SpotPrice:         price.SpotPrice,
+		ReservedPrice:     price.ReservedPrice,
+		SmoothedSpotPrice: price.SmoothedSpotPrice,
+	}
+}
+
+// newZonePrices maps the zone prices to their gRPC representation, nil if there are no zone prices
+func newZonePrices(zonePrices []types.ZonePrice) []*cloudinfov1.ZonePrice {
+	if len(zonePrices) == 0 {
+		return nil
+	}
+
+	mapped := make([]*cloudinfov1.ZonePrice, 0, len(zonePrices))
+	for _, zonePrice := range zonePrices {
+		mapped = append(mapped, &cloudinfov1.ZonePrice{
+			Zone:  zonePrice.Zone,
+			Price: zonePrice.Price,
+		})
+	}
+
+	return mapped
+}
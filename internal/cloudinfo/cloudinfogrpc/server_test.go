@@ -0,0 +1,295 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudinfogrpc
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"logur.dev/logur"
+
+	"github.com/banzaicloud/cloudinfo/api/grpc/cloudinfov1"
+	"github.com/banzaicloud/cloudinfo/internal/app/cloudinfo/cistore"
+	"github.com/banzaicloud/cloudinfo/internal/cloudinfo"
+	"github.com/banzaicloud/cloudinfo/internal/cloudinfo/cloudinfoadapter"
+	"github.com/banzaicloud/cloudinfo/internal/cloudinfo/types"
+)
+
+// newTestClient serves the cloud info of a seeded store over an in-process connection
+func newTestClient(t *testing.T) cloudinfov1.CloudInfoClient {
+	logger := cloudinfoadapter.NewLogger(&logur.TestLogger{})
+
+	store := cistore.NewCacheProductStore(time.Hour, 0, logger)
+	store.StoreServices("amazon", []types.Service{{Service: "compute"}, {Service: "eks"}})
+	store.StoreRegions("amazon", "compute", map[string]string{"us-east-1": "US East (N. Virginia)"})
+	store.StoreZones("amazon", "compute", "us-east-1", []string{"us-east-1a", "us-east-1b"})
+	store.StoreVm("amazon", "compute", "us-east-1", []types.VMInfo{
+		{
+			Category:      types.CategoryGeneral,
+			Type:          "m5.large",
+			OnDemandPrice: 0.096,
+			Cpus:          2,
+			Mem:           8,
+			NtwPerf:       "Up to 10 Gigabit",
+			NtwPerfCat:    types.NtwHight,
+			Zones:         []string{"us-east-1a", "us-east-1b"},
+			Attributes:    map[string]string{types.CPU: "2", types.Memory: "8"},
+			CurrentGen:    true,
+			Architecture:  types.ArchX86_64,
+		},
+		{
+			Category:      types.CategoryCompute,
+			Type:          "c5.large",
+			OnDemandPrice: 0.085,
+			Cpus:          2,
+			Mem:           4,
+		},
+	})
+	store.StorePrice("amazon", "us-east-1", "m5.large", types.Price{
+		OnDemandPrice: 0.096,
+		SpotPrice:     types.SpotPriceInfo{"us-east-1a": 0.035},
+		ReservedPrice: map[string]float64{"1yr-no-upfront": 0.06},
+	})
+
+	ci, err := cloudinfo.NewCloudInfo([]string{"amazon", "google"}, store, logger)
+	require.NoError(t, err)
+
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	NewServer(ci, logger).Register(server)
+
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.DialContext(context.Background(), "bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithInsecure(),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	return cloudinfov1.NewCloudInfoClient(conn)
+}
+
+func TestServer_GetProviders(t *testing.T) {
+	client := newTestClient(t)
+
+	response, err := client.GetProviders(context.Background(), &cloudinfov1.GetProvidersRequest{})
+	require.NoError(t, err)
+
+	// the providers without cached services are skipped
+	require.Len(t, response.GetProviders(), 1)
+	assert.Equal(t, "amazon", response.GetProviders()[0].GetProvider())
+	assert.Len(t, response.GetProviders()[0].GetServices(), 2)
+}
+
+func TestServer_GetServices(t *testing.T) {
+	client := newTestClient(t)
+
+	tests := []struct {
+		name     string
+		provider string
+		check    func(response *cloudinfov1.GetServicesResponse, err error)
+	}{
+		{
+			name:     "the cached services are returned",
+			provider: "amazon",
+			check: func(response *cloudinfov1.GetServicesResponse, err error) {
+				require.NoError(t, err)
+				require.Len(t, response.GetServices(), 2)
+				assert.Equal(t, "compute", response.GetServices()[0].GetService())
+				assert.Equal(t, "eks", response.GetServices()[1].GetService())
+			},
+		},
+		{
+			name:     "not found if the provider is not supported",
+			provider: "alibaba",
+			check: func(response *cloudinfov1.GetServicesResponse, err error) {
+				assert.Equal(t, codes.NotFound, status.Code(err))
+			},
+		},
+		{
+			name:     "unavailable if the services are not yet cached",
+			provider: "google",
+			check: func(response *cloudinfov1.GetServicesResponse, err error) {
+				assert.Equal(t, codes.Unavailable, status.Code(err))
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.check(client.GetServices(context.Background(), &cloudinfov1.GetServicesRequest{Provider: test.provider}))
+		})
+	}
+}
+
+func TestServer_GetRegions(t *testing.T) {
+	client := newTestClient(t)
+
+	tests := []struct {
+		name    string
+		service string
+		check   func(response *cloudinfov1.GetRegionsResponse, err error)
+	}{
+		{
+			name:    "the cached regions are returned",
+			service: "compute",
+			check: func(response *cloudinfov1.GetRegionsResponse, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, map[string]string{"us-east-1": "US East (N. Virginia)"}, response.GetRegions())
+			},
+		},
+		{
+			name:    "not found if the service is not supported",
+			service: "aks",
+			check: func(response *cloudinfov1.GetRegionsResponse, err error) {
+				assert.Equal(t, codes.NotFound, status.Code(err))
+			},
+		},
+		{
+			name:    "unavailable if the regions are not yet cached",
+			service: "eks",
+			check: func(response *cloudinfov1.GetRegionsResponse, err error) {
+				assert.Equal(t, codes.Unavailable, status.Code(err))
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.check(client.GetRegions(context.Background(), &cloudinfov1.GetRegionsRequest{Provider: "amazon", Service: test.service}))
+		})
+	}
+}
+
+func TestServer_GetZones(t *testing.T) {
+	client := newTestClient(t)
+
+	response, err := client.GetZones(context.Background(), &cloudinfov1.GetZonesRequest{
+		Provider: "amazon",
+		Service:  "compute",
+		Region:   "us-east-1",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"us-east-1a", "us-east-1b"}, response.GetZones())
+
+	_, err = client.GetZones(context.Background(), &cloudinfov1.GetZonesRequest{
+		Provider: "amazon",
+		Service:  "compute",
+		Region:   "eu-west-1",
+	})
+	assert.Equal(t, codes.NotFound, status.Code(err), "the region is not supported")
+}
+
+func TestServer_GetProductDetails(t *testing.T) {
+	client := newTestClient(t)
+
+	response, err := client.GetProductDetails(context.Background(), &cloudinfov1.GetProductDetailsRequest{
+		Provider: "amazon",
+		Service:  "compute",
+		Region:   "us-east-1",
+	})
+	require.NoError(t, err)
+	require.Len(t, response.GetProducts(), 2)
+
+	products := make(map[string]*cloudinfov1.ProductDetails, len(response.GetProducts()))
+	for _, product := range response.GetProducts() {
+		products[product.GetVm().GetType()] = product
+	}
+
+	m5 := products["m5.large"]
+	require.NotNil(t, m5)
+	assert.Equal(t, types.CategoryGeneral, m5.GetVm().GetCategory())
+	assert.Equal(t, 0.096, m5.GetVm().GetOnDemandPrice())
+	assert.Equal(t, 2.0, m5.GetVm().GetCpusPerVm())
+	assert.Equal(t, 8.0, m5.GetVm().GetMemPerVm())
+	assert.Equal(t, types.NtwHight, m5.GetVm().GetNtwPerfCategory())
+	assert.Equal(t, []string{"us-east-1a", "us-east-1b"}, m5.GetVm().GetZones())
+	assert.Equal(t, map[string]string{types.CPU: "2", types.Memory: "8"}, m5.GetVm().GetAttributes())
+	assert.True(t, m5.GetVm().GetCurrentGen())
+	assert.Equal(t, types.ArchX86_64, m5.GetVm().GetArchitecture())
+	require.Len(t, m5.GetVm().GetSpotPrice(), 1)
+	assert.Equal(t, "us-east-1a", m5.GetVm().GetSpotPrice()[0].GetZone())
+	assert.Equal(t, 0.035, m5.GetVm().GetSpotPrice()[0].GetPrice())
+	assert.True(t, m5.GetPriceAvailable())
+	assert.Equal(t, map[string]float64{"1yr-no-upfront": 0.06}, m5.GetReservedPrice())
+	assert.Equal(t, 0.048, m5.GetPricePerCpu())
+	assert.Equal(t, 0.012, m5.GetPricePerGb())
+
+	require.Contains(t, m5.GetZonePrices(), "us-east-1a")
+	assert.Equal(t, 0.096, m5.GetZonePrices()["us-east-1a"].GetOnDemandPrice())
+	assert.Equal(t, map[string]float64{"us-east-1a": 0.035}, m5.GetZonePrices()["us-east-1a"].GetSpotPrice())
+	require.Contains(t, m5.GetZonePrices(), "us-east-1b")
+	assert.Empty(t, m5.GetZonePrices()["us-east-1b"].GetSpotPrice())
+
+	c5 := products["c5.large"]
+	require.NotNil(t, c5)
+	assert.False(t, c5.GetPriceAvailable(), "the price of the product is not cached")
+	assert.Empty(t, c5.GetVm().GetSpotPrice())
+
+	_, err = client.GetProductDetails(context.Background(), &cloudinfov1.GetProductDetailsRequest{
+		Provider: "amazon",
+		Service:  "eks",
+		Region:   "us-east-1",
+	})
+	assert.Equal(t, codes.Unavailable, status.Code(err), "the products are not yet cached")
+}
+
+func TestServer_StreamProductDetails(t *testing.T) {
+	client := newTestClient(t)
+
+	stream, err := client.StreamProductDetails(context.Background(), &cloudinfov1.GetProductDetailsRequest{
+		Provider: "amazon",
+		Service:  "compute",
+		Region:   "us-east-1",
+	})
+	require.NoError(t, err)
+
+	instanceTypes := make([]string, 0)
+	for {
+		product, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+
+		instanceTypes = append(instanceTypes, product.GetVm().GetType())
+	}
+	assert.ElementsMatch(t, []string{"m5.large", "c5.large"}, instanceTypes)
+
+	stream, err = client.StreamProductDetails(context.Background(), &cloudinfov1.GetProductDetailsRequest{
+		Provider: "alibaba",
+		Service:  "compute",
+		Region:   "us-east-1",
+	})
+	require.NoError(t, err)
+
+	_, err = stream.Recv()
+	assert.Equal(t, codes.NotFound, status.Code(err), "the provider is not supported")
+}
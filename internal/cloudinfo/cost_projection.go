@@ -0,0 +1,91 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudinfo
+
+import (
+	"emperror.dev/errors"
+
+	"github.com/banzaicloud/cloudinfo/internal/cloudinfo/types"
+)
+
+// CostProjection holds the parameters the monthly costs of the products are projected with
+type CostProjection struct {
+	// Count the number of instances to run, zero is considered a single instance
+	Count int
+	// Utilization the fraction of the month the instances run for, zero is considered a full month
+	Utilization float64
+}
+
+// ProductCost is a product along with its projected monthly costs
+type ProductCost struct {
+	types.ProductDetails
+
+	// MonthlyOnDemandCost the projected monthly cost of the instances run on demand
+	MonthlyOnDemandCost float64 `json:"monthlyOnDemandCost"`
+	// MonthlySpotCost the projected monthly cost of the instances run at the cheapest spot price, zero if the product has no spot price
+	MonthlySpotCost float64 `json:"monthlySpotCost,omitempty"`
+}
+
+// validate checks the projection parameters are in range
+func (cp CostProjection) validate() error {
+	if cp.Count < 0 || cp.Utilization < 0 || cp.Utilization > 1 {
+		return errors.NewWithDetails("invalid cost projection", "count", cp.Count, "utilization", cp.Utilization)
+	}
+
+	return nil
+}
+
+// factor returns the number of instance hours per hour the hourly prices are to be multiplied by
+func (cp CostProjection) factor() float64 {
+	count, utilization := float64(cp.Count), cp.Utilization
+	if count == 0 {
+		count = 1
+	}
+	if utilization == 0 {
+		utilization = 1
+	}
+
+	return count * utilization
+}
+
+// projectCost projects the monthly costs of the product
+func projectCost(pd types.ProductDetails, projection CostProjection) ProductCost {
+	factor := projection.factor()
+
+	return ProductCost{
+		ProductDetails:      pd,
+		MonthlyOnDemandCost: pd.MonthlyOnDemandCost() * factor,
+		MonthlySpotCost:     pd.CheapestSpotPrice() * types.HoursPerMonth * factor,
+	}
+}
+
+// GetProductCosts retrieves the products of the region along with their monthly costs projected for the instance count and utilization
+func (cpi *cloudInfo) GetProductCosts(provider, service, region string, projection CostProjection) ([]ProductCost, error) {
+	if err := projection.validate(); err != nil {
+		return nil, err
+	}
+
+	details, err := cpi.GetProductDetails(provider, service, region)
+	if err != nil {
+		return nil, err
+	}
+
+	costs := make([]ProductCost, 0, len(details))
+	for _, pd := range details {
+		costs = append(costs, projectCost(pd, projection))
+	}
+
+	return costs, nil
+}
@@ -0,0 +1,101 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudinfo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/banzaicloud/cloudinfo/internal/cloudinfo/types"
+)
+
+func TestProductDetails_MonthlyOnDemandCost(t *testing.T) {
+	pd := types.NewProductDetails(types.VMInfo{Type: "dummyType1", OnDemandPrice: 0.1})
+	assert.InDelta(t, 73, pd.MonthlyOnDemandCost(), 1e-9)
+
+	free := types.NewProductDetails(types.VMInfo{Type: "dummyType2"})
+	assert.Equal(t, 0.0, free.MonthlyOnDemandCost(), "zero should be returned without on demand price")
+}
+
+func TestCachingCloudInfo_GetProductCosts(t *testing.T) {
+	store := newTestCloudInfoStore()
+	store.StoreVm("dummyProvider", "dummyService", "dummyRegion", []types.VMInfo{
+		{Type: "type1", OnDemandPrice: 0.1, Cpus: 2, Mem: 8},
+		{Type: "type2", OnDemandPrice: 0.2, Cpus: 4, Mem: 16},
+	})
+	store.StorePrice("dummyProvider", "dummyRegion", "type1", types.Price{OnDemandPrice: 0.1,
+		SpotPrice: types.SpotPriceInfo{"zone1": 0.04, "zone2": 0.03}})
+	store.StorePrice("dummyProvider", "dummyRegion", "type2", types.Price{OnDemandPrice: 0.2})
+
+	tests := []struct {
+		name       string
+		projection CostProjection
+		checker    func(costs []ProductCost, err error)
+	}{
+		{
+			name:       "a single instance for the full month by default",
+			projection: CostProjection{},
+			checker: func(costs []ProductCost, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, 2, len(costs))
+				assert.InDelta(t, 73, costs[0].MonthlyOnDemandCost, 1e-9)
+				assert.InDelta(t, 21.9, costs[0].MonthlySpotCost, 1e-9)
+				assert.InDelta(t, 146, costs[1].MonthlyOnDemandCost, 1e-9)
+				assert.Equal(t, 0.0, costs[1].MonthlySpotCost, "zero should be returned without spot price")
+			},
+		},
+		{
+			name:       "the costs are multiplied by the instance count",
+			projection: CostProjection{Count: 10},
+			checker: func(costs []ProductCost, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.InDelta(t, 730, costs[0].MonthlyOnDemandCost, 1e-9)
+				assert.InDelta(t, 219, costs[0].MonthlySpotCost, 1e-9)
+			},
+		},
+		{
+			name:       "the costs are scaled by the utilization",
+			projection: CostProjection{Count: 4, Utilization: 0.25},
+			checker: func(costs []ProductCost, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.InDelta(t, 73, costs[0].MonthlyOnDemandCost, 1e-9)
+				assert.InDelta(t, 146, costs[1].MonthlyOnDemandCost, 1e-9)
+			},
+		},
+		{
+			name:       "error - utilization out of range",
+			projection: CostProjection{Count: 1, Utilization: 1.5},
+			checker: func(costs []ProductCost, err error) {
+				assert.Nil(t, costs, "the costs should be nil")
+				assert.EqualError(t, err, "invalid cost projection")
+			},
+		},
+		{
+			name:       "error - negative count",
+			projection: CostProjection{Count: -1},
+			checker: func(costs []ProductCost, err error) {
+				assert.Nil(t, costs, "the costs should be nil")
+				assert.EqualError(t, err, "invalid cost projection")
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			info, _ := NewCloudInfo([]string{"dummyProvider"}, store, cloudinfoLogger)
+			test.checker(info.GetProductCosts("dummyProvider", "dummyService", "dummyRegion", test.projection))
+		})
+	}
+}
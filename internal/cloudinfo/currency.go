@@ -0,0 +1,92 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudinfo
+
+import (
+	"emperror.dev/errors"
+
+	"github.com/banzaicloud/cloudinfo/internal/cloudinfo/types"
+)
+
+// BaseCurrency the currency prices are scraped and stored in
+const BaseCurrency = "USD"
+
+// CurrencyConverter provides exchange rates for converting prices
+type CurrencyConverter interface {
+	// Rate returns the amount of the "to" currency one unit of the "from" currency is worth
+	Rate(from, to string) (float64, error)
+}
+
+// identityCurrencyConverter only supports "converting" prices into their own currency
+type identityCurrencyConverter struct{}
+
+func (identityCurrencyConverter) Rate(from, to string) (float64, error) {
+	if from != to {
+		return 0, errors.NewWithDetails("currency conversion not supported", "from", from, "to", to)
+	}
+
+	return 1, nil
+}
+
+// NewIdentityCurrencyConverter creates a converter that leaves prices in the base currency untouched
+func NewIdentityCurrencyConverter() CurrencyConverter {
+	return identityCurrencyConverter{}
+}
+
+// convertProductDetails multiplies the on demand, spot, zone and reserved prices of the products by the rate
+// the price maps are replaced rather than updated as they may be shared with the store
+func convertProductDetails(details []types.ProductDetails, rate float64) {
+	for i := range details {
+		details[i].OnDemandPrice *= rate
+		details[i].PricePerCpu *= rate
+		details[i].PricePerGB *= rate
+
+		spotPrices := make([]types.ZonePrice, 0, len(details[i].SpotPrice))
+		for _, zonePrice := range details[i].SpotPrice {
+			spotPrices = append(spotPrices, types.ZonePrice{Zone: zonePrice.Zone, Price: zonePrice.Price * rate})
+		}
+		details[i].SpotPrice = spotPrices
+
+		if len(details[i].SpotPriceSmoothed) > 0 {
+			smoothedPrices := make([]types.ZonePrice, 0, len(details[i].SpotPriceSmoothed))
+			for _, zonePrice := range details[i].SpotPriceSmoothed {
+				smoothedPrices = append(smoothedPrices, types.ZonePrice{Zone: zonePrice.Zone, Price: zonePrice.Price * rate})
+			}
+			details[i].SpotPriceSmoothed = smoothedPrices
+		}
+
+		if len(details[i].ZonePrices) > 0 {
+			zonePrices := make(map[string]types.Price, len(details[i].ZonePrices))
+			for zone, price := range details[i].ZonePrices {
+				spotPrice := make(types.SpotPriceInfo, len(price.SpotPrice))
+				for spotZone, value := range price.SpotPrice {
+					spotPrice[spotZone] = value * rate
+				}
+				price.OnDemandPrice *= rate
+				price.SpotPrice = spotPrice
+				zonePrices[zone] = price
+			}
+			details[i].ZonePrices = zonePrices
+		}
+
+		if len(details[i].ReservedPrice) > 0 {
+			reservedPrices := make(map[string]float64, len(details[i].ReservedPrice))
+			for term, price := range details[i].ReservedPrice {
+				reservedPrices[term] = price * rate
+			}
+			details[i].ReservedPrice = reservedPrices
+		}
+	}
+}
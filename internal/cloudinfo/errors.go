@@ -0,0 +1,71 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudinfo
+
+import (
+	"emperror.dev/errors"
+)
+
+const (
+	// ErrProviderNotSupported signals that the requested provider is not configured
+	ErrProviderNotSupported = errors.Sentinel("provider not supported")
+
+	// ErrServiceNotSupported signals that the requested service is not available for the provider
+	ErrServiceNotSupported = errors.Sentinel("service not supported")
+
+	// ErrRegionNotSupported signals that the requested region is not available for the service
+	ErrRegionNotSupported = errors.Sentinel("region not supported")
+
+	// ErrNotCached signals that the requested information is not (yet) available in the store
+	ErrNotCached = errors.Sentinel("not yet cached")
+
+	// ErrSpotPriceHistoryNotSupported signals that the provider can't retrieve spot price history
+	ErrSpotPriceHistoryNotSupported = errors.Sentinel("spot price history not supported")
+
+	// ErrProductNotFound signals that the requested instance type is not among the cached products of the region
+	ErrProductNotFound = errors.Sentinel("product not found")
+
+	// ErrRegionLocationUnknown signals that none of the regions of the service has a known location
+	ErrRegionLocationUnknown = errors.Sentinel("region location unknown")
+
+	// ErrDataTooStale signals that the cached information is older than the configured max age
+	ErrDataTooStale = errors.Sentinel("data too stale")
+
+	// ErrNoInfoer signals that the cloud infoer of a provider to be scraped is missing
+	ErrNoInfoer = errors.Sentinel("no cloud infoer")
+
+	// ErrZoneSpotPriceNotFound signals that the cached price of the instance type has no spot price for the zone
+	ErrZoneSpotPriceNotFound = errors.Sentinel("zone spot price not found")
+
+	// ErrSnapshotNotSupported signals that the store can't be exported or imported
+	ErrSnapshotNotSupported = errors.Sentinel("store snapshots not supported")
+)
+
+// notCachedError is returned when the requested information is missing from the store
+// it matches ErrNotCached
+type notCachedError string
+
+func (e notCachedError) Error() string {
+	return string(e) + " not yet cached"
+}
+
+func (e notCachedError) Is(target error) bool {
+	return target == ErrNotCached
+}
+
+// newNotCachedError creates an ErrNotCached error for the missing information decorated with the passed in details
+func newNotCachedError(what string, details ...interface{}) error {
+	return errors.WithDetails(notCachedError(what), details...)
+}
@@ -27,6 +27,9 @@ type Executor interface {
 	Execute(ctx context.Context, sf TaskFn) error
 }
 
+// ExecutorFactory creates an Executor running the task with the given time period
+type ExecutorFactory func(period time.Duration, log Logger) Executor
+
 // PeriodicExecutor Executor that periodically executes the passed in task function
 type PeriodicExecutor struct {
 	// interval specifies the time interval within the task function will be executed once
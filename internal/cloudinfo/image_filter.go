@@ -0,0 +1,41 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudinfo
+
+import (
+	"strings"
+
+	"github.com/banzaicloud/cloudinfo/internal/cloudinfo/types"
+)
+
+// ImageFilter holds the operating system the cached images are filtered by
+// Empty fields match any image
+type ImageFilter struct {
+	Os        string
+	OsVersion string
+}
+
+// applyImageFilter checks whether the image satisfies the filter, the values are compared case insensitively
+func applyImageFilter(image types.Image, filter ImageFilter) bool {
+	if filter.Os != "" && !strings.EqualFold(image.Os, filter.Os) {
+		return false
+	}
+
+	if filter.OsVersion != "" && !strings.EqualFold(image.OsVersion, filter.OsVersion) {
+		return false
+	}
+
+	return true
+}
@@ -15,6 +15,11 @@
 package cloudinfo
 
 import (
+	"context"
+	"time"
+
+	"emperror.dev/errors"
+
 	"github.com/banzaicloud/cloudinfo/internal/cloudinfo/types"
 )
 
@@ -31,6 +36,9 @@ type CloudInfoer interface {
 	// GetProducts gets product information based on the given arguments from an external system
 	GetProducts(vms []types.VMInfo, service, regionId string) ([]types.VMInfo, error)
 
+	// HasZones signals if a product info provider has availability zones
+	HasZones() bool
+
 	// GetZones returns the availability zones in a region
 	GetZones(region string) ([]string, error)
 
@@ -40,6 +48,9 @@ type CloudInfoer interface {
 	// HasShortLivedPriceInfo signals if a product info provider has frequently changing price info
 	HasShortLivedPriceInfo() bool
 
+	// PriceRegionService returns the service whose regions the prices are scraped in (usually "compute")
+	PriceRegionService() string
+
 	// GetCurrentPrices retrieves all the spot prices in a region
 	GetCurrentPrices(region string) (map[string]types.Price, error)
 
@@ -49,9 +60,81 @@ type CloudInfoer interface {
 	// GetServiceImages retrieves the images supported by the given service in the given region
 	GetServiceImages(service, region string) ([]types.Image, error)
 
+	// HasVersions signals if a product info provider has kubernetes version support
+	HasVersions() bool
+
 	// GetVersions retrieves the  versions supported by the given service in the given region
 	GetVersions(service, region string) ([]types.LocationVersion, error)
 
 	// GetServiceProducts retrieves the products supported by the given service in the given region
 	GetServiceProducts(region, service string) ([]types.ProductDetails, error)
+
+	// HasReserved signals if a product info provider has reserved prices (the infoer is expected to implement ReservedPriceInfoer)
+	HasReserved() bool
+}
+
+// SpotPriceHistoryInfoer is an optional interface implemented by the infoers able to retrieve spot price history
+type SpotPriceHistoryInfoer interface {
+	// GetSpotPriceHistory retrieves the spot prices of the instance type in the region for the given time window
+	GetSpotPriceHistory(region, instanceType string, window time.Duration) ([]types.TimestampedPrice, error)
+}
+
+// Credentials holds the provider specific credentials keyed by name (eg.: accessKeyId, secretAccessKey)
+type Credentials map[string]string
+
+// ReconfigurableInfoer is an optional interface implemented by the infoers able to change their credentials at runtime
+type ReconfigurableInfoer interface {
+	// Reconfigure replaces the credentials used to access the provider
+	Reconfigure(creds Credentials) error
+}
+
+// DefaultPriceRegionService is the service whose regions the prices are scraped in for most of the providers
+const DefaultPriceRegionService = "compute"
+
+// ReservedPriceInfoer is an optional interface implemented by the infoers able to retrieve reserved instance prices
+type ReservedPriceInfoer interface {
+	// GetReservedPrices retrieves the hourly reserved prices of the instance types in the region keyed by instance type and term
+	GetReservedPrices(region string) (map[string]map[string]float64, error)
+}
+
+// SpotPriceHistoryProviders returns the providers whose infoer is able to retrieve spot price history
+func SpotPriceHistoryProviders(infoers map[string]CloudInfoer) []string {
+	providers := make([]string, 0, len(infoers))
+	for provider, infoer := range infoers {
+		if _, ok := infoer.(SpotPriceHistoryInfoer); ok {
+			providers = append(providers, provider)
+		}
+	}
+
+	return providers
+}
+
+// ValidateProvider checks whether the infoer of the provider is able to access the provider (eg.: its credentials are valid)
+// the provider is queried directly, nothing gets stored; it's meant to be called before the infoer is handed to the scraper
+func ValidateProvider(ctx context.Context, provider string, infoer CloudInfoer) error {
+	type result struct {
+		regions map[string]string
+		err     error
+	}
+
+	// buffered, so that the call can complete after the context is done
+	done := make(chan result, 1)
+	go func() {
+		regions, err := infoer.GetRegions(infoer.PriceRegionService())
+		done <- result{regions: regions, err: err}
+	}()
+
+	select {
+	case res := <-done:
+		if res.err != nil {
+			return errors.WrapIfWithDetails(res.err, "provider misconfigured: failed to retrieve regions", "provider", provider)
+		}
+		if len(res.regions) == 0 {
+			return errors.NewWithDetails("provider misconfigured: no regions available", "provider", provider)
+		}
+	case <-ctx.Done():
+		return errors.WrapIfWithDetails(ctx.Err(), "provider validation aborted", "provider", provider)
+	}
+
+	return nil
 }
@@ -0,0 +1,50 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudinfo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateProvider(t *testing.T) {
+	tests := []struct {
+		name    string
+		infoer  CloudInfoer
+		checker func(err error)
+	}{
+		{
+			name:   "the provider is accessible",
+			infoer: &DummyCloudInfoer{},
+			checker: func(err error) {
+				assert.Nil(t, err, "the error should be nil")
+			},
+		},
+		{
+			name:   "the regions can't be retrieved",
+			infoer: &DummyCloudInfoer{TcId: failingRegions},
+			checker: func(err error) {
+				assert.EqualError(t, err, "provider misconfigured: failed to retrieve regions: failed to retrieve regions")
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.checker(ValidateProvider(context.Background(), "dummyProvider", test.infoer))
+		})
+	}
+}
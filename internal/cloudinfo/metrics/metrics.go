@@ -37,6 +37,16 @@ var (
 	},
 		[]string{"provider", "service", "region"},
 	)
+	// scrapeDurationHistogram collects the distribution of the scrape durations
+	// the buckets range from sub-second region scrapes to multi-minute provider scrapes
+	scrapeDurationHistogram = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "scrape",
+		Name:      "duration_seconds",
+		Help:      "Cloud provider scrape duration in seconds, partitioned by provider and service",
+		Buckets:   prometheus.ExponentialBuckets(0.25, 2, 12),
+	},
+		[]string{"provider", "service"},
+	)
 	// ScrapeFailuresTotalCounter collects metrics for the prometheus
 	scrapeFailuresTotalCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
 		Namespace: "scrape",
@@ -69,6 +79,46 @@ var (
 	},
 		[]string{"provider", "region"},
 	)
+	// cacheSizeGauge collects the number of cached products per provider and service
+	cacheSizeGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "cloudinfo",
+		Name:      "cache_entries",
+		Help:      "Number of cached products, partitioned by provider and service",
+	},
+		[]string{"provider", "service"},
+	)
+	// priceAgeGauge collects the age of the cached prices
+	priceAgeGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "cloudinfo",
+		Name:      "price_age_seconds",
+		Help:      "Time passed since the last update of the cached price of the instance type in seconds",
+	},
+		[]string{"provider", "region", "instanceType"},
+	)
+	// priceCircuitBreakerGauge collects the state of the price scrape circuit breakers
+	priceCircuitBreakerGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "cloudinfo",
+		Name:      "price_circuit_breaker_state",
+		Help:      "State of the short lived price scrape circuit breaker (0: closed, 1: half open, 2: open), partitioned by provider",
+	},
+		[]string{"provider"},
+	)
+	// cacheHitsCounter collects the number of reads served from the cache
+	cacheHitsCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "cloudinfo",
+		Name:      "cache_hits_total",
+		Help:      "Total number of reads served from the cache, partitioned by provider and service",
+	},
+		[]string{"provider", "service"},
+	)
+	// cacheMissesCounter collects the number of reads failed because of the information not being cached
+	cacheMissesCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "cloudinfo",
+		Name:      "cache_misses_total",
+		Help:      "Total number of reads of not yet cached information, partitioned by provider and service",
+	},
+		[]string{"provider", "service"},
+	)
 	// OnDemandPriceGauge collects metrics for the prometheus
 	OnDemandPriceGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
 		Namespace: "cloudinfo",
@@ -98,6 +148,21 @@ type Reporter interface {
 
 	// ReportScrapeShortLivedFailure reports the failure of scraping short lived information
 	ReportScrapeShortLivedFailure(provider, region string)
+
+	// ReportCacheSize reports the number of cached products of the provider's service
+	ReportCacheSize(provider, service string, entries int)
+
+	// ReportPriceAge reports the time passed since the cached price of the instance type got updated
+	ReportPriceAge(provider, region, instanceType string, age time.Duration)
+
+	// ReportPriceCircuitBreakerState reports the state of the provider's price scrape circuit breaker (0: closed, 1: half open, 2: open)
+	ReportPriceCircuitBreakerState(provider string, state int)
+
+	// ReportCacheHit reports a read of the provider's service information served from the cache
+	ReportCacheHit(provider, service string)
+
+	// ReportCacheMiss reports a read of the provider's service information not (yet) cached
+	ReportCacheMiss(provider, service string)
 }
 
 // DefaultMetricsReporter default metrics source for the application
@@ -121,11 +186,21 @@ func (ms *DefaultMetricsReporter) addCollector(c prometheus.Collector) {
 }
 
 func (ms *DefaultMetricsReporter) ReportScrapeProviderCompleted(provider string, startTime time.Time) {
-	scrapeCompleteDurationGauge.WithLabelValues(provider).Set(time.Since(startTime).Seconds())
+	duration := time.Since(startTime)
+	scrapeCompleteDurationGauge.WithLabelValues(provider).Set(duration.Seconds())
+	// the provider scrape spans all the services
+	observeScrapeDuration(provider, "N/A", duration)
 }
 
 func (ms *DefaultMetricsReporter) ReportScrapeRegionCompleted(provider, service, region string, startTime time.Time) {
-	scrapeRegionDurationGauge.WithLabelValues(provider, service, region).Set(time.Since(startTime).Seconds())
+	duration := time.Since(startTime)
+	scrapeRegionDurationGauge.WithLabelValues(provider, service, region).Set(duration.Seconds())
+	observeScrapeDuration(provider, service, duration)
+}
+
+// observeScrapeDuration records the scrape duration in the histogram
+func observeScrapeDuration(provider, service string, duration time.Duration) {
+	scrapeDurationHistogram.WithLabelValues(provider, service).Observe(duration.Seconds())
 }
 
 func (ms *DefaultMetricsReporter) ReportScrapeFailure(provider, service, region string) {
@@ -144,15 +219,41 @@ func (ms *DefaultMetricsReporter) ReportScrapeShortLivedFailure(provider, region
 	scrapeShortLivedFailuresTotalCounter.WithLabelValues(provider, region).Inc()
 }
 
+func (ms *DefaultMetricsReporter) ReportCacheSize(provider, service string, entries int) {
+	cacheSizeGauge.WithLabelValues(provider, service).Set(float64(entries))
+}
+
+func (ms *DefaultMetricsReporter) ReportPriceAge(provider, region, instanceType string, age time.Duration) {
+	priceAgeGauge.WithLabelValues(provider, region, instanceType).Set(age.Seconds())
+}
+
+func (ms *DefaultMetricsReporter) ReportPriceCircuitBreakerState(provider string, state int) {
+	priceCircuitBreakerGauge.WithLabelValues(provider).Set(float64(state))
+}
+
+func (ms *DefaultMetricsReporter) ReportCacheHit(provider, service string) {
+	cacheHitsCounter.WithLabelValues(provider, service).Inc()
+}
+
+func (ms *DefaultMetricsReporter) ReportCacheMiss(provider, service string) {
+	cacheMissesCounter.WithLabelValues(provider, service).Inc()
+}
+
 // NewMetricsSource assembles a Reporter with custom collectors
 func NewDefaultMetricsReporter() Reporter {
 	dms := &DefaultMetricsReporter{}
 	dms.addCollector(scrapeCompleteDurationGauge)
 	dms.addCollector(scrapeRegionDurationGauge)
+	dms.addCollector(scrapeDurationHistogram)
 	dms.addCollector(scrapeFailuresTotalCounter)
 	dms.addCollector(scrapeShortLivedCompleteDurationGauge)
 	dms.addCollector(scrapeShortLivedRegionDurationGauge)
 	dms.addCollector(scrapeShortLivedFailuresTotalCounter)
+	dms.addCollector(cacheSizeGauge)
+	dms.addCollector(priceAgeGauge)
+	dms.addCollector(priceCircuitBreakerGauge)
+	dms.addCollector(cacheHitsCounter)
+	dms.addCollector(cacheMissesCounter)
 
 	dms.registerCollectors()
 
@@ -241,6 +342,16 @@ func (nor *noOpReporter) ReportScrapeRegionShortLivedCompleted(provider, region
 
 func (nor *noOpReporter) ReportScrapeShortLivedFailure(provider, region string) {}
 
+func (nor *noOpReporter) ReportCacheSize(provider, service string, entries int) {}
+
+func (nor *noOpReporter) ReportPriceAge(provider, region, instanceType string, age time.Duration) {}
+
+func (nor *noOpReporter) ReportPriceCircuitBreakerState(provider string, state int) {}
+
+func (nor *noOpReporter) ReportCacheHit(provider, service string) {}
+
+func (nor *noOpReporter) ReportCacheMiss(provider, service string) {}
+
 func NewNoOpMetricsReporter() Reporter {
 	return &noOpReporter{}
 }
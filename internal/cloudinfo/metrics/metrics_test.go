@@ -0,0 +1,83 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefaultMetricsReporter_ReportCacheSize(t *testing.T) {
+	reporter := &DefaultMetricsReporter{}
+
+	reporter.ReportCacheSize("amazon", "compute", 42)
+	assert.Equal(t, 42.0, testutil.ToFloat64(cacheSizeGauge.WithLabelValues("amazon", "compute")))
+
+	reporter.ReportCacheSize("amazon", "compute", 0)
+	assert.Equal(t, 0.0, testutil.ToFloat64(cacheSizeGauge.WithLabelValues("amazon", "compute")))
+}
+
+func TestObserveScrapeDuration(t *testing.T) {
+	observeScrapeDuration("google", "compute", 90*time.Second)
+
+	expected := `
+# HELP scrape_duration_seconds Cloud provider scrape duration in seconds, partitioned by provider and service
+# TYPE scrape_duration_seconds histogram
+scrape_duration_seconds_bucket{provider="google",service="compute",le="0.25"} 0
+scrape_duration_seconds_bucket{provider="google",service="compute",le="0.5"} 0
+scrape_duration_seconds_bucket{provider="google",service="compute",le="1"} 0
+scrape_duration_seconds_bucket{provider="google",service="compute",le="2"} 0
+scrape_duration_seconds_bucket{provider="google",service="compute",le="4"} 0
+scrape_duration_seconds_bucket{provider="google",service="compute",le="8"} 0
+scrape_duration_seconds_bucket{provider="google",service="compute",le="16"} 0
+scrape_duration_seconds_bucket{provider="google",service="compute",le="32"} 0
+scrape_duration_seconds_bucket{provider="google",service="compute",le="64"} 0
+scrape_duration_seconds_bucket{provider="google",service="compute",le="128"} 1
+scrape_duration_seconds_bucket{provider="google",service="compute",le="256"} 1
+scrape_duration_seconds_bucket{provider="google",service="compute",le="512"} 1
+scrape_duration_seconds_bucket{provider="google",service="compute",le="+Inf"} 1
+scrape_duration_seconds_sum{provider="google",service="compute"} 90
+scrape_duration_seconds_count{provider="google",service="compute"} 1
+`
+	assert.Nil(t, testutil.CollectAndCompare(scrapeDurationHistogram, strings.NewReader(expected)))
+}
+
+func TestDefaultMetricsReporter_ReportPriceAge(t *testing.T) {
+	reporter := &DefaultMetricsReporter{}
+
+	reporter.ReportPriceAge("amazon", "us-east-1", "m5.large", 5*time.Minute)
+	assert.Equal(t, 300.0, testutil.ToFloat64(priceAgeGauge.WithLabelValues("amazon", "us-east-1", "m5.large")))
+}
+
+func TestDefaultMetricsReporter_ReportPriceCircuitBreakerState(t *testing.T) {
+	reporter := &DefaultMetricsReporter{}
+
+	reporter.ReportPriceCircuitBreakerState("amazon", 2)
+	assert.Equal(t, 2.0, testutil.ToFloat64(priceCircuitBreakerGauge.WithLabelValues("amazon")))
+}
+
+func TestDefaultMetricsReporter_ReportCacheLookups(t *testing.T) {
+	reporter := &DefaultMetricsReporter{}
+
+	reporter.ReportCacheHit("amazon", "compute")
+	reporter.ReportCacheHit("amazon", "compute")
+	reporter.ReportCacheMiss("amazon", "compute")
+	assert.Equal(t, 2.0, testutil.ToFloat64(cacheHitsCounter.WithLabelValues("amazon", "compute")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(cacheMissesCounter.WithLabelValues("amazon", "compute")))
+}
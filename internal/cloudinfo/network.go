@@ -0,0 +1,62 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudinfo
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// networkBandwidthRegexp matches the explicit network bandwidths, eg.: "Up to 10 Gigabit", "16 Gbit/s", "300 Mbit/s"
+// nolint: gochecknoglobals
+var networkBandwidthRegexp = regexp.MustCompile(`(?i)^(up to\s+)?([0-9]+(?:\.[0-9]+)?)\s*(gigabit|gbit/s|gbps|megabit|mbit/s|mbps)$`)
+
+// NetworkBandwidthInfoer is an optional interface implemented by the infoers reporting the network performance qualitatively
+type NetworkBandwidthInfoer interface {
+	// NetworkBandwidths returns the approximate bandwidth in Gbps of the qualitative network performance values (eg.: "High")
+	NetworkBandwidths() map[string]float64
+}
+
+// ParseNetworkGbps extracts the network bandwidth in Gbps from the network performance reported by the provider
+// explicit bandwidths are parsed (the upper bound is taken for "Up to" values), qualitative values are looked up in the
+// provider supplied bandwidths; zero is returned if the bandwidth can't be determined
+func ParseNetworkGbps(ntwPerf string, bandwidths map[string]float64) float64 {
+	ntwPerf = strings.TrimSpace(ntwPerf)
+
+	if match := networkBandwidthRegexp.FindStringSubmatch(ntwPerf); match != nil {
+		value, err := strconv.ParseFloat(match[2], 64)
+		if err != nil {
+			return 0
+		}
+
+		if unit := strings.ToLower(match[3]); strings.HasPrefix(unit, "m") {
+			value /= 1000
+		}
+
+		return value
+	}
+
+	return bandwidths[ntwPerf]
+}
+
+// networkBandwidths returns the qualitative network bandwidths of the infoer, nil if it doesn't supply any
+func networkBandwidths(infoer CloudInfoer) map[string]float64 {
+	if bandwidthInfoer, ok := infoer.(NetworkBandwidthInfoer); ok {
+		return bandwidthInfoer.NetworkBandwidths()
+	}
+
+	return nil
+}
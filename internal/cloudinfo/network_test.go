@@ -0,0 +1,49 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudinfo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseNetworkGbps(t *testing.T) {
+	bandwidths := map[string]float64{"Low": 0.1, "Moderate": 0.5, "High": 1}
+
+	tests := []struct {
+		ntwPerf string
+		gbps    float64
+	}{
+		{ntwPerf: "Up to 10 Gigabit", gbps: 10},
+		{ntwPerf: "Up to 25 Gigabit", gbps: 25},
+		{ntwPerf: "10 Gigabit", gbps: 10},
+		{ntwPerf: "100 Gigabit", gbps: 100},
+		{ntwPerf: "High", gbps: 1},
+		{ntwPerf: "Moderate", gbps: 0.5},
+		{ntwPerf: "Low", gbps: 0.1},
+		{ntwPerf: "Very Low", gbps: 0},
+		{ntwPerf: "NA", gbps: 0},
+		{ntwPerf: "16 Gbit/s", gbps: 16},
+		{ntwPerf: "0.7 Gbps", gbps: 0.7},
+		{ntwPerf: "300 Mbit/s", gbps: 0.3},
+		{ntwPerf: "", gbps: 0},
+	}
+	for _, test := range tests {
+		t.Run(test.ntwPerf, func(t *testing.T) {
+			assert.InDelta(t, test.gbps, ParseNetworkGbps(test.ntwPerf, bandwidths), 1e-9)
+		})
+	}
+}
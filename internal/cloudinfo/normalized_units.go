@@ -0,0 +1,76 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudinfo
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// unitsPerCpu the normalized units of a vCPU, so that the cpu based units line up with the size based ones (2 vCPU large = 4)
+const unitsPerCpu = 2
+
+// sizeNormalizationFactors the normalized units of the instance sizes relative to the large size, as used by AWS Spot Fleet
+// nolint: gochecknoglobals
+var sizeNormalizationFactors = map[string]float64{
+	"nano":   0.25,
+	"micro":  0.5,
+	"small":  1,
+	"medium": 2,
+	"large":  4,
+	"xlarge": 8,
+}
+
+// multipliedSizeRegexp matches the multiples of the xlarge size, eg.: 2xlarge, 24xlarge
+// nolint: gochecknoglobals
+var multipliedSizeRegexp = regexp.MustCompile(`^([0-9]+)xlarge$`)
+
+// sizedInstanceTypeProviders the providers naming their instance types with a size suffix (eg.: m5.2xlarge, ecs.g6.large)
+// nolint: gochecknoglobals
+var sizedInstanceTypeProviders = []string{"amazon", "alibaba"}
+
+// NormalizedUnits calculates the capacity of the instance type in normalized units, making different instance types comparable
+// the units are derived from the size in the instance type name for the providers using sized names (*.large = 4),
+// otherwise (or if the size is not recognized, eg.: metal) from the number of vCPUs (1 vCPU = 2)
+func NormalizedUnits(provider, instanceType string, cpus float64) float64 {
+	if Contains(sizedInstanceTypeProviders, provider) {
+		if units, ok := sizeUnits(instanceType); ok {
+			return units
+		}
+	}
+
+	return cpus * unitsPerCpu
+}
+
+// sizeUnits returns the normalized units of the size suffix of the instance type
+func sizeUnits(instanceType string) (float64, bool) {
+	size := instanceType[strings.LastIndex(instanceType, ".")+1:]
+
+	if units, ok := sizeNormalizationFactors[size]; ok {
+		return units, true
+	}
+
+	if match := multipliedSizeRegexp.FindStringSubmatch(size); match != nil {
+		multiplier, err := strconv.ParseFloat(match[1], 64)
+		if err != nil {
+			return 0, false
+		}
+
+		return multiplier * sizeNormalizationFactors["xlarge"], true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,63 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudinfo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/banzaicloud/cloudinfo/internal/cloudinfo/types"
+)
+
+func TestNormalizedUnits(t *testing.T) {
+	tests := []struct {
+		provider     string
+		instanceType string
+		cpus         float64
+		units        float64
+	}{
+		{provider: "amazon", instanceType: "t3.nano", cpus: 2, units: 0.25},
+		{provider: "amazon", instanceType: "m5.large", cpus: 2, units: 4},
+		{provider: "amazon", instanceType: "m5.xlarge", cpus: 4, units: 8},
+		{provider: "amazon", instanceType: "m5.24xlarge", cpus: 96, units: 192},
+		{provider: "alibaba", instanceType: "ecs.g6.2xlarge", cpus: 8, units: 16},
+		// the size of the bare metal instance types can't be told from their name
+		{provider: "amazon", instanceType: "m5.metal", cpus: 96, units: 192},
+		{provider: "azure", instanceType: "Standard_D4s_v3", cpus: 4, units: 8},
+		{provider: "google", instanceType: "n1-standard-1", cpus: 1, units: 2},
+	}
+	for _, test := range tests {
+		t.Run(test.provider+"/"+test.instanceType, func(t *testing.T) {
+			assert.Equal(t, test.units, NormalizedUnits(test.provider, test.instanceType, test.cpus))
+		})
+	}
+}
+
+func TestCachingCloudInfo_GetSortedProductDetails_pricePerUnit(t *testing.T) {
+	store := newTestCloudInfoStore()
+	store.StoreVm("dummyProvider", "dummyService", "dummyRegion", []types.VMInfo{
+		{Type: "m5.xlarge", OnDemandPrice: 0.192, Cpus: 4, NormalizedUnits: 8},
+		{Type: "c5.large", OnDemandPrice: 0.085, Cpus: 2, NormalizedUnits: 4},
+		{Type: "unknown", OnDemandPrice: 0.01, Cpus: 2},
+		{Type: "m5.large", OnDemandPrice: 0.096, Cpus: 2, NormalizedUnits: 4},
+	})
+	info, _ := NewCloudInfo([]string{"dummyProvider"}, store, cloudinfoLogger)
+
+	details, err := info.GetSortedProductDetails("dummyProvider", "dummyService", "dummyRegion", PricePerUnitAsc)
+	assert.Nil(t, err, "the error should be nil")
+	assert.Equal(t, []string{"c5.large", "m5.xlarge", "m5.large", "unknown"}, productTypes(details), "products with unknown units should be pushed to the end")
+	assert.Equal(t, 0.02125, details[0].PricePerNormalizedUnit())
+}
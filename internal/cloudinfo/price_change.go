@@ -0,0 +1,72 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudinfo
+
+import (
+	"github.com/banzaicloud/cloudinfo/internal/cloudinfo/types"
+)
+
+// DiffPrices returns the changes between the previous and the current prices keyed by instance type
+// instance types missing from either of the maps are reported with zero prices on the missing side
+func DiffPrices(previous, current map[string]types.Price) map[string]types.PriceChange {
+	changes := make(map[string]types.PriceChange)
+
+	for instanceType, price := range current {
+		prev := previous[instanceType]
+		if _, ok := previous[instanceType]; ok && !priceChanged(prev, price) {
+			continue
+		}
+
+		changes[instanceType] = types.PriceChange{
+			OldOnDemandPrice: prev.OnDemandPrice,
+			NewOnDemandPrice: price.OnDemandPrice,
+			OldSpotPrice:     prev.SpotPrice,
+			NewSpotPrice:     price.SpotPrice,
+		}
+	}
+
+	for instanceType, prev := range previous {
+		if _, ok := current[instanceType]; ok {
+			continue
+		}
+
+		changes[instanceType] = types.PriceChange{
+			OldOnDemandPrice: prev.OnDemandPrice,
+			OldSpotPrice:     prev.SpotPrice,
+		}
+	}
+
+	return changes
+}
+
+// priceChanged checks whether the on demand price or any of the zone spot prices differ
+func priceChanged(previous, current types.Price) bool {
+	return previous.OnDemandPrice != current.OnDemandPrice || !spotPricesEqual(previous.SpotPrice, current.SpotPrice)
+}
+
+// spotPricesEqual checks whether the spot prices have the same zones with the same price in every zone
+func spotPricesEqual(previous, current types.SpotPriceInfo) bool {
+	if len(previous) != len(current) {
+		return false
+	}
+
+	for zone, price := range previous {
+		if currentPrice, ok := current[zone]; !ok || currentPrice != price {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,87 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudinfo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/banzaicloud/cloudinfo/internal/cloudinfo/types"
+)
+
+func TestDiffPrices(t *testing.T) {
+	previous := map[string]types.Price{
+		"unchanged": {OnDemandPrice: 0.1, SpotPrice: types.SpotPriceInfo{"zone1": 0.03}},
+		"onDemand":  {OnDemandPrice: 0.2, SpotPrice: types.SpotPriceInfo{"zone1": 0.05}},
+		"spot":      {OnDemandPrice: 0.3, SpotPrice: types.SpotPriceInfo{"zone1": 0.09, "zone2": 0.1}},
+		"zone":      {OnDemandPrice: 0.35, SpotPrice: types.SpotPriceInfo{"zone1": 0.11}},
+		"removed":   {OnDemandPrice: 0.4},
+	}
+
+	tests := []struct {
+		name    string
+		current map[string]types.Price
+		checker func(changes map[string]types.PriceChange)
+	}{
+		{
+			name: "only the changed prices are reported, spot prices are compared zone by zone",
+			current: map[string]types.Price{
+				"unchanged": {OnDemandPrice: 0.1, SpotPrice: types.SpotPriceInfo{"zone1": 0.03}},
+				"onDemand":  {OnDemandPrice: 0.25, SpotPrice: types.SpotPriceInfo{"zone1": 0.05}},
+				"spot":      {OnDemandPrice: 0.3, SpotPrice: types.SpotPriceInfo{"zone1": 0.09, "zone2": 0.12}},
+				"zone":      {OnDemandPrice: 0.35, SpotPrice: types.SpotPriceInfo{"zone2": 0.11}},
+				"removed":   {OnDemandPrice: 0.4},
+				"added":     {OnDemandPrice: 0.5},
+			},
+			checker: func(changes map[string]types.PriceChange) {
+				assert.Equal(t, map[string]types.PriceChange{
+					"onDemand": {
+						OldOnDemandPrice: 0.2,
+						NewOnDemandPrice: 0.25,
+						OldSpotPrice:     types.SpotPriceInfo{"zone1": 0.05},
+						NewSpotPrice:     types.SpotPriceInfo{"zone1": 0.05},
+					},
+					"spot": {
+						OldOnDemandPrice: 0.3,
+						NewOnDemandPrice: 0.3,
+						OldSpotPrice:     types.SpotPriceInfo{"zone1": 0.09, "zone2": 0.1},
+						NewSpotPrice:     types.SpotPriceInfo{"zone1": 0.09, "zone2": 0.12},
+					},
+					"zone": {
+						OldOnDemandPrice: 0.35,
+						NewOnDemandPrice: 0.35,
+						OldSpotPrice:     types.SpotPriceInfo{"zone1": 0.11},
+						NewSpotPrice:     types.SpotPriceInfo{"zone2": 0.11},
+					},
+					"added": {NewOnDemandPrice: 0.5},
+				}, changes)
+			},
+		},
+		{
+			name:    "the instance types missing from the current prices are reported",
+			current: map[string]types.Price{},
+			checker: func(changes map[string]types.PriceChange) {
+				assert.Equal(t, 5, len(changes))
+				assert.Equal(t, types.PriceChange{OldOnDemandPrice: 0.4}, changes["removed"])
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.checker(DiffPrices(previous, test.current))
+		})
+	}
+}
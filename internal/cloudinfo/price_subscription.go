@@ -0,0 +1,74 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudinfo
+
+import (
+	"sync"
+
+	"github.com/banzaicloud/cloudinfo/internal/cloudinfo/types"
+)
+
+// priceSubscriptionBuffer the number of price changes buffered per subscriber
+// changes are dropped while the buffer of the subscriber is full, so that slow subscribers can't stall the scrapes
+const priceSubscriptionBuffer = 64
+
+// SubscribePrices subscribes to the price changes of the instance types in the region of the provider
+// the returned function cancels the subscription and closes the channel, it's safe to call it multiple times
+func (cpi *cloudInfo) SubscribePrices(provider, region string) (<-chan types.PriceChangeEvent, func()) {
+	ch := make(chan types.PriceChangeEvent, priceSubscriptionBuffer)
+	key := priceSubscriptionKey(provider, region)
+
+	cpi.priceSubscriptionsMu.Lock()
+	if cpi.priceSubscriptions[key] == nil {
+		cpi.priceSubscriptions[key] = make(map[chan types.PriceChangeEvent]struct{})
+	}
+	cpi.priceSubscriptions[key][ch] = struct{}{}
+	cpi.priceSubscriptionsMu.Unlock()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			cpi.priceSubscriptionsMu.Lock()
+			defer cpi.priceSubscriptionsMu.Unlock()
+
+			delete(cpi.priceSubscriptions[key], ch)
+			if len(cpi.priceSubscriptions[key]) == 0 {
+				delete(cpi.priceSubscriptions, key)
+			}
+			close(ch)
+		})
+	}
+
+	return ch, unsubscribe
+}
+
+// deliverPriceChange passes the price change to the subscribers of the region without blocking
+func (cpi *cloudInfo) deliverPriceChange(change types.PriceChangeEvent) {
+	cpi.priceSubscriptionsMu.Lock()
+	defer cpi.priceSubscriptionsMu.Unlock()
+
+	for ch := range cpi.priceSubscriptions[priceSubscriptionKey(change.Provider, change.Region)] {
+		select {
+		case ch <- change:
+		default:
+			cpi.log.Debug("dropping price change, the subscriber is lagging behind", map[string]interface{}{
+				"provider": change.Provider, "region": change.Region, "instanceType": change.InstanceType})
+		}
+	}
+}
+
+func priceSubscriptionKey(provider, region string) string {
+	return provider + "/" + region
+}
@@ -0,0 +1,86 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudinfo
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/banzaicloud/cloudinfo/internal/app/cloudinfo/messaging"
+	"github.com/banzaicloud/cloudinfo/internal/cloudinfo/types"
+)
+
+// risingSpotPriceCloudInfoer is a DummyCloudInfoer raising the spot price at every scrape
+type risingSpotPriceCloudInfoer struct {
+	DummyCloudInfoer
+	scrapes int32
+}
+
+func (i *risingSpotPriceCloudInfoer) GetCurrentPrices(region string) (map[string]types.Price, error) {
+	scrapes := atomic.AddInt32(&i.scrapes, 1)
+
+	return map[string]types.Price{
+		"dummyType1": {OnDemandPrice: 0.32, SpotPrice: types.SpotPriceInfo{region + "a": 0.1 * float64(scrapes)}},
+	}, nil
+}
+
+func TestCachingCloudInfo_SubscribePrices(t *testing.T) {
+	eventBus := messaging.NewDefaultEventBus(nil)
+	store := newTestCloudInfoStore()
+	sm := newTestScrapingManager(&risingSpotPriceCloudInfoer{}, store, 1)
+	sm.eventBus = eventBus
+	info, _ := NewCloudInfoWithOptions([]string{"dummyProvider"}, store, cloudinfoLogger, CloudInfoOptions{EventBus: eventBus})
+
+	changes, unsubscribe := info.SubscribePrices("dummyProvider", "dummyRegion1")
+	others, unsubscribeOthers := info.SubscribePrices("dummyProvider", "dummyRegion2")
+	defer unsubscribeOthers()
+
+	// there's no change at the first scrape
+	sm.scrapePricesInRegion(context.Background(), "dummyRegion1", nil)
+	sm.scrapePricesInRegion(context.Background(), "dummyRegion1", nil)
+
+	select {
+	case change := <-changes:
+		assert.Equal(t, "dummyProvider", change.Provider)
+		assert.Equal(t, "dummyRegion1", change.Region)
+		assert.Equal(t, "dummyType1", change.InstanceType)
+		assert.Equal(t, types.SpotPriceInfo{"dummyRegion1a": 0.1}, change.OldSpotPrice)
+		assert.Equal(t, types.SpotPriceInfo{"dummyRegion1a": 0.2}, change.NewSpotPrice)
+	case <-time.After(time.Second):
+		t.Fatal("the price change should be delivered")
+	}
+	assert.Empty(t, others, "the changes of other regions should not be delivered")
+
+	unsubscribe()
+	unsubscribe()
+	_, open := <-changes
+	assert.False(t, open, "the channel should be closed by unsubscribing")
+}
+
+func TestCachingCloudInfo_SubscribePrices_slowSubscriber(t *testing.T) {
+	info, _ := NewCloudInfo([]string{"dummyProvider"}, newTestCloudInfoStore(), cloudinfoLogger)
+	changes, unsubscribe := info.SubscribePrices("dummyProvider", "dummyRegion1")
+	defer unsubscribe()
+
+	for i := 0; i < 2*priceSubscriptionBuffer; i++ {
+		info.deliverPriceChange(types.PriceChangeEvent{Provider: "dummyProvider", Region: "dummyRegion1", InstanceType: "dummyType1"})
+	}
+
+	assert.Equal(t, priceSubscriptionBuffer, len(changes), "the changes exceeding the buffer should be dropped")
+}
@@ -0,0 +1,86 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudinfo
+
+import (
+	"strings"
+	"unicode"
+)
+
+// FamilyFunc derives the family of an instance type from its name
+type FamilyFunc func(instanceType string) string
+
+// nolint: gochecknoglobals
+var familyFuncs = map[string]FamilyFunc{
+	"amazon":  DotFamily,
+	"alibaba": alibabaFamily,
+	"oracle":  oracleFamily,
+	"google":  googleFamily,
+	"azure":   azureFamily,
+}
+
+// familyFunc returns the family derivation of the provider, DotFamily is used for unknown providers
+func familyFunc(provider string) FamilyFunc {
+	if fn, ok := familyFuncs[provider]; ok {
+		return fn
+	}
+
+	return DotFamily
+}
+
+// DotFamily returns the part of the instance type before the first dot (eg. m5 for m5.large)
+func DotFamily(instanceType string) string {
+	return strings.SplitN(instanceType, ".", 2)[0]
+}
+
+// alibabaFamily strips the ecs prefix of the instance type (eg. ecs.g5 for ecs.g5.large)
+func alibabaFamily(instanceType string) string {
+	return "ecs." + DotFamily(strings.TrimPrefix(instanceType, "ecs."))
+}
+
+// oracleFamily returns the part of the instance type before the last dot (eg. VM.Standard2 for VM.Standard2.1)
+func oracleFamily(instanceType string) string {
+	if i := strings.LastIndex(instanceType, "."); i > 0 {
+		return instanceType[:i]
+	}
+
+	return instanceType
+}
+
+// googleFamily returns the part of the machine type before the last dash (eg. n1-standard for n1-standard-4)
+func googleFamily(instanceType string) string {
+	if i := strings.LastIndex(instanceType, "-"); i > 0 {
+		return instanceType[:i]
+	}
+
+	return instanceType
+}
+
+// azureFamily strips the size digits of the instance type (eg. Standard_Ds_v3 for Standard_D2s_v3)
+func azureFamily(instanceType string) string {
+	parts := strings.Split(instanceType, "_")
+	if len(parts) < 2 {
+		return instanceType
+	}
+
+	parts[1] = strings.Map(func(r rune) rune {
+		if unicode.IsDigit(r) {
+			return -1
+		}
+		return r
+	}, parts[1])
+
+	return strings.Join(parts, "_")
+}
@@ -0,0 +1,149 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudinfo
+
+import (
+	"github.com/banzaicloud/cloudinfo/internal/cloudinfo/types"
+)
+
+// PricingModel a way the products are charged for
+type PricingModel string
+
+const (
+	// OnDemandPricing the on demand (pay as you go) prices
+	OnDemandPricing PricingModel = "onDemand"
+	// SpotPricing the spot (preemptible) prices, including the smoothed ones
+	SpotPricing PricingModel = "spot"
+	// ReservedPricing the reserved (committed use) prices
+	ReservedPricing PricingModel = "reserved"
+)
+
+// ProductFilter holds the bounds the cached products are filtered by
+// Zero valued fields are considered unbounded
+type ProductFilter struct {
+	MinCpu float64
+	MaxCpu float64
+	MinMem float64
+	MaxMem float64
+	MinGpu float64
+	// MinMemPerCpu and MaxMemPerCpu bound the memory (GB) per vCPU, products without cpus are dropped if either is set
+	MinMemPerCpu float64
+	MaxMemPerCpu float64
+	// GpuOnly restricts the products to the ones having GPUs attached
+	GpuOnly bool
+	// NtwPerfCats holds the accepted network performance categories (exact match), empty accepts all
+	NtwPerfCats []string
+	// Architecture restricts the products to the given cpu architecture, products with unknown architecture match any
+	Architecture string
+	// MinInstanceStorage the minimum size of the local disks in GB, products without local disks are dropped if set
+	MinInstanceStorage float64
+	// CurrentGenOnly drops the previous generation products
+	CurrentGenOnly bool
+	// Zone restricts the products to the ones available in the given zone, products without zones are available in every zone
+	Zone string
+	// InstanceType restricts the products to the given instance type
+	InstanceType string
+	// MinNtwGbps the minimum network bandwidth in Gbps, products with unknown bandwidth are dropped if set
+	MinNtwGbps float64
+	// PricingModels holds the pricing models the prices of the products are returned for, empty returns every price
+	// the on demand price is part of the product information, so it's returned regardless of the requested models
+	PricingModels []PricingModel
+}
+
+// includesPricing checks whether the prices of the pricing model are requested
+func (f ProductFilter) includesPricing(model PricingModel) bool {
+	if len(f.PricingModels) == 0 {
+		return true
+	}
+
+	for _, m := range f.PricingModels {
+		if m == model {
+			return true
+		}
+	}
+
+	return false
+}
+
+// applyProductFilter checks whether the virtual machine satisfies the bounds set in the filter
+func applyProductFilter(vm types.VMInfo, filter ProductFilter) bool {
+	if filter.MinCpu > 0 && vm.Cpus < filter.MinCpu {
+		return false
+	}
+
+	if filter.MaxCpu > 0 && vm.Cpus > filter.MaxCpu {
+		return false
+	}
+
+	if filter.MinMem > 0 && vm.Mem < filter.MinMem {
+		return false
+	}
+
+	if filter.MaxMem > 0 && vm.Mem > filter.MaxMem {
+		return false
+	}
+
+	if filter.MinGpu > 0 && vm.Gpus < filter.MinGpu {
+		return false
+	}
+
+	if filter.MinMemPerCpu > 0 || filter.MaxMemPerCpu > 0 {
+		if vm.Cpus <= 0 {
+			return false
+		}
+
+		memPerCpu := vm.Mem / vm.Cpus
+		if filter.MinMemPerCpu > 0 && memPerCpu < filter.MinMemPerCpu {
+			return false
+		}
+		if filter.MaxMemPerCpu > 0 && memPerCpu > filter.MaxMemPerCpu {
+			return false
+		}
+	}
+
+	if filter.GpuOnly && !vm.HasGpu() {
+		return false
+	}
+
+	if len(filter.NtwPerfCats) > 0 && !Contains(filter.NtwPerfCats, vm.NtwPerfCat) {
+		return false
+	}
+
+	if filter.Architecture != "" && vm.Architecture != "" && vm.Architecture != filter.Architecture {
+		return false
+	}
+
+	if filter.MinInstanceStorage > 0 && vm.InstanceStorageGB < filter.MinInstanceStorage {
+		return false
+	}
+
+	if filter.CurrentGenOnly && !vm.CurrentGen {
+		return false
+	}
+
+	if filter.Zone != "" && len(vm.Zones) > 0 && !Contains(vm.Zones, filter.Zone) {
+		return false
+	}
+
+	if filter.InstanceType != "" && vm.Type != filter.InstanceType {
+		return false
+	}
+
+	if filter.MinNtwGbps > 0 && vm.NtwGbps < filter.MinNtwGbps {
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,94 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudinfo
+
+import (
+	"sort"
+
+	"github.com/banzaicloud/cloudinfo/internal/cloudinfo/types"
+)
+
+// SortBy represents the ordering of the product details
+type SortBy string
+
+const (
+	// PriceAsc orders the products by on demand price ascending
+	PriceAsc SortBy = "priceAsc"
+	// PriceDesc orders the products by on demand price descending
+	PriceDesc SortBy = "priceDesc"
+	// SpotPriceAsc orders the products by their cheapest (minimum) spot zone price ascending
+	SpotPriceAsc SortBy = "spotPriceAsc"
+	// CpuAsc orders the products by cpu count ascending
+	CpuAsc SortBy = "cpuAsc"
+	// MemAsc orders the products by memory ascending
+	MemAsc SortBy = "memAsc"
+	// SavingsDesc orders the products by their spot savings descending
+	SavingsDesc SortBy = "savingsDesc"
+	// CpuPerDollarDesc orders the products by the cpus per on demand price descending
+	CpuPerDollarDesc SortBy = "cpuPerDollarDesc"
+	// MemPerDollarDesc orders the products by the memory per on demand price descending
+	MemPerDollarDesc SortBy = "memPerDollarDesc"
+	// PricePerUnitAsc orders the products by the on demand price of a normalized unit ascending
+	PricePerUnitAsc SortBy = "pricePerUnitAsc"
+)
+
+// sortKey returns the value products are ordered by and whether the value is available for the product
+func sortKey(pd types.ProductDetails, sortBy SortBy) (float64, bool) {
+	switch sortBy {
+	case PriceAsc, PriceDesc:
+		return pd.OnDemandPrice, pd.OnDemandPrice > 0
+	case SpotPriceAsc:
+		return cheapestSpotPrice(pd)
+	case CpuAsc:
+		return pd.Cpus, true
+	case MemAsc:
+		return pd.Mem, true
+	case SavingsDesc:
+		_, ok := cheapestSpotPrice(pd)
+		return pd.SpotSavings(), ok && pd.OnDemandPrice > 0
+	case CpuPerDollarDesc:
+		return pd.CpuPerDollar(), pd.OnDemandPrice > 0
+	case MemPerDollarDesc:
+		return pd.MemPerDollar(), pd.OnDemandPrice > 0
+	case PricePerUnitAsc:
+		return pd.PricePerNormalizedUnit(), pd.OnDemandPrice > 0 && pd.NormalizedUnits > 0
+	default:
+		return 0, false
+	}
+}
+
+// cheapestSpotPrice returns the lowest zone spot price of the product
+func cheapestSpotPrice(pd types.ProductDetails) (float64, bool) {
+	min, _, _, zones := pd.SpotStats()
+	return min, zones > 0
+}
+
+// sortProductDetails orders the products in place; products with no value for the requested key are moved to the end
+func sortProductDetails(details []types.ProductDetails, sortBy SortBy) {
+	sort.SliceStable(details, func(i, j int) bool {
+		vi, iok := sortKey(details[i], sortBy)
+		vj, jok := sortKey(details[j], sortBy)
+
+		if !iok || !jok {
+			return iok && !jok
+		}
+
+		if sortBy == PriceDesc || sortBy == SavingsDesc || sortBy == CpuPerDollarDesc || sortBy == MemPerDollarDesc {
+			return vi > vj
+		}
+
+		return vi < vj
+	})
+}
@@ -22,15 +22,6 @@ import (
 	"github.com/banzaicloud/cloudinfo/internal/cloudinfo/types"
 )
 
-// nolint: gochecknoglobals
-var providerNames = map[string]string{
-	"amazon":  "Amazon Web Services",
-	"google":  "Google Cloud",
-	"alibaba": "Alibaba Cloud",
-	"oracle":  "Oracle",
-	"azure":   "Microsoft Azure",
-}
-
 // ProviderStore retrieves providers.
 type ProviderStore interface {
 	// GetProviders returns the supported providers.
@@ -65,14 +56,9 @@ func (s *ProviderService) ListProviders(ctx context.Context) ([]Provider, error)
 	providers := make([]Provider, len(cloudProviders))
 
 	for i, provider := range cloudProviders {
-		name, ok := providerNames[provider.Provider]
-		if !ok {
-			name = provider.Provider
-		}
-
 		providers[i] = Provider{
 			Code: provider.Provider,
-			Name: name,
+			Name: types.GetProviderMetadata(provider.Provider).DisplayName,
 		}
 	}
 
@@ -49,3 +49,33 @@ func TestProviderService_ListProviders(t *testing.T) {
 		providers,
 	)
 }
+
+func TestNewProvider_metadata(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider string
+		check    func(metadata types.ProviderMetadata)
+	}{
+		{
+			name:     "known provider",
+			provider: "amazon",
+			check: func(metadata types.ProviderMetadata) {
+				assert.Equal(t, "Amazon Web Services", metadata.DisplayName)
+				assert.Equal(t, "aws", metadata.IconKey)
+				assert.NotEmpty(t, metadata.DocumentationURL)
+			},
+		},
+		{
+			name:     "unknown provider",
+			provider: "dummyProvider",
+			check: func(metadata types.ProviderMetadata) {
+				assert.Equal(t, types.ProviderMetadata{DisplayName: "dummyProvider"}, metadata)
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.check(types.NewProvider(test.provider).Metadata)
+		})
+	}
+}
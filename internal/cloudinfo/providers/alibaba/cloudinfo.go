@@ -311,6 +311,11 @@ func (a *AlibabaInfoer) getPrice(instanceTypes []string, region string) ([]float
 	return price, nil
 }
 
+// HasZones - Alibaba supports availability zones
+func (a *AlibabaInfoer) HasZones() bool {
+	return true
+}
+
 // GetZones returns the availability zones in a region
 func (a *AlibabaInfoer) GetZones(region string) ([]string, error) {
 	logger := log.WithFields(a.log, map[string]interface{}{"region": region})
@@ -362,6 +367,16 @@ func (a *AlibabaInfoer) HasShortLivedPriceInfo() bool {
 	return false
 }
 
+// HasReserved - Alibaba reserved prices are not supported
+func (a *AlibabaInfoer) HasReserved() bool {
+	return false
+}
+
+// PriceRegionService - Alibaba prices are scraped in the regions of the compute service
+func (a *AlibabaInfoer) PriceRegionService() string {
+	return cloudinfo.DefaultPriceRegionService
+}
+
 // GetCurrentPrices returns the current spot prices of every instance type in every availability zone in a given region
 func (a *AlibabaInfoer) GetCurrentPrices(region string) (map[string]types.Price, error) {
 	var spotPrices map[string]types.SpotPriceInfo
@@ -410,7 +425,9 @@ func (a *AlibabaInfoer) GetServiceImages(service, region string) ([]types.Image,
 	for _, image := range response.Images.Image {
 		if strings.Contains(image.ImageId, "centos_7") {
 			images = append(images, types.Image{
-				Name: image.ImageId,
+				Name:      image.ImageId,
+				Os:        "centos",
+				OsVersion: "7",
 			})
 		}
 	}
@@ -423,6 +440,11 @@ func (a *AlibabaInfoer) GetServiceProducts(region, service string) ([]types.Prod
 	return nil, errors.New("GetServiceProducts - not yet implemented")
 }
 
+// HasVersions - Alibaba supports kubernetes versions
+func (a *AlibabaInfoer) HasVersions() bool {
+	return true
+}
+
 // GetVersions retrieves the kubernetes versions supported by the given service in the given region
 func (a *AlibabaInfoer) GetVersions(service, region string) ([]types.LocationVersion, error) {
 	switch service {
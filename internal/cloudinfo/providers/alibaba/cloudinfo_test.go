@@ -0,0 +1,219 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alibaba
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/requests"
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/responses"
+	"github.com/stretchr/testify/assert"
+	"logur.dev/logur"
+
+	"github.com/banzaicloud/cloudinfo/internal/cloudinfo/cloudinfoadapter"
+	"github.com/banzaicloud/cloudinfo/internal/cloudinfo/types"
+)
+
+// testDescriber mocks the Alibaba API calls, the responses are keyed by the API name
+type testDescriber struct {
+	responses map[string]string
+}
+
+func (td *testDescriber) ProcessCommonRequest(request *requests.CommonRequest) (*responses.CommonResponse, error) {
+	content, ok := td.responses[request.ApiName]
+	if !ok {
+		return nil, errors.New("failed to call " + request.ApiName)
+	}
+
+	response := responses.NewCommonResponse()
+	httpResponse := &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{},
+		Body:       ioutil.NopCloser(strings.NewReader(content)),
+	}
+	if err := responses.Unmarshal(response, httpResponse, "JSON"); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+const (
+	testRegions       = `{"Regions":{"Region":[{"RegionId":"eu-central-1","LocalName":"Frankfurt"},{"RegionId":"cn-hangzhou","LocalName":"Hangzhou"}]}}`
+	testZones         = `{"Zones":{"Zone":[{"ZoneId":"eu-central-1a","AvailableResources":{"ResourcesInfo":[{"InstanceTypes":{"SupportedInstanceType":["ecs.g5.large"]}}]}},{"ZoneId":"eu-central-1b","AvailableResources":{"ResourcesInfo":[{"InstanceTypes":{"SupportedInstanceType":["ecs.g5.large","ecs.c5.xlarge"]}}]}}]}}`
+	testInstanceTypes = `{"InstanceTypes":{"InstanceType":[{"InstanceTypeId":"ecs.g5.large","CpuCoreCount":2,"MemorySize":8,"GPUAmount":0,"InstanceBandwidthRx":1024000},{"InstanceTypeId":"ecs.c5.xlarge","CpuCoreCount":4,"MemorySize":8,"GPUAmount":0,"InstanceBandwidthRx":10240000},{"InstanceTypeId":"ecs.r5.large","CpuCoreCount":2,"MemorySize":16,"GPUAmount":0,"InstanceBandwidthRx":1024000}]}}`
+	testPrices        = `{"Success":true,"Code":"Success","Data":{"ModuleDetails":{"ModuleDetail":[{"OriginalCost":0.12},{"OriginalCost":0.25}]}}}`
+)
+
+func newTestInfoer(client CommonDescriber) *AlibabaInfoer {
+	return &AlibabaInfoer{
+		client: client,
+		log:    cloudinfoadapter.NewLogger(&logur.TestLogger{}),
+	}
+}
+
+func TestAlibabaInfoer_GetRegions(t *testing.T) {
+	tests := []struct {
+		name   string
+		client CommonDescriber
+		check  func(regions map[string]string, err error)
+	}{
+		{
+			name:   "success - regions are mapped to their local names",
+			client: &testDescriber{responses: map[string]string{"DescribeRegions": testRegions}},
+			check: func(regions map[string]string, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, map[string]string{"eu-central-1": "Frankfurt", "cn-hangzhou": "Hangzhou"}, regions)
+			},
+		},
+		{
+			name:   "error - DescribeRegions call fails",
+			client: &testDescriber{},
+			check: func(regions map[string]string, err error) {
+				assert.Nil(t, regions, "the regions should be nil")
+				assert.EqualError(t, err, "DescribeRegions API call problem: failed to call DescribeRegions")
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.check(newTestInfoer(test.client).GetRegions("compute"))
+		})
+	}
+}
+
+func TestAlibabaInfoer_GetZones(t *testing.T) {
+	tests := []struct {
+		name   string
+		client CommonDescriber
+		check  func(zones []string, err error)
+	}{
+		{
+			name:   "success - zones of the region are returned",
+			client: &testDescriber{responses: map[string]string{"DescribeZones": testZones}},
+			check: func(zones []string, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, []string{"eu-central-1a", "eu-central-1b"}, zones)
+			},
+		},
+		{
+			name:   "error - DescribeZones call fails",
+			client: &testDescriber{},
+			check: func(zones []string, err error) {
+				assert.Nil(t, zones, "the zones should be nil")
+				assert.EqualError(t, err, "DescribeZones API call problem: failed to call DescribeZones")
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.check(newTestInfoer(test.client).GetZones("eu-central-1"))
+		})
+	}
+}
+
+func TestAlibabaInfoer_GetVirtualMachines(t *testing.T) {
+	tests := []struct {
+		name   string
+		client CommonDescriber
+		check  func(vms []types.VMInfo, err error)
+	}{
+		{
+			name: "success - instance types available in the region are mapped to products",
+			client: &testDescriber{responses: map[string]string{
+				"DescribeInstanceTypes": testInstanceTypes,
+				"DescribeZones":         testZones,
+				"GetPayAsYouGoPrice":    testPrices,
+			}},
+			check: func(vms []types.VMInfo, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, 2, len(vms))
+
+				assert.Equal(t, "ecs.g5.large", vms[0].Type)
+				assert.Equal(t, types.CategoryGeneral, vms[0].Category)
+				assert.Equal(t, float64(2), vms[0].Cpus)
+				assert.Equal(t, float64(8), vms[0].Mem)
+				assert.Equal(t, "1.0 Gbit/s", vms[0].NtwPerf)
+				assert.Equal(t, types.NtwLow, vms[0].NtwPerfCat)
+				assert.Equal(t, []string{"eu-central-1a", "eu-central-1b"}, vms[0].Zones)
+				assert.Equal(t, 0.12, vms[0].OnDemandPrice)
+
+				assert.Equal(t, "ecs.c5.xlarge", vms[1].Type)
+				assert.Equal(t, types.CategoryCompute, vms[1].Category)
+				assert.Equal(t, "10.0 Gbit/s", vms[1].NtwPerf)
+				assert.Equal(t, types.NtwHight, vms[1].NtwPerfCat)
+				assert.Equal(t, []string{"eu-central-1b"}, vms[1].Zones)
+				assert.Equal(t, 0.25, vms[1].OnDemandPrice)
+			},
+		},
+		{
+			name: "error - DescribeInstanceTypes call fails",
+			client: &testDescriber{responses: map[string]string{
+				"DescribeZones": testZones,
+			}},
+			check: func(vms []types.VMInfo, err error) {
+				assert.Nil(t, vms, "the vms should be nil")
+				assert.EqualError(t, err, "DescribeInstanceTypes API call problem: failed to call DescribeInstanceTypes")
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.check(newTestInfoer(test.client).GetVirtualMachines("eu-central-1"))
+		})
+	}
+}
+
+func TestAlibabaInfoer_mapCategory(t *testing.T) {
+	tests := []struct {
+		name         string
+		instanceType string
+		check        func(category string, err error)
+	}{
+		{
+			name:         "general purpose family",
+			instanceType: "ecs.g5.large",
+			check: func(category string, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, types.CategoryGeneral, category)
+			},
+		},
+		{
+			name:         "family with suffix",
+			instanceType: "ecs.gn6v-c8g1.2xlarge",
+			check: func(category string, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, types.CategoryGpu, category)
+			},
+		},
+		{
+			name:         "unknown family",
+			instanceType: "ecs.unknown.large",
+			check: func(category string, err error) {
+				assert.Equal(t, "", category)
+				assert.EqualError(t, err, "could not determine the category: unknown")
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.check(newTestInfoer(&testDescriber{}).mapCategory(test.instanceType))
+		})
+	}
+}
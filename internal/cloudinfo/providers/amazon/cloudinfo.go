@@ -173,6 +173,15 @@ func (e *Ec2Infoer) GetVirtualMachines(region string) ([]types.VMInfo, error) {
 			missingAttributes[instanceType] = append(missingAttributes[instanceType], "networkPerformance")
 		}
 
+		baselinePerf, burstable := burstableBaselines[instanceType]
+
+		// instance types without local disks report "EBS only"
+		storage, _ := pd.getDataForKey("storage")
+		storageGB, storageType := instanceStorage(storage)
+
+		// the physical processor is only missing for a few legacy instance types
+		physicalProcessor, _ := pd.getDataForKey("physicalProcessor")
+
 		var currGen = true
 		if currentGenStr, err := pd.getDataForKey("currentGeneration"); err == nil {
 			if strings.ToLower(currentGenStr) == "no" {
@@ -201,7 +210,13 @@ func (e *Ec2Infoer) GetVirtualMachines(region string) ([]types.VMInfo, error) {
 			NtwPerf:       ntwPerf,
 			NtwPerfCat:    ntwPerfCat,
 			CurrentGen:    currGen,
+			Architecture:  architecture(physicalProcessor),
+			Burstable:     burstable,
+			BaselinePerf:  baselinePerf,
 			Attributes:    cloudinfo.Attributes(cpusStr, strings.Split(memStr, " ")[0], ntwPerfCat, instanceFamily),
+
+			InstanceStorageGB:   storageGB,
+			InstanceStorageType: storageType,
 		}
 		vms = append(vms, vm)
 	}
@@ -243,6 +258,52 @@ func (e *Ec2Infoer) GetProducts(vms []types.VMInfo, service, regionId string) ([
 	}
 }
 
+// burstableBaselines holds the baseline cpu utilization per vCPU in percent of the burstable instance types
+// nolint: gochecknoglobals
+var burstableBaselines = map[string]float64{
+	"t2.nano": 5, "t2.micro": 10, "t2.small": 20, "t2.medium": 20, "t2.large": 30, "t2.xlarge": 22.5, "t2.2xlarge": 17,
+	"t3.nano": 5, "t3.micro": 10, "t3.small": 20, "t3.medium": 20, "t3.large": 30, "t3.xlarge": 40, "t3.2xlarge": 40,
+	"t3a.nano": 5, "t3a.micro": 10, "t3a.small": 20, "t3a.medium": 20, "t3a.large": 30, "t3a.xlarge": 40, "t3a.2xlarge": 40,
+	"t4g.nano": 5, "t4g.micro": 10, "t4g.small": 20, "t4g.medium": 20, "t4g.large": 30, "t4g.xlarge": 40, "t4g.2xlarge": 40,
+}
+
+// instanceStorage parses the storage attribute of the instance type (eg.: "2 x 900 NVMe SSD")
+// into the total size in GB and the type of the local disks; zero is returned for instance types without local disks
+func instanceStorage(storage string) (float64, string) {
+	fields := strings.Fields(storage)
+	if len(fields) < 3 || fields[1] != "x" {
+		return 0, ""
+	}
+
+	count, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return 0, ""
+	}
+
+	size, err := strconv.ParseFloat(strings.ReplaceAll(fields[2], ",", ""), 64)
+	if err != nil {
+		return 0, ""
+	}
+
+	storageType := strings.TrimSpace(strings.TrimPrefix(strings.Join(fields[3:], " "), "GB"))
+
+	return count * size, storageType
+}
+
+// architecture derives the cpu architecture from the physical processor of the instance type
+// the Graviton processors are arm based, all the others are x86
+func architecture(physicalProcessor string) string {
+	if physicalProcessor == "" {
+		return ""
+	}
+
+	if strings.Contains(strings.ToLower(physicalProcessor), "graviton") {
+		return types.ArchArm64
+	}
+
+	return types.ArchX86_64
+}
+
 type priceData struct {
 	awsData aws.JSONValue
 	attrMap map[string]interface{}
@@ -428,6 +489,11 @@ func (e *Ec2Infoer) GetRegions(service string) (map[string]string, error) {
 	}
 }
 
+// HasZones - Amazon supports availability zones
+func (e *Ec2Infoer) HasZones() bool {
+	return true
+}
+
 // GetZones returns the availability zones in a region
 func (e *Ec2Infoer) GetZones(region string) ([]string, error) {
 	logger := log.WithFields(e.log, map[string]interface{}{"region": region})
@@ -453,6 +519,26 @@ func (e *Ec2Infoer) HasShortLivedPriceInfo() bool {
 	return true
 }
 
+// HasReserved - Amazon reserved prices are not supported
+func (e *Ec2Infoer) HasReserved() bool {
+	return false
+}
+
+// NetworkBandwidths returns the approximate bandwidths of the qualitative Amazon network performance values
+func (e *Ec2Infoer) NetworkBandwidths() map[string]float64 {
+	return networkBandwidths
+}
+
+// Attributes - EC2 instance types can be filtered by their gpu count besides cpu and memory
+func (e *Ec2Infoer) Attributes() []string {
+	return []string{types.CPU, types.Memory, types.GPU}
+}
+
+// PriceRegionService - EC2 prices are scraped in the regions of the compute service
+func (e *Ec2Infoer) PriceRegionService() string {
+	return cloudinfo.DefaultPriceRegionService
+}
+
 func (e *Ec2Infoer) getSpotPricesFromPrometheus(region string) (map[string]types.SpotPriceInfo, error) {
 	logger := log.WithFields(e.log, map[string]interface{}{"region": region})
 	logger.Debug("getting spot price averages from Prometheus API")
@@ -565,7 +651,7 @@ func (e *Ec2Infoer) GetServiceImages(service, region string) ([]types.Image, err
 			}
 
 			if latestImage != nil {
-				serviceImages = append(serviceImages, types.NewImage(*latestImage.ImageId, k8sVersion, true))
+				serviceImages = append(serviceImages, newEKSImage(*latestImage.ImageId, k8sVersion, true))
 			}
 
 			images, err := e.ec2Describer(region).DescribeImages(getEKSDescribeImagesInput(k8sVersion, false))
@@ -579,7 +665,7 @@ func (e *Ec2Infoer) GetServiceImages(service, region string) ([]types.Image, err
 			}
 
 			if latestImage != nil {
-				serviceImages = append(serviceImages, types.NewImage(*latestImage.ImageId, k8sVersion, false))
+				serviceImages = append(serviceImages, newEKSImage(*latestImage.ImageId, k8sVersion, false))
 			}
 		}
 	case svcPKE:
@@ -599,6 +685,8 @@ func (e *Ec2Infoer) GetServiceImages(service, region string) ([]types.Image, err
 
 			pkeImage := types.NewImage(*amazonImage.ImageId, imageTags[tagK8SVersion], gpu)
 			pkeImage.Tags = imageTags
+			pkeImage.Os = imageTags[tagOsType]
+			pkeImage.OsVersion = imageTags[tagOsVersion]
 			creationDate, _ := getImageCreateDate(amazonImage)
 			pkeImage.CreationDate = creationDate
 			serviceImages = append(serviceImages, pkeImage)
@@ -613,6 +701,11 @@ func (e *Ec2Infoer) GetServiceProducts(region, service string) ([]types.ProductD
 	return nil, errors.New("GetServiceProducts - not yet implemented")
 }
 
+// HasVersions - Amazon supports kubernetes versions
+func (e *Ec2Infoer) HasVersions() bool {
+	return true
+}
+
 // GetVersions retrieves the kubernetes versions supported by the given service in the given region
 func (e *Ec2Infoer) GetVersions(service, region string) ([]types.LocationVersion, error) {
 	switch service {
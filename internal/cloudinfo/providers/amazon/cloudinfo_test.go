@@ -656,3 +656,51 @@ func TestPriceData_newPriceData(t *testing.T) {
 		})
 	}
 }
+
+func TestArchitecture(t *testing.T) {
+	tests := []struct {
+		physicalProcessor string
+		architecture      string
+	}{
+		{physicalProcessor: "AWS Graviton2 Processor", architecture: types.ArchArm64},
+		{physicalProcessor: "Intel Xeon Platinum 8175", architecture: types.ArchX86_64},
+		{physicalProcessor: "AMD EPYC 7571", architecture: types.ArchX86_64},
+		{physicalProcessor: "", architecture: ""},
+	}
+	for _, test := range tests {
+		t.Run(test.physicalProcessor, func(t *testing.T) {
+			assert.Equal(t, test.architecture, architecture(test.physicalProcessor))
+		})
+	}
+}
+
+func TestBurstableBaselines(t *testing.T) {
+	baselinePerf, burstable := burstableBaselines["t3.medium"]
+	assert.True(t, burstable, "t3.medium should be burstable")
+	assert.Equal(t, 20.0, baselinePerf)
+
+	_, burstable = burstableBaselines["m5.large"]
+	assert.False(t, burstable, "m5.large should not be burstable")
+}
+
+func TestInstanceStorage(t *testing.T) {
+	tests := []struct {
+		storage     string
+		sizeGB      float64
+		storageType string
+	}{
+		{storage: "2 x 900 NVMe SSD", sizeGB: 1800, storageType: "NVMe SSD"},
+		{storage: "24 x 2000 HDD", sizeGB: 48000, storageType: "HDD"},
+		{storage: "1 x 4 SSD", sizeGB: 4, storageType: "SSD"},
+		{storage: "1 x 1,900 GB NVMe SSD", sizeGB: 1900, storageType: "NVMe SSD"},
+		{storage: "EBS only", sizeGB: 0, storageType: ""},
+		{storage: "", sizeGB: 0, storageType: ""},
+	}
+	for _, test := range tests {
+		t.Run(test.storage, func(t *testing.T) {
+			sizeGB, storageType := instanceStorage(test.storage)
+			assert.Equal(t, test.sizeGB, sizeGB)
+			assert.Equal(t, test.storageType, storageType)
+		})
+	}
+}
@@ -19,6 +19,8 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
+
+	"github.com/banzaicloud/cloudinfo/internal/cloudinfo/types"
 )
 
 const (
@@ -28,6 +30,12 @@ const (
 
 	tagPKEVersion = "pke-version"
 	tagK8SVersion = "k8s-version"
+	tagOsType     = "os-type"
+	tagOsVersion  = "os-version"
+
+	// the EKS optimized images are built on Amazon Linux 2
+	eksImageOs        = "amazon-linux"
+	eksImageOsVersion = "2"
 )
 
 func getEKSDescribeImagesInput(kubernetesVersion string, GPUs bool) *ec2.DescribeImagesInput {
@@ -86,3 +94,12 @@ func tagsFormImage(image *ec2.Image) map[string]string {
 	}
 	return tags
 }
+
+// newEKSImage creates an EKS optimized image
+func newEKSImage(id, kubernetesVersion string, gpu bool) types.Image {
+	image := types.NewImage(id, kubernetesVersion, gpu)
+	image.Os = eksImageOs
+	image.OsVersion = eksImageOsVersion
+
+	return image
+}
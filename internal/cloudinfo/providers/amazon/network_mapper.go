@@ -43,6 +43,15 @@ var (
 		types.NtwHight:  {"Up to 10 Gigabit", "10 Gigabit"},
 		types.NtwExtra:  {"20 Gigabit", "25 Gigabit", "Up to 25 Gigabit", "50 Gigabit", "100 Gigabit"},
 	}
+
+	// networkBandwidths holds the approximate bandwidths in Gbps of the qualitative network performance values
+	networkBandwidths = map[string]float64{
+		"Very Low":        0.05,
+		"Low":             0.1,
+		"Low to Moderate": 0.3,
+		"Moderate":        0.5,
+		"High":            1,
+	}
 )
 
 // AmazonNetworkMapper module object for handling amazon specific VM to Networking capabilities mapping
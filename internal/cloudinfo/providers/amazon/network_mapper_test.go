@@ -19,6 +19,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/banzaicloud/cloudinfo/internal/cloudinfo"
 	"github.com/banzaicloud/cloudinfo/internal/cloudinfo/types"
 )
 
@@ -55,3 +56,16 @@ func TestEc2NetworkMapper_MapNetworkPerf(t *testing.T) {
 		})
 	}
 }
+
+func TestEc2Infoer_NetworkBandwidths(t *testing.T) {
+	bandwidths := (&Ec2Infoer{}).NetworkBandwidths()
+
+	for category, values := range networkPerformanceCategories {
+		for _, value := range values {
+			assert.True(t, cloudinfo.ParseNetworkGbps(value, bandwidths) > 0, "the bandwidth of %q (%s) should be known", value, category)
+		}
+	}
+
+	assert.Equal(t, 25.0, cloudinfo.ParseNetworkGbps("Up to 25 Gigabit", bandwidths))
+	assert.Equal(t, 1.0, cloudinfo.ParseNetworkGbps("High", bandwidths))
+}
@@ -436,6 +436,11 @@ func (a *AzureInfoer) GetProducts(vms []types.VMInfo, service, regionId string)
 	}
 }
 
+// HasZones - Azure supports availability zones
+func (a *AzureInfoer) HasZones() bool {
+	return true
+}
+
 // GetZones returns the availability zones in a region
 // Zones are currently only returned by the SKU (https://docs.microsoft.com/en-us/rest/api/compute/resourceskus/list#resourceskulocationinfo)
 func (a *AzureInfoer) GetZones(region string) ([]string, error) {
@@ -548,6 +553,16 @@ func (a *AzureInfoer) HasShortLivedPriceInfo() bool {
 	return false
 }
 
+// HasReserved - Azure reserved prices are not supported
+func (a *AzureInfoer) HasReserved() bool {
+	return false
+}
+
+// PriceRegionService - Azure prices are scraped in the regions of the compute service
+func (a *AzureInfoer) PriceRegionService() string {
+	return cloudinfo.DefaultPriceRegionService
+}
+
 // GetCurrentPrices retrieves all the price info in a region
 func (a *AzureInfoer) GetCurrentPrices(region string) (map[string]types.Price, error) {
 	return nil, errors.New("azure prices cannot be queried on the fly")
@@ -568,6 +583,11 @@ func (a *AzureInfoer) GetServiceProducts(region, service string) ([]types.Produc
 	return nil, errors.New("GetServiceProducts - not yet implemented")
 }
 
+// HasVersions - Azure supports kubernetes versions
+func (a *AzureInfoer) HasVersions() bool {
+	return true
+}
+
 // GetVersions retrieves the kubernetes versions supported by the given service in the given region
 func (a *AzureInfoer) GetVersions(service, region string) ([]types.LocationVersion, error) {
 	switch service {
@@ -192,6 +192,10 @@ func (i *DigitaloceanInfoer) GetProducts(vms []types.VMInfo, service, regionId s
 	}
 }
 
+func (*DigitaloceanInfoer) HasZones() bool {
+	return false
+}
+
 func (*DigitaloceanInfoer) GetZones(region string) ([]string, error) {
 	return []string{}, nil
 }
@@ -239,6 +243,14 @@ func (*DigitaloceanInfoer) HasShortLivedPriceInfo() bool {
 	return false
 }
 
+func (*DigitaloceanInfoer) HasReserved() bool {
+	return false
+}
+
+func (*DigitaloceanInfoer) PriceRegionService() string {
+	return cloudinfo.DefaultPriceRegionService
+}
+
 func (*DigitaloceanInfoer) GetCurrentPrices(region string) (map[string]types.Price, error) {
 	return nil, errors.New("GetCurrentPrices - not yet implemented")
 }
@@ -251,6 +263,10 @@ func (*DigitaloceanInfoer) GetServiceImages(service, region string) ([]types.Ima
 	return nil, errors.New("GetServiceImages - not yet implemented")
 }
 
+func (*DigitaloceanInfoer) HasVersions() bool {
+	return true
+}
+
 func (i *DigitaloceanInfoer) GetVersions(service, region string) ([]types.LocationVersion, error) {
 	switch service {
 	case "dok":
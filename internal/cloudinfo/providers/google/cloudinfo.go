@@ -37,6 +37,13 @@ import (
 
 const svcGke = "gke"
 
+// commitmentTerms maps the usage types of the committed use SKUs to the terms the committed use prices are stored with
+// nolint: gochecknoglobals
+var commitmentTerms = map[string]string{
+	"Commit1Yr": "1yr-commitment",
+	"Commit3Yr": "3yr-commitment",
+}
+
 var regionNames = map[string]string{
 	"asia-east1":              "Asia Pacific (Taiwan)",
 	"asia-east2":              "Asia Pacific (Hong Kong)",
@@ -199,6 +206,8 @@ func (g *GceInfoer) Initialize() (map[string]map[string]types.Price, error) {
 							prices.OnDemandPrice = price[mt.Name]["OnDemand"]
 						} else {
 							prices.OnDemandPrice = price[types.CPU]["OnDemand"]*float64(mt.GuestCpus) + price[types.Memory]["OnDemand"]*float64(mt.MemoryMb)/1024
+							// shared core machine types are not eligible for committed use discounts
+							prices.ReservedPrice = committedUsePrices(price, mt.GuestCpus, mt.MemoryMb)
 						}
 						spotPrice := make(types.SpotPriceInfo)
 						for _, z := range zonesInRegions[region] {
@@ -244,41 +253,8 @@ func (g *GceInfoer) getPrice() (map[string]map[string]map[string]float64, error)
 	price := make(map[string]map[string]map[string]float64)
 	err = g.cbSvc.Services.Skus.List(compEngId).Pages(context.Background(), func(response *cloudbilling.ListSkusResponse) error {
 		for _, sku := range response.Skus {
-			if sku.Category.ResourceGroup == "G1Small" || sku.Category.ResourceGroup == "F1Micro" {
-				priceInUsd, err := g.priceInUsd(sku.PricingInfo)
-				if err != nil {
-					return err
-				}
-
-				for _, region := range sku.ServiceRegions {
-					if price[region] == nil {
-						price[region] = make(map[string]map[string]float64)
-					}
-					if sku.Category.ResourceGroup == "G1Small" {
-						price[region]["g1-small"] = g.priceFromSku(price, region, "g1-small", sku.Category.UsageType, priceInUsd)
-					} else {
-						price[region]["f1-micro"] = g.priceFromSku(price, region, "f1-micro", sku.Category.UsageType, priceInUsd)
-					}
-				}
-			}
-			if sku.Category.ResourceGroup == "N1Standard" {
-				if !strings.Contains(sku.Description, "Upgrade Premium") {
-					priceInUsd, err := g.priceInUsd(sku.PricingInfo)
-					if err != nil {
-						return err
-					}
-
-					for _, region := range sku.ServiceRegions {
-						if price[region] == nil {
-							price[region] = make(map[string]map[string]float64)
-						}
-						if strings.Contains(sku.Description, "Instance Ram") {
-							price[region][types.Memory] = g.priceFromSku(price, region, types.Memory, sku.Category.UsageType, priceInUsd)
-						} else {
-							price[region][types.CPU] = g.priceFromSku(price, region, types.CPU, sku.Category.UsageType, priceInUsd)
-						}
-					}
-				}
+			if err := g.addSkuPrice(price, sku); err != nil {
+				return err
 			}
 		}
 		return nil
@@ -289,6 +265,94 @@ func (g *GceInfoer) getPrice() (map[string]map[string]map[string]float64, error)
 	return price, nil
 }
 
+// addSkuPrice adds the price of the SKU to the prices keyed by region, device and usage type
+// SKUs not related to the supported instance types are skipped
+func (g *GceInfoer) addSkuPrice(price map[string]map[string]map[string]float64, sku *cloudbilling.Sku) error {
+	if sku.Category.ResourceGroup == "G1Small" || sku.Category.ResourceGroup == "F1Micro" {
+		priceInUsd, err := g.priceInUsd(sku.PricingInfo)
+		if err != nil {
+			return err
+		}
+
+		for _, region := range sku.ServiceRegions {
+			if price[region] == nil {
+				price[region] = make(map[string]map[string]float64)
+			}
+			if sku.Category.ResourceGroup == "G1Small" {
+				price[region]["g1-small"] = g.priceFromSku(price, region, "g1-small", sku.Category.UsageType, priceInUsd)
+			} else {
+				price[region]["f1-micro"] = g.priceFromSku(price, region, "f1-micro", sku.Category.UsageType, priceInUsd)
+			}
+		}
+	}
+	if sku.Category.ResourceGroup == "N1Standard" {
+		if !strings.Contains(sku.Description, "Upgrade Premium") {
+			priceInUsd, err := g.priceInUsd(sku.PricingInfo)
+			if err != nil {
+				return err
+			}
+
+			for _, region := range sku.ServiceRegions {
+				if price[region] == nil {
+					price[region] = make(map[string]map[string]float64)
+				}
+				if strings.Contains(sku.Description, "Instance Ram") {
+					price[region][types.Memory] = g.priceFromSku(price, region, types.Memory, sku.Category.UsageType, priceInUsd)
+				} else {
+					price[region][types.CPU] = g.priceFromSku(price, region, types.CPU, sku.Category.UsageType, priceInUsd)
+				}
+			}
+		}
+	}
+	// the N1 commitments are billed per cpu and memory, eg.: "Commitment v1: Cpu in Americas for 1 Year"
+	// other machine families are listed with their names, eg.: "Commitment v1: N2 Cpu in Americas for 1 Year"
+	if _, ok := commitmentTerms[sku.Category.UsageType]; ok {
+		var device string
+		switch {
+		case strings.HasPrefix(sku.Description, "Commitment v1: Cpu in "):
+			device = types.CPU
+		case strings.HasPrefix(sku.Description, "Commitment v1: Ram in "):
+			device = types.Memory
+		default:
+			return nil
+		}
+
+		priceInUsd, err := g.priceInUsd(sku.PricingInfo)
+		if err != nil {
+			return err
+		}
+
+		for _, region := range sku.ServiceRegions {
+			if price[region] == nil {
+				price[region] = make(map[string]map[string]float64)
+			}
+			price[region][device] = g.priceFromSku(price, region, device, sku.Category.UsageType, priceInUsd)
+		}
+	}
+
+	return nil
+}
+
+// committedUsePrices calculates the committed use prices of the machine type keyed by term from the cpu and memory commitment prices
+// nil is returned if there are no commitment prices
+func committedUsePrices(price map[string]map[string]float64, cpus, memoryMb int64) map[string]float64 {
+	var reserved map[string]float64
+	for usageType, term := range commitmentTerms {
+		cpuPrice, cpuOk := price[types.CPU][usageType]
+		memPrice, memOk := price[types.Memory][usageType]
+		if !cpuOk || !memOk {
+			continue
+		}
+
+		if reserved == nil {
+			reserved = make(map[string]float64)
+		}
+		reserved[term] = cpuPrice*float64(cpus) + memPrice*float64(memoryMb)/1024
+	}
+
+	return reserved
+}
+
 func (g *GceInfoer) priceInUsd(pricingInfos []*cloudbilling.PricingInfo) (float64, error) {
 	if len(pricingInfos) != 1 {
 		return 0, emperror.With(errors.New("pricing info not parsable"), "numberOfPricingInfos", len(pricingInfos))
@@ -419,6 +483,11 @@ func (g *GceInfoer) GetRegions(service string) (map[string]string, error) {
 	return regionIdMap, nil
 }
 
+// HasZones - Google supports availability zones
+func (g *GceInfoer) HasZones() bool {
+	return true
+}
+
 // GetZones returns the availability zones in a region
 func (g *GceInfoer) GetZones(region string) ([]string, error) {
 	logger := log.WithFields(g.log, map[string]interface{}{"region": region})
@@ -447,6 +516,16 @@ func (g *GceInfoer) HasShortLivedPriceInfo() bool {
 	return false
 }
 
+// HasReserved - Google committed use prices are retrieved along with the on demand prices, not scraped separately
+func (g *GceInfoer) HasReserved() bool {
+	return false
+}
+
+// PriceRegionService - Google Cloud prices are scraped in the regions of the compute service
+func (g *GceInfoer) PriceRegionService() string {
+	return cloudinfo.DefaultPriceRegionService
+}
+
 // GetCurrentPrices retrieves all the spot prices in a region
 func (g *GceInfoer) GetCurrentPrices(region string) (map[string]types.Price, error) {
 	return nil, errors.New("google prices cannot be queried on the fly")
@@ -467,6 +546,11 @@ func (g *GceInfoer) GetServiceProducts(region, service string) ([]types.ProductD
 	return nil, errors.New("GetServiceProducts - not yet implemented")
 }
 
+// HasVersions - Google supports kubernetes versions
+func (g *GceInfoer) HasVersions() bool {
+	return true
+}
+
 // GetVersions retrieves the kubernetes versions supported by the given service in the given region
 func (g *GceInfoer) GetVersions(service, region string) ([]types.LocationVersion, error) {
 	switch service {
@@ -0,0 +1,85 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package google
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/cloudbilling/v1"
+
+	"github.com/banzaicloud/cloudinfo/internal/cloudinfo/types"
+)
+
+// testSku creates a SKU priced with the given nanos per unit in the us-central1 region
+func testSku(resourceGroup, usageType, description string, nanos int64) *cloudbilling.Sku {
+	return &cloudbilling.Sku{
+		Category:       &cloudbilling.Category{ResourceFamily: "Compute", ResourceGroup: resourceGroup, UsageType: usageType},
+		Description:    description,
+		ServiceRegions: []string{"us-central1"},
+		PricingInfo: []*cloudbilling.PricingInfo{{
+			PricingExpression: &cloudbilling.PricingExpression{
+				TieredRates: []*cloudbilling.TierRate{{UnitPrice: &cloudbilling.Money{CurrencyCode: "USD", Nanos: nanos}}},
+			},
+		}},
+	}
+}
+
+func TestGceInfoer_addSkuPrice(t *testing.T) {
+	skus := []*cloudbilling.Sku{
+		testSku("N1Standard", "OnDemand", "N1 Predefined Instance Core running in Americas", 31611000),
+		testSku("N1Standard", "OnDemand", "N1 Predefined Instance Ram running in Americas", 4237000),
+		testSku("N1Standard", "Preemptible", "Preemptible N1 Predefined Instance Core running in Americas", 6655000),
+		testSku("N1Standard", "Preemptible", "Preemptible N1 Predefined Instance Ram running in Americas", 892000),
+		testSku("CPU", "Commit1Yr", "Commitment v1: Cpu in Americas for 1 Year", 19915000),
+		testSku("RAM", "Commit1Yr", "Commitment v1: Ram in Americas for 1 Year", 2669000),
+		testSku("CPU", "Commit3Yr", "Commitment v1: Cpu in Americas for 3 Years", 14225000),
+		testSku("RAM", "Commit3Yr", "Commitment v1: Ram in Americas for 3 Years", 1907000),
+		// not related to the supported machine types
+		testSku("CPU", "Commit1Yr", "Commitment v1: N2 Cpu in Americas for 1 Year", 20000000),
+		testSku("GPU", "Commit1Yr", "Commitment v1: Nvidia Tesla K80 GPU in Americas for 1 Year", 280000000),
+		testSku("F1Micro", "OnDemand", "Micro Instance with burstable CPU running in Americas", 7600000),
+	}
+
+	g := &GceInfoer{}
+	price := make(map[string]map[string]map[string]float64)
+	for _, sku := range skus {
+		assert.Nil(t, g.addSkuPrice(price, sku), "the error should be nil")
+	}
+
+	regionPrice := price["us-central1"]
+	assert.InDelta(t, 0.031611, regionPrice[types.CPU]["OnDemand"], 1e-9)
+	assert.InDelta(t, 0.019915, regionPrice[types.CPU]["Commit1Yr"], 1e-9, "the N2 commitment should not override the N1 one")
+	assert.InDelta(t, 0.014225, regionPrice[types.CPU]["Commit3Yr"], 1e-9)
+	assert.InDelta(t, 0.002669, regionPrice[types.Memory]["Commit1Yr"], 1e-9)
+	assert.InDelta(t, 0.001907, regionPrice[types.Memory]["Commit3Yr"], 1e-9)
+	assert.InDelta(t, 0.0076, regionPrice["f1-micro"]["OnDemand"], 1e-9)
+	assert.Equal(t, 3, len(regionPrice), "only the supported devices should be priced")
+
+	// n1-standard-2: 2 cpus, 7.5 GB memory
+	reserved := committedUsePrices(regionPrice, 2, 7680)
+	assert.Equal(t, 2, len(reserved), "both commitment terms should be populated")
+	assert.InDelta(t, 2*0.019915+7.5*0.002669, reserved["1yr-commitment"], 1e-9)
+	assert.InDelta(t, 2*0.014225+7.5*0.001907, reserved["3yr-commitment"], 1e-9)
+}
+
+func TestCommittedUsePrices_noCommitment(t *testing.T) {
+	price := map[string]map[string]float64{
+		types.CPU:    {"OnDemand": 0.031611, "Commit1Yr": 0.019915},
+		types.Memory: {"OnDemand": 0.004237},
+	}
+
+	assert.Nil(t, committedUsePrices(price, 2, 7680), "the terms without cpu and memory commitment prices should be skipped")
+}
@@ -16,6 +16,7 @@ package oracle
 
 import (
 	"fmt"
+	"strings"
 
 	"emperror.dev/emperror"
 	"emperror.dev/errors"
@@ -266,8 +267,17 @@ func (i *Infoer) GetRegions(service string) (map[string]string, error) {
 		return nil, err
 	}
 
-	regions := make(map[string]string)
-	for _, region := range subscribedRegionNames {
+	regions := describeRegions(subscribedRegionNames)
+
+	logger.Debug("found regions", map[string]interface{}{"numberOfRegions": len(regions)})
+	return regions, nil
+}
+
+// describeRegions maps the subscribed OCI regions (keyed by identifier) to their display names,
+// unknown regions are described by their identifier
+func describeRegions(subscribedRegions map[string]string) map[string]string {
+	regions := make(map[string]string, len(subscribedRegions))
+	for region := range subscribedRegions {
 		description := region
 		if displayName, ok := regionNames[region]; ok {
 			description = displayName
@@ -275,8 +285,12 @@ func (i *Infoer) GetRegions(service string) (map[string]string, error) {
 		regions[region] = description
 	}
 
-	logger.Debug("found regions", map[string]interface{}{"numberOfRegions": len(regions)})
-	return regions, nil
+	return regions
+}
+
+// HasZones - Oracle supports availability zones
+func (i *Infoer) HasZones() bool {
+	return true
 }
 
 // GetZones returns the availability zones in a region
@@ -309,6 +323,16 @@ func (i *Infoer) HasShortLivedPriceInfo() bool {
 	return false
 }
 
+// HasReserved - Oracle reserved prices are not supported
+func (i *Infoer) HasReserved() bool {
+	return false
+}
+
+// PriceRegionService - Oracle prices are scraped in the regions of the compute service
+func (i *Infoer) PriceRegionService() string {
+	return cloudinfo.DefaultPriceRegionService
+}
+
 // HasImages - Oracle support images
 func (i *Infoer) HasImages() bool {
 	return true
@@ -323,7 +347,13 @@ func (i *Infoer) GetServiceImages(service, region string) ([]types.Image, error)
 
 	var images = make([]types.Image, 0, len(imageNames))
 	for _, imageName := range imageNames {
-		images = append(images, types.NewImage(imageName, "", false))
+		image := types.NewImage(imageName, "", false)
+		// the image names are made of the operating system and its version, eg.: "Oracle Linux 7.6"
+		if i := strings.LastIndex(imageName, " "); i > 0 {
+			image.Os = imageName[:i]
+			image.OsVersion = imageName[i+1:]
+		}
+		images = append(images, image)
 	}
 
 	return images, nil
@@ -334,6 +364,11 @@ func (i *Infoer) GetServiceProducts(region, service string) ([]types.ProductDeta
 	return nil, errors.New("GetServiceProducts - not yet implemented")
 }
 
+// HasVersions - Oracle supports kubernetes versions
+func (i *Infoer) HasVersions() bool {
+	return true
+}
+
 // GetVersions retrieves the kubernetes versions supported by the given service in the given region
 func (i *Infoer) GetVersions(service, region string) ([]types.LocationVersion, error) {
 	switch service {
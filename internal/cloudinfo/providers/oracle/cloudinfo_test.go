@@ -0,0 +1,136 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oracle
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"logur.dev/logur"
+
+	"github.com/banzaicloud/cloudinfo/internal/cloudinfo/cloudinfoadapter"
+	"github.com/banzaicloud/cloudinfo/internal/cloudinfo/types"
+)
+
+func TestDescribeRegions(t *testing.T) {
+	tests := []struct {
+		name              string
+		subscribedRegions map[string]string
+		check             func(regions map[string]string)
+	}{
+		{
+			name:              "known regions are described by their display name",
+			subscribedRegions: map[string]string{"eu-frankfurt-1": "eu-frankfurt-1", "us-ashburn-1": "us-ashburn-1"},
+			check: func(regions map[string]string) {
+				assert.Equal(t, map[string]string{"eu-frankfurt-1": "EU (Frankfurt)", "us-ashburn-1": "US East (Ashburn)"}, regions)
+			},
+		},
+		{
+			name:              "unknown regions are described by their identifier",
+			subscribedRegions: map[string]string{"ap-tokyo-1": "ap-tokyo-1"},
+			check: func(regions map[string]string) {
+				assert.Equal(t, map[string]string{"ap-tokyo-1": "ap-tokyo-1"}, regions)
+			},
+		},
+		{
+			name:              "no subscribed regions",
+			subscribedRegions: nil,
+			check: func(regions map[string]string) {
+				assert.Empty(t, regions)
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.check(describeRegions(test.subscribedRegions))
+		})
+	}
+}
+
+func TestInfoer_GetProductPrice(t *testing.T) {
+	tests := []struct {
+		name      string
+		cache     map[string]ITRACloudInfo
+		shapeSpec ShapeSpecs
+		check     func(price float64, err error)
+	}{
+		{
+			name: "the pay as you go price is multiplied by the number of OCPUs",
+			cache: map[string]ITRACloudInfo{
+				"B88514": {PartNumber: "B88514", Prices: []ITRAPriceInfo{{Model: "MONTHLY_COMMIT", Value: 0.05}, {Model: "PAY_AS_YOU_GO", Value: 0.0638}}},
+			},
+			shapeSpec: shapeSpecs["VM.Standard2.4"],
+			check: func(price float64, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.InDelta(t, 0.2552, price, 1e-9)
+			},
+		},
+		{
+			name: "missing pay as you go price",
+			cache: map[string]ITRACloudInfo{
+				"B88317": {PartNumber: "B88317", Prices: []ITRAPriceInfo{{Model: "MONTHLY_COMMIT", Value: 0.05}}},
+			},
+			shapeSpec: shapeSpecs["VM.Standard1.2"],
+			check: func(price float64, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, float64(0), price)
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			infoer := &Infoer{
+				shapeSpecs:     shapeSpecs,
+				cloudInfoCache: test.cache,
+				log:            cloudinfoadapter.NewLogger(&logur.TestLogger{}),
+			}
+			test.check(infoer.GetProductPrice(test.shapeSpec))
+		})
+	}
+}
+
+func TestOCINetworkMapper_MapNetworkPerf(t *testing.T) {
+	tests := []struct {
+		name  string
+		shape string
+		check func(cat string, err error)
+	}{
+		{
+			name:  "low network performance",
+			shape: "VM.Standard.E2.1",
+			check: func(cat string, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, types.NtwLow, cat)
+			},
+		},
+		{
+			name:  "extra network performance",
+			shape: "VM.DenseIO2.24",
+			check: func(cat string, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, types.NtwExtra, cat)
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.check(newNetworkMapper().MapNetworkPerf(shapeSpecs[test.shape].NtwPerf))
+		})
+	}
+}
+
+func TestInfoer_HasShortLivedPriceInfo(t *testing.T) {
+	assert.False(t, (&Infoer{}).HasShortLivedPriceInfo(), "oracle has no spot prices")
+}
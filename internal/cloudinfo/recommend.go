@@ -0,0 +1,119 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudinfo
+
+import (
+	"sort"
+
+	"emperror.dev/errors"
+
+	"github.com/banzaicloud/cloudinfo/internal/cloudinfo/types"
+)
+
+// Requirement holds the minimum specs of the recommended products and the weights of the attributes they're scored by
+// Zero valued minimums are considered unbounded, attributes with zero weight don't contribute to the score
+type Requirement struct {
+	MinCpu float64
+	MinMem float64
+
+	// PriceWeight the weight of the on demand price, cheaper products score higher
+	PriceWeight float64
+	// CpuWeight the weight of the cpu count, products with more cpus score higher
+	CpuWeight float64
+	// MemWeight the weight of the memory, products with more memory score higher
+	MemWeight float64
+}
+
+// ScoredProduct is a product along with its score computed for a requirement
+type ScoredProduct struct {
+	types.ProductDetails
+
+	// Score the weighted score of the product between 0 and 1
+	Score float64 `json:"score"`
+}
+
+// RecommendProducts ranks the products satisfying the minimum specs of the requirement by their weighted score
+// every attribute is normalized to the [0, 1] range over the candidates; products without on demand price are excluded
+func (cpi *cloudInfo) RecommendProducts(provider, service, region string, req Requirement) ([]ScoredProduct, error) {
+	totalWeight := req.PriceWeight + req.CpuWeight + req.MemWeight
+	if req.PriceWeight < 0 || req.CpuWeight < 0 || req.MemWeight < 0 || totalWeight == 0 {
+		return nil, errors.NewWithDetails("invalid requirement weights", "price", req.PriceWeight, "cpu", req.CpuWeight, "mem", req.MemWeight)
+	}
+
+	details, err := cpi.FilterProductDetails(provider, service, region, ProductFilter{MinCpu: req.MinCpu, MinMem: req.MinMem})
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make([]types.ProductDetails, 0, len(details))
+	for _, pd := range details {
+		if pd.OnDemandPrice > 0 {
+			candidates = append(candidates, pd)
+		}
+	}
+
+	price := newAttrRange(candidates, func(pd types.ProductDetails) float64 { return pd.OnDemandPrice })
+	cpu := newAttrRange(candidates, func(pd types.ProductDetails) float64 { return pd.Cpus })
+	mem := newAttrRange(candidates, func(pd types.ProductDetails) float64 { return pd.Mem })
+
+	scored := make([]ScoredProduct, 0, len(candidates))
+	for _, pd := range candidates {
+		score := req.PriceWeight*(1-price.normalize(pd.OnDemandPrice)) +
+			req.CpuWeight*cpu.normalize(pd.Cpus) +
+			req.MemWeight*mem.normalize(pd.Mem)
+
+		scored = append(scored, ScoredProduct{ProductDetails: pd, Score: score / totalWeight})
+	}
+
+	// equally scored products are ordered by price
+	sort.SliceStable(scored, func(i, j int) bool {
+		if scored[i].Score != scored[j].Score {
+			return scored[i].Score > scored[j].Score
+		}
+		return scored[i].OnDemandPrice < scored[j].OnDemandPrice
+	})
+
+	return scored, nil
+}
+
+// attrRange holds the bounds of an attribute over a set of products
+type attrRange struct {
+	min float64
+	max float64
+}
+
+func newAttrRange(details []types.ProductDetails, value func(pd types.ProductDetails) float64) attrRange {
+	var r attrRange
+	for i, pd := range details {
+		v := value(pd)
+		if i == 0 || v < r.min {
+			r.min = v
+		}
+		if i == 0 || v > r.max {
+			r.max = v
+		}
+	}
+
+	return r
+}
+
+// normalize maps the value into the [0, 1] range, every value is mapped to 1 if the range is empty
+func (r attrRange) normalize(v float64) float64 {
+	if r.max == r.min {
+		return 1
+	}
+
+	return (v - r.min) / (r.max - r.min)
+}
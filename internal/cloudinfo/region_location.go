@@ -0,0 +1,164 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudinfo
+
+import (
+	"math"
+	"sort"
+
+	"github.com/banzaicloud/cloudinfo/internal/cloudinfo/types"
+)
+
+// earthRadiusKm the mean radius of the Earth in kilometers
+const earthRadiusKm = 6371.0
+
+// coordinates the approximate geographic location of a region
+type coordinates struct {
+	lat float64
+	lng float64
+}
+
+// regionCoordinates the approximate location of the regions (the city the data centers are in or next to) per provider
+// nolint: gochecknoglobals
+var regionCoordinates = map[string]map[string]coordinates{
+	"amazon": {
+		"us-east-1":      {38.13, -78.45},
+		"us-east-2":      {39.96, -83.00},
+		"us-west-1":      {37.35, -121.96},
+		"us-west-2":      {46.15, -123.88},
+		"ca-central-1":   {45.50, -73.57},
+		"sa-east-1":      {-23.55, -46.63},
+		"eu-west-1":      {53.35, -6.26},
+		"eu-west-2":      {51.51, -0.13},
+		"eu-west-3":      {48.86, 2.35},
+		"eu-central-1":   {50.11, 8.68},
+		"eu-north-1":     {59.33, 18.07},
+		"eu-south-1":     {45.46, 9.19},
+		"ap-east-1":      {22.32, 114.17},
+		"ap-south-1":     {19.08, 72.88},
+		"ap-northeast-1": {35.68, 139.69},
+		"ap-northeast-2": {37.57, 126.98},
+		"ap-northeast-3": {34.69, 135.50},
+		"ap-southeast-1": {1.35, 103.82},
+		"ap-southeast-2": {-33.87, 151.21},
+		"me-south-1":     {26.07, 50.56},
+		"af-south-1":     {-33.92, 18.42},
+	},
+	"google": {
+		"us-central1":             {41.26, -95.86},
+		"us-east1":                {33.20, -80.01},
+		"us-east4":                {39.04, -77.49},
+		"us-west1":                {45.60, -121.18},
+		"us-west2":                {34.05, -118.24},
+		"us-west3":                {40.76, -111.89},
+		"us-west4":                {36.17, -115.14},
+		"northamerica-northeast1": {45.50, -73.57},
+		"southamerica-east1":      {-23.55, -46.63},
+		"europe-north1":           {60.57, 27.19},
+		"europe-west1":            {50.45, 3.82},
+		"europe-west2":            {51.51, -0.13},
+		"europe-west3":            {50.11, 8.68},
+		"europe-west4":            {53.44, 6.84},
+		"europe-west6":            {47.38, 8.54},
+		"asia-east1":              {24.05, 120.52},
+		"asia-east2":              {22.32, 114.17},
+		"asia-northeast1":         {35.68, 139.69},
+		"asia-northeast2":         {34.69, 135.50},
+		"asia-northeast3":         {37.57, 126.98},
+		"asia-south1":             {19.08, 72.88},
+		"asia-southeast1":         {1.35, 103.82},
+		"australia-southeast1":    {-33.87, 151.21},
+	},
+	"azure": {
+		"eastus":             {37.37, -79.82},
+		"eastus2":            {36.67, -78.39},
+		"centralus":          {41.59, -93.60},
+		"northcentralus":     {41.88, -87.63},
+		"southcentralus":     {29.42, -98.49},
+		"westus":             {37.78, -122.42},
+		"westus2":            {47.23, -119.85},
+		"canadacentral":      {43.65, -79.38},
+		"brazilsouth":        {-23.55, -46.63},
+		"northeurope":        {53.35, -6.26},
+		"westeurope":         {52.37, 4.90},
+		"uksouth":            {51.51, -0.13},
+		"francecentral":      {46.38, 2.37},
+		"germanywestcentral": {50.11, 8.68},
+		"switzerlandnorth":   {47.45, 8.56},
+		"norwayeast":         {59.91, 10.75},
+		"eastasia":           {22.27, 114.19},
+		"southeastasia":      {1.28, 103.83},
+		"japaneast":          {35.68, 139.77},
+		"koreacentral":       {37.57, 126.98},
+		"centralindia":       {18.58, 73.92},
+		"australiaeast":      {-33.86, 151.21},
+		"southafricanorth":   {-25.73, 28.22},
+		"uaenorth":           {25.27, 55.30},
+	},
+}
+
+// regionInfos assembles the location information of the regions of the provider ordered by region id
+// regions missing from the built-in mapping have zero coordinates
+func regionInfos(provider string, regions map[string]string) []types.RegionInfo {
+	infos := make([]types.RegionInfo, 0, len(regions))
+	for id, name := range regions {
+		location := regionCoordinates[provider][id]
+		infos = append(infos, types.RegionInfo{
+			ID:        id,
+			Name:      name,
+			Continent: getContinent(id),
+			Lat:       location.lat,
+			Lng:       location.lng,
+		})
+	}
+
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].ID < infos[j].ID
+	})
+
+	return infos
+}
+
+// nearestRegion returns the region closest to the location, regions missing from the built-in mapping are not considered
+// false is returned if none of the regions has a known location
+func nearestRegion(provider string, regions map[string]string, lat, lng float64) (types.RegionInfo, bool) {
+	var (
+		nearest  types.RegionInfo
+		distance = math.Inf(1)
+		found    bool
+	)
+	for _, info := range regionInfos(provider, regions) {
+		if _, ok := regionCoordinates[provider][info.ID]; !ok {
+			continue
+		}
+
+		if d := greatCircleDistance(lat, lng, info.Lat, info.Lng); d < distance {
+			nearest, distance, found = info, d, true
+		}
+	}
+
+	return nearest, found
+}
+
+// greatCircleDistance calculates the distance of two locations on the surface of the Earth in kilometers (haversine formula)
+func greatCircleDistance(lat1, lng1, lat2, lng2 float64) float64 {
+	toRadians := func(deg float64) float64 { return deg * math.Pi / 180 }
+
+	dLat := toRadians(lat2 - lat1)
+	dLng := toRadians(lng2 - lng1)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(toRadians(lat1))*math.Cos(toRadians(lat2))*math.Sin(dLng/2)*math.Sin(dLng/2)
+
+	return 2 * earthRadiusKm * math.Asin(math.Sqrt(a))
+}
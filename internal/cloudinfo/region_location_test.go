@@ -0,0 +1,110 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudinfo
+
+import (
+	"testing"
+
+	"emperror.dev/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/banzaicloud/cloudinfo/internal/cloudinfo/types"
+)
+
+func TestRegionInfos(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider string
+		regions  map[string]string
+		checker  func(infos []types.RegionInfo)
+	}{
+		{
+			name:     "known aws regions resolve to their continent",
+			provider: "amazon",
+			regions: map[string]string{
+				"us-east-1":      "US East (N. Virginia)",
+				"eu-west-1":      "EU (Ireland)",
+				"ap-southeast-2": "Asia Pacific (Sydney)",
+			},
+			checker: func(infos []types.RegionInfo) {
+				assert.Equal(t, 3, len(infos))
+				assert.Equal(t, types.RegionInfo{ID: "ap-southeast-2", Name: "Asia Pacific (Sydney)", Continent: types.ContinentAustralia, Lat: -33.87, Lng: 151.21}, infos[0])
+				assert.Equal(t, types.RegionInfo{ID: "eu-west-1", Name: "EU (Ireland)", Continent: types.ContinentEurope, Lat: 53.35, Lng: -6.26}, infos[1])
+				assert.Equal(t, types.RegionInfo{ID: "us-east-1", Name: "US East (N. Virginia)", Continent: types.ContinentNorthAmerica, Lat: 38.13, Lng: -78.45}, infos[2])
+			},
+		},
+		{
+			name:     "regions missing from the mapping have no coordinates",
+			provider: "amazon",
+			regions:  map[string]string{"eu-dummy-1": "EU (Dummy)"},
+			checker: func(infos []types.RegionInfo) {
+				assert.Equal(t, []types.RegionInfo{{ID: "eu-dummy-1", Name: "EU (Dummy)", Continent: types.ContinentEurope}}, infos)
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.checker(regionInfos(test.provider, test.regions))
+		})
+	}
+}
+
+func TestCachingCloudInfo_FindNearestRegion(t *testing.T) {
+	tests := []struct {
+		name     string
+		regions  map[string]string
+		lat, lng float64
+		checker  func(region types.RegionInfo, err error)
+	}{
+		{
+			name: "location near Frankfurt",
+			regions: map[string]string{
+				"eu-central-1": "EU (Frankfurt)",
+				"eu-west-1":    "EU (Ireland)",
+				"us-east-1":    "US East (N. Virginia)",
+			},
+			lat: 50.04,
+			lng: 8.56,
+			checker: func(region types.RegionInfo, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, "eu-central-1", region.ID)
+			},
+		},
+		{
+			name:    "regions with unknown location",
+			regions: map[string]string{"eu-dummy-1": "EU (Dummy)"},
+			lat:     50.04,
+			lng:     8.56,
+			checker: func(region types.RegionInfo, err error) {
+				assert.True(t, errors.Is(err, ErrRegionLocationUnknown), "the error should signal the unknown location")
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			store := newTestCloudInfoStore()
+			store.StoreRegions("amazon", "compute", test.regions)
+			info, _ := NewCloudInfo([]string{"amazon"}, store, cloudinfoLogger)
+
+			test.checker(info.FindNearestRegion("amazon", "compute", test.lat, test.lng))
+		})
+	}
+}
+
+func TestGreatCircleDistance(t *testing.T) {
+	// Frankfurt - London is about 640 km
+	assert.InDelta(t, 640, greatCircleDistance(50.11, 8.68, 51.51, -0.13), 10)
+	assert.Equal(t, 0.0, greatCircleDistance(50.11, 8.68, 50.11, 8.68))
+}
@@ -0,0 +1,103 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudinfo
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"emperror.dev/errors"
+)
+
+// RetryableError is implemented by errors infoers consider transient (eg.: API throttling)
+// Calls failing with such errors are retried by the scraping manager
+type RetryableError interface {
+	error
+
+	// Retryable checks whether the failed call can be retried
+	Retryable() bool
+}
+
+// isRetryable checks whether any error in the chain is marked as retryable
+func isRetryable(err error) bool {
+	var retryableErr RetryableError
+
+	return errors.As(err, &retryableErr) && retryableErr.Retryable()
+}
+
+// call calls the function, giving up on it if it doesn't return within the call timeout
+// infoer calls are not context aware, so a timed out call is not cancelled: it keeps running in the background
+// (holding its connections and counting against the API quota of the provider) until the infoer returns,
+// and its result is discarded; a retry may therefore overlap with the abandoned call
+func (sm *scrapingManager) call(ctx context.Context, operation string, fn func() error) error {
+	if sm.callTimeout <= 0 {
+		return fn()
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, sm.callTimeout)
+	defer cancel()
+
+	// buffered, so that the call can complete after the timeout
+	done := make(chan error, 1)
+	go func() {
+		done <- fn()
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return errors.WrapIfWithDetails(ctx.Err(), "provider call timed out", "operation", operation, "timeout", sm.callTimeout)
+	}
+}
+
+// wait blocks until the rate limit of the provider allows calling the infoer
+func (sm *scrapingManager) wait(ctx context.Context, operation string) error {
+	if sm.limiter == nil {
+		return nil
+	}
+
+	return errors.WrapIfWithDetails(sm.limiter.Wait(ctx), "failed to wait for the rate limiter", "operation", operation)
+}
+
+// retry calls the function until it succeeds, fails with a non retryable error or the attempts are exhausted
+// the delay between attempts is doubled each time, with jitter applied to it
+func (sm *scrapingManager) retry(ctx context.Context, operation string, fn func() error) error {
+	delay := sm.retryBaseDelay
+
+	for attempt := 1; ; attempt++ {
+		if err := sm.wait(ctx, operation); err != nil {
+			return err
+		}
+
+		err := sm.call(ctx, operation, fn)
+		if err == nil || !isRetryable(err) || attempt >= sm.retryMaxAttempts {
+			return err
+		}
+
+		// wait between half and the full delay
+		wait := delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+		sm.log.Debug("retrying failed call", map[string]interface{}{"operation": operation, "attempt": attempt, "delay": wait})
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(wait):
+		}
+
+		delay *= 2
+	}
+}
@@ -0,0 +1,136 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudinfo
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+
+	"emperror.dev/errors"
+
+	"github.com/banzaicloud/cloudinfo/internal/cloudinfo/types"
+)
+
+// jsonSchemaDraft the JSON Schema version the generated schemas conform to
+const jsonSchemaDraft = "http://json-schema.org/draft-07/schema#"
+
+// ProductDetailsJSONSchema returns the JSON Schema describing the serialized form of the product details
+// the schema is generated from the json tags of the structs, fields tagged with omitempty are not required
+func ProductDetailsJSONSchema() ([]byte, error) {
+	schema, err := jsonSchema(reflect.TypeOf(types.ProductDetails{}))
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to generate the product details schema")
+	}
+
+	schema["$schema"] = jsonSchemaDraft
+	schema["title"] = "ProductDetails"
+
+	return json.MarshalIndent(schema, "", "  ")
+}
+
+// nolint: gochecknoglobals
+var timeType = reflect.TypeOf(time.Time{})
+
+// jsonSchema returns the schema of the type
+func jsonSchema(t reflect.Type) (map[string]interface{}, error) {
+	if t == timeType {
+		return map[string]interface{}{"type": "string", "format": "date-time"}, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		return jsonSchema(t.Elem())
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}, nil
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}, nil
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}, nil
+	case reflect.Slice, reflect.Array:
+		items, err := jsonSchema(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"type": "array", "items": items}, nil
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			return nil, errors.NewWithDetails("unsupported map key type", "type", t.String())
+		}
+		values, err := jsonSchema(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"type": "object", "additionalProperties": values}, nil
+	case reflect.Struct:
+		properties := make(map[string]interface{})
+		required := make([]string, 0)
+		if err := addStructProperties(t, properties, &required); err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"type": "object", "properties": properties, "required": required}, nil
+	default:
+		return nil, errors.NewWithDetails("unsupported type", "type", t.String())
+	}
+}
+
+// addStructProperties adds the serialized fields of the struct to the properties, the fields of embedded structs are inlined
+func addStructProperties(t reflect.Type, properties map[string]interface{}, required *[]string) error {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		name, opts := tag, ""
+		if idx := strings.Index(tag, ","); idx >= 0 {
+			name, opts = tag[:idx], tag[idx+1:]
+		}
+
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			if err := addStructProperties(field.Type, properties, required); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if field.PkgPath != "" {
+			// unexported fields are not serialized
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		schema, err := jsonSchema(field.Type)
+		if err != nil {
+			return errors.WithDetails(err, "field", field.Name)
+		}
+
+		properties[name] = schema
+		if !strings.Contains(opts, "omitempty") {
+			*required = append(*required, name)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudinfo
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProductDetailsJSONSchema(t *testing.T) {
+	raw, err := ProductDetailsJSONSchema()
+	assert.Nil(t, err, "the error should be nil")
+
+	var schema struct {
+		Schema     string                            `json:"$schema"`
+		Type       string                            `json:"type"`
+		Properties map[string]map[string]interface{} `json:"properties"`
+		Required   []string                          `json:"required"`
+	}
+	assert.Nil(t, json.Unmarshal(raw, &schema), "the schema should parse")
+
+	assert.Equal(t, jsonSchemaDraft, schema.Schema)
+	assert.Equal(t, "object", schema.Type)
+
+	assert.Equal(t, map[string]interface{}{"type": "number"}, schema.Properties["onDemandPrice"])
+	assert.Contains(t, schema.Required, "onDemandPrice")
+
+	// the zone prices of the spot price
+	assert.Equal(t, map[string]interface{}{
+		"type": "array",
+		"items": map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"zone":  map[string]interface{}{"type": "string"},
+				"price": map[string]interface{}{"type": "number"},
+			},
+			"required": []interface{}{"zone", "price"},
+		},
+	}, schema.Properties["spotPrice"])
+
+	// the per zone prices holding the spot prices per zone
+	zonePrices := schema.Properties["zonePrices"]
+	assert.Equal(t, "object", zonePrices["type"])
+	price := zonePrices["additionalProperties"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"type": "object", "additionalProperties": map[string]interface{}{"type": "number"}},
+		price["properties"].(map[string]interface{})["spotPrice"])
+
+	assert.NotContains(t, schema.Required, "zonePrices", "omitempty fields should not be required")
+	assert.NotContains(t, schema.Properties, "VMInfo", "the embedded struct should be inlined")
+}
@@ -17,11 +17,15 @@ package cloudinfo
 import (
 	"context"
 	"fmt"
+	"math/rand"
+	"sort"
 	"strconv"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"emperror.dev/errors"
+	"golang.org/x/time/rate"
 
 	"github.com/banzaicloud/cloudinfo/internal/app/cloudinfo/messaging"
 	"github.com/banzaicloud/cloudinfo/internal/app/cloudinfo/tracing"
@@ -33,38 +37,174 @@ import (
 // scrapingManager manages data renewal for a given provider
 // retrieves data from the cloud provider and stores it in the store
 type scrapingManager struct {
-	provider     string
-	infoer       CloudInfoer
+	provider string
+	infoer   CloudInfoer
+	// guards the infoer, held for reading while scraping so that the infoer is only replaced between scrapes
+	infoerMu sync.RWMutex
+
 	store        CloudInfoStore
 	metrics      metrics.Reporter
 	tracer       tracing.Tracer
 	log          Logger
 	eventBus     messaging.EventBus
 	errorHandler ErrorHandler
+	// the maximum number of regions scraped in parallel, values less than one leave it unbounded
+	regionConcurrency int
+	// retry settings for transient infoer failures
+	retryMaxAttempts int
+	retryBaseDelay   time.Duration
+	// the time an infoer call is given to complete, zero means no limit
+	callTimeout time.Duration
+	// limits the rate of the infoer calls shared by all the scrape goroutines of the provider, nil if unlimited
+	limiter *rate.Limiter
+	// records every stored price
+	auditSink AuditSink
+
+	// the last scrape failure per service and region
+	scrapeErrors   map[string]types.ScrapeError
+	scrapeErrorsMu sync.Mutex
+
+	// serializes the updates of the region products, so that the last update sees the latest VMs and prices
+	regionProductsMu sync.Mutex
+	// the number of cached VMs per service and region, guarded by regionProductsMu
+	cachedVms map[string]map[string]int
+
+	// the time of the last successful short lived price update per region
+	priceUpdates   map[string]time.Time
+	priceUpdatesMu sync.Mutex
+
+	// the regions scraped in detail, all the regions are scraped if empty
+	allowedRegions map[string]struct{}
+
+	// the weight of the latest spot price in the smoothed (exponential moving average) spot price, zero disables smoothing
+	spotSmoothingFactor float64
+
+	// stops the short lived price scrapes of the provider after consecutive failures, nil if disabled
+	priceBreaker *circuitBreaker
 }
 
-func (sm *scrapingManager) initialize(ctx context.Context) {
+func (sm *scrapingManager) initialize(ctx context.Context) error {
 	ctx, _ = sm.tracer.StartWithTags(ctx, "initialize", map[string]interface{}{"provider": sm.provider})
 	defer sm.tracer.EndSpan(ctx)
 
 	sm.log.Info("initializing cloud product information")
-	prices, err := sm.infoer.Initialize()
+	var prices map[string]map[string]types.Price
+	err := sm.call(ctx, "Initialize", func() (err error) {
+		prices, err = sm.infoer.Initialize()
+		return
+	})
 	if err != nil {
 		sm.log.Error("failed to initialize cloud product information")
 		sm.errorHandler.Handle(err)
-		return
+		return err
 	}
 
+	priceCount := 0
 	for region, ap := range prices {
+		sm.storePrices(region, ap)
 		for instType, p := range ap {
-			sm.store.StorePrice(sm.provider, region, instType, p)
 			metrics.OnDemandPriceGauge.WithLabelValues(sm.provider, region, instType).Set(p.OnDemandPrice)
 		}
+		priceCount += len(ap)
 	}
-	sm.log.Info("finished initializing cloud product information")
+	sm.log.Info("finished initializing cloud product information", map[string]interface{}{"regions": len(prices), "prices": priceCount})
+
+	return nil
 }
 
-func (sm *scrapingManager) scrapeServiceRegionProducts(ctx context.Context, service string, regionId string) error {
+// storePrices merges the prices of the region into the cached ones and stores them
+// the spot prices of the zones missing from the scraped prices and the cached reserved prices
+// of the instance types having none are retained, as these may come from separate scrapes
+// the smoothed spot prices are updated from the cached ones if spot price smoothing is enabled
+// an empty result leaves the cached prices untouched; the stored (merged) prices are returned
+func (sm *scrapingManager) storePrices(region string, prices map[string]types.Price) map[string]types.Price {
+	if len(prices) == 0 {
+		return nil
+	}
+
+	merged := make(map[string]types.Price, len(prices))
+	for instanceType, price := range prices {
+		cached, ok := sm.store.GetPrice(sm.provider, region, instanceType)
+		if sm.spotSmoothingFactor > 0 {
+			price.SmoothedSpotPrice = smoothSpotPrice(cached.SmoothedSpotPrice, price.SpotPrice, sm.spotSmoothingFactor)
+		}
+		if ok {
+			price = cached.Merge(price)
+		}
+		merged[instanceType] = price
+	}
+
+	sm.store.StorePrices(sm.provider, region, merged)
+	sm.auditPrices(region, merged)
+
+	return merged
+}
+
+// auditPrices records the stored prices of the region in the audit sink
+func (sm *scrapingManager) auditPrices(region string, prices map[string]types.Price) {
+	now := time.Now()
+	for instanceType, price := range prices {
+		sm.auditSink.RecordPrice(now, sm.provider, region, instanceType, price)
+	}
+}
+
+// smoothSpotPrice calculates the exponential moving average of the spot prices per zone
+// zones without previous average start from the current spot price, zones without current spot price are dropped
+func smoothSpotPrice(previous, current types.SpotPriceInfo, factor float64) types.SpotPriceInfo {
+	if len(current) == 0 {
+		return nil
+	}
+
+	smoothed := make(types.SpotPriceInfo, len(current))
+	for zone, price := range current {
+		avg, ok := previous[zone]
+		if !ok {
+			smoothed[zone] = price
+			continue
+		}
+
+		smoothed[zone] = factor*price + (1-factor)*avg
+	}
+
+	return smoothed
+}
+
+// scrapeReservedPricesInRegion stores the reserved prices of the instance types in the region if the infoer supports them
+// failures are handled here as the reserved prices are not essential for the region's products
+func (sm *scrapingManager) scrapeReservedPricesInRegion(ctx context.Context, region string) {
+	if !sm.infoer.HasReserved() {
+		return
+	}
+
+	reservedInfoer, ok := sm.infoer.(ReservedPriceInfoer)
+	if !ok {
+		sm.log.Warn("reserved prices are not supported by the infoer", map[string]interface{}{"region": region})
+		return
+	}
+
+	var reservedPrices map[string]map[string]float64
+	err := sm.retry(ctx, "GetReservedPrices", func() (err error) {
+		reservedPrices, err = reservedInfoer.GetReservedPrices(region)
+		return
+	})
+	if err != nil {
+		sm.log.Error("failed to scrape reserved prices", map[string]interface{}{"region": region})
+		sm.errorHandler.Handle(errors.WithDetails(err, "provider", sm.provider, "region", region))
+		return
+	}
+
+	prices := make(map[string]types.Price, len(reservedPrices))
+	for instType, reservedPrice := range reservedPrices {
+		price, _ := sm.store.GetPrice(sm.provider, region, instType)
+		price.ReservedPrice = reservedPrice
+		prices[instType] = price
+	}
+	sm.store.StorePrices(sm.provider, region, prices)
+	sm.auditPrices(region, prices)
+}
+
+// scrapeServiceRegionProducts scrapes and stores the products of the service in the region, returning the number of cached products
+func (sm *scrapingManager) scrapeServiceRegionProducts(ctx context.Context, service string, regionId string) (int, error) {
 	logger := log.WithFields(sm.log, map[string]interface{}{"service": service, "region": regionId})
 
 	logger.Debug("retrieving regional product information")
@@ -73,31 +213,84 @@ func (sm *scrapingManager) scrapeServiceRegionProducts(ctx context.Context, serv
 		logger.Debug("VMs not yet cached, proceeding to scraping them...")
 	}
 
-	values, err := sm.infoer.GetProducts(vms, service, regionId)
+	var values []types.VMInfo
+	err := sm.retry(ctx, "GetProducts", func() (err error) {
+		values, err = sm.infoer.GetProducts(vms, service, regionId)
+		return
+	})
 	if err != nil {
-		return errors.Wrap(err, "failed to retrieve products for region")
+		return 0, errors.Wrap(err, "failed to retrieve products for region")
 	}
 
-	for _, vm := range values {
+	bandwidths := networkBandwidths(sm.infoer)
+	attributes := supportedAttributes(sm.infoer)
+	for i, vm := range values {
 		if vm.OnDemandPrice > 0 {
 			metrics.OnDemandPriceGauge.WithLabelValues(sm.provider, regionId, vm.Type).Set(vm.OnDemandPrice)
 		}
+		if vm.NtwGbps == 0 {
+			values[i].NtwGbps = ParseNetworkGbps(vm.NtwPerf, bandwidths)
+		}
+		if vm.NormalizedUnits == 0 {
+			values[i].NormalizedUnits = NormalizedUnits(sm.provider, vm.Type, vm.Cpus)
+		}
+		fillAttributes(&values[i], attributes)
 	}
 
-	sm.store.StoreVm(sm.provider, service, regionId, values)
+	// the VMs are joined with the prices before being stored, so that readers don't see them without prices
+	sm.regionProductsMu.Lock()
+	defer sm.regionProductsMu.Unlock()
 
-	err = sm.updateVirtualMachines(service, regionId)
-	if err != nil {
-		return err
+	virtualMachines := sm.updateVirtualMachines(regionId, values)
+	if len(virtualMachines) == 0 && len(vms) > 0 {
+		// an empty result is more likely a partial outage of the provider API than an emptied catalog
+		logger.Warn("no products retrieved, keeping the cached products", map[string]interface{}{"cached": len(vms)})
+		return len(vms), nil
+	}
+	if ok {
+		// there's nothing to compare the catalog with at the first scrape
+		sm.store.StoreCatalogChanges(sm.provider, service, regionId, catalogChanges(vms, virtualMachines, time.Now()))
 	}
+	sm.store.StoreVm(sm.provider, service, regionId, virtualMachines)
+	sm.storeRegionProducts(service, regionId, virtualMachines)
+	sm.reportCacheSize(service, regionId, len(virtualMachines))
 
-	return nil
+	return len(virtualMachines), nil
+}
+
+// catalogChanges collects the instance types added and removed between the previous and the current VMs
+func catalogChanges(previous, current []types.VMInfo, now time.Time) types.CatalogChanges {
+	previousTypes := make(map[string]struct{}, len(previous))
+	for _, vm := range previous {
+		previousTypes[vm.Type] = struct{}{}
+	}
+
+	changes := types.CatalogChanges{Added: make([]string, 0), Removed: make([]string, 0), Time: now}
+	for _, vm := range current {
+		if _, ok := previousTypes[vm.Type]; ok {
+			delete(previousTypes, vm.Type)
+			continue
+		}
+		changes.Added = append(changes.Added, vm.Type)
+	}
+	for instanceType := range previousTypes {
+		changes.Removed = append(changes.Removed, instanceType)
+	}
+
+	sort.Strings(changes.Added)
+	sort.Strings(changes.Removed)
+
+	return changes
 }
 
 func (sm *scrapingManager) scrapeServiceRegionImages(ctx context.Context, service string, regionId string) error {
 	if sm.infoer.HasImages() {
 		sm.log.Debug("retrieving regional image information", map[string]interface{}{"service": service, "region": regionId})
-		images, err := sm.infoer.GetServiceImages(service, regionId)
+		var images []types.Image
+		err := sm.call(ctx, "GetServiceImages", func() (err error) {
+			images, err = sm.infoer.GetServiceImages(service, regionId)
+			return
+		})
 		if err != nil {
 			return errors.WrapIff(err, "failed to retrieve service images for region")
 		}
@@ -109,7 +302,15 @@ func (sm *scrapingManager) scrapeServiceRegionImages(ctx context.Context, servic
 }
 
 func (sm *scrapingManager) scrapeServiceRegionVersions(ctx context.Context, service string, regionId string) error {
-	versions, err := sm.infoer.GetVersions(service, regionId)
+	if !sm.infoer.HasVersions() {
+		return nil
+	}
+
+	var versions []types.LocationVersion
+	err := sm.call(ctx, "GetVersions", func() (err error) {
+		versions, err = sm.infoer.GetVersions(service, regionId)
+		return
+	})
 	if err != nil {
 		return errors.WrapIf(err, "failed to retrieve service versions for region")
 	}
@@ -121,7 +322,17 @@ func (sm *scrapingManager) scrapeServiceRegionVersions(ctx context.Context, serv
 }
 
 func (sm *scrapingManager) scrapeServiceRegionZones(ctx context.Context, service, region string) error {
-	zones, err := sm.infoer.GetZones(region)
+	if !sm.infoer.HasZones() {
+		// the region is cached without zones, so that the zone queries don't report it as not cached
+		sm.store.StoreZones(sm.provider, service, region, []string{})
+		return nil
+	}
+
+	var zones []string
+	err := sm.call(ctx, "GetZones", func() (err error) {
+		zones, err = sm.infoer.GetZones(region)
+		return
+	})
 	if err != nil {
 		return errors.WrapIf(err, "failed to retrieve zones for region")
 	}
@@ -132,11 +343,54 @@ func (sm *scrapingManager) scrapeServiceRegionZones(ctx context.Context, service
 	return nil
 }
 
+// scrapeServiceRegion scrapes the zones, products, images and versions of a service in the given region
+// the number of cached products is returned
+func (sm *scrapingManager) scrapeServiceRegion(ctx context.Context, service, regionId string) (int, error) {
+	if err := sm.scrapeServiceRegionZones(ctx, service, regionId); err != nil {
+		return 0, err
+	}
+	// reserved prices are the same for every service, they're stored before the products get joined with the prices
+	if service == sm.infoer.PriceRegionService() {
+		sm.scrapeReservedPricesInRegion(ctx, regionId)
+	}
+	products, err := sm.scrapeServiceRegionProducts(ctx, service, regionId)
+	if err != nil {
+		return 0, err
+	}
+	if err := sm.scrapeServiceRegionImages(ctx, service, regionId); err != nil {
+		return 0, err
+	}
+	if err := sm.scrapeServiceRegionVersions(ctx, service, regionId); err != nil {
+		return 0, err
+	}
+
+	return products, nil
+}
+
+// getRegions retrieves the regions of the service, retrying transient failures
+func (sm *scrapingManager) getRegions(ctx context.Context, service string) (map[string]string, error) {
+	var regions map[string]string
+	err := sm.retry(ctx, "GetRegions", func() (err error) {
+		regions, err = sm.infoer.GetRegions(service)
+		return
+	})
+	if err != nil {
+		// the regions are not read on failure, a timed out call may still be setting them
+		return nil, err
+	}
+
+	return regions, nil
+}
+
 func (sm *scrapingManager) scrapeServiceRegionInfo(ctx context.Context, services []types.Service) error {
 	ctx, _ = sm.tracer.StartWithTags(ctx, "scrape-region-info", map[string]interface{}{"provider": sm.provider})
 	defer sm.tracer.EndSpan(ctx)
 
-	var lastScrapeError error = nil
+	var (
+		// per region failures are collected, so that a failing region doesn't prevent refreshing the others
+		scrapeErr error
+		mu        sync.Mutex
+	)
 	for _, service := range services {
 		sm.log.Info("start to scrape service region information", map[string]interface{}{"service": service.ServiceName()})
 
@@ -150,48 +404,121 @@ func (sm *scrapingManager) scrapeServiceRegionInfo(ctx context.Context, services
 			continue
 		}
 
-		regions, err := sm.infoer.GetRegions(service.ServiceName())
+		regions, err := sm.getRegions(ctx, service.ServiceName())
 		if err != nil {
-			sm.metrics.ReportScrapeFailure(sm.provider, service.ServiceName(), "N/A")
+			sm.reportScrapeFailure(service.ServiceName(), "N/A", err)
 			return errors.WithDetails(err, "failed to retrieve regions", "service", service.ServiceName())
 		}
 
+		// every region is reported, while only the allowed ones are scraped in detail
 		sm.store.DeleteRegions(sm.provider, service.ServiceName())
 		sm.store.StoreRegions(sm.provider, service.ServiceName(), regions)
+		regions = sm.filterRegions(regions)
+
+		var (
+			wg sync.WaitGroup
+			// the number of successfully scraped regions and their products, guarded by mu
+			scrapedRegions, scrapedProducts int
+		)
+		// the semaphore caps the number of regions scraped in parallel, all of them if no cap is set
+		width := sm.regionConcurrency
+		if width < 1 {
+			width = len(regions)
+		}
+		sem := make(chan struct{}, width)
 
 		for regionId := range regions {
-			start := time.Now()
-			if err = sm.scrapeServiceRegionZones(ctx, service.ServiceName(), regionId); err != nil {
-				lastScrapeError = errors.WithDetails(err, "provider", sm.provider, "service", service.ServiceName(), "region", regionId)
-				sm.log.WithFields(map[string]interface{}{"error": lastScrapeError, "region": regionId}).
-					Error("failed to scrape zones for region")
-				continue
-			}
-			if err = sm.scrapeServiceRegionProducts(ctx, service.ServiceName(), regionId); err != nil {
-				sm.metrics.ReportScrapeFailure(sm.provider, service.ServiceName(), regionId)
-				lastScrapeError = errors.WithDetails(err, "provider", sm.provider, "service", service.ServiceName(), "region", regionId)
-				sm.log.WithFields(map[string]interface{}{"error": lastScrapeError, "region": regionId}).
-					Error("failed to scrape products for region")
-				continue
-			}
-			if err = sm.scrapeServiceRegionImages(ctx, service.ServiceName(), regionId); err != nil {
-				sm.metrics.ReportScrapeFailure(sm.provider, service.ServiceName(), regionId)
-				lastScrapeError = errors.WithDetails(err, "provider", sm.provider, "service", service.ServiceName(), "region", regionId)
-				sm.log.WithFields(map[string]interface{}{"error": lastScrapeError, "region": regionId}).
-					Error("failed to scrape images for region")
-				continue
-			}
-			if err = sm.scrapeServiceRegionVersions(ctx, service.ServiceName(), regionId); err != nil {
-				sm.metrics.ReportScrapeFailure(sm.provider, service.ServiceName(), regionId)
-				lastScrapeError = errors.WithDetails(err, "provider", sm.provider, "service", service.ServiceName(), "region", regionId)
-				sm.log.WithFields(map[string]interface{}{"error": lastScrapeError, "region": regionId}).
-					Error("failed to scrape images for region")
-				continue
+			sem <- struct{}{}
+
+			// stop walking the regions if the scrape got cancelled (eg.: the application is shutting down)
+			if err = ctx.Err(); err != nil {
+				<-sem
+				sm.reportScrapeFailure(service.ServiceName(), regionId, err)
+				break
 			}
-			sm.metrics.ReportScrapeRegionCompleted(sm.provider, service.ServiceName(), regionId, start)
+
+			wg.Add(1)
+			go func(regionId string) {
+				defer func() {
+					<-sem
+					wg.Done()
+				}()
+
+				start := time.Now()
+				products, err := sm.scrapeServiceRegion(ctx, service.ServiceName(), regionId)
+				if err != nil {
+					sm.reportScrapeFailure(service.ServiceName(), regionId, err)
+
+					mu.Lock()
+					scrapeErr = errors.Append(scrapeErr,
+						errors.WithDetails(err, "provider", sm.provider, "service", service.ServiceName(), "region", regionId))
+					mu.Unlock()
+					return
+				}
+				sm.clearScrapeFailure(service.ServiceName(), regionId)
+				sm.metrics.ReportScrapeRegionCompleted(sm.provider, service.ServiceName(), regionId, start)
+
+				mu.Lock()
+				scrapedRegions++
+				scrapedProducts += products
+				mu.Unlock()
+			}(regionId)
+		}
+		wg.Wait()
+
+		sm.log.Info("finished scraping service region information",
+			map[string]interface{}{"service": service.ServiceName(), "regions": scrapedRegions, "products": scrapedProducts})
+
+		if err = ctx.Err(); err != nil {
+			return errors.Append(scrapeErr, errors.WithDetails(err, "provider", sm.provider, "service", service.ServiceName()))
 		}
 	}
-	return lastScrapeError
+	return scrapeErr
+}
+
+// reportScrapeFailure reports the failure to the metrics and records it as the last failure of the service and region
+func (sm *scrapingManager) reportScrapeFailure(service, region string, err error) {
+	sm.metrics.ReportScrapeFailure(sm.provider, service, region)
+
+	sm.scrapeErrorsMu.Lock()
+	defer sm.scrapeErrorsMu.Unlock()
+
+	sm.scrapeErrors[service+"/"+region] = types.ScrapeError{
+		Service: service,
+		Region:  region,
+		Message: err.Error(),
+		Time:    time.Now(),
+	}
+	sm.storeScrapeErrors()
+}
+
+// clearScrapeFailure discards the recorded failure of the service and region after a successful scrape
+func (sm *scrapingManager) clearScrapeFailure(service, region string) {
+	sm.scrapeErrorsMu.Lock()
+	defer sm.scrapeErrorsMu.Unlock()
+
+	if _, ok := sm.scrapeErrors[service+"/"+region]; !ok {
+		return
+	}
+
+	delete(sm.scrapeErrors, service+"/"+region)
+	sm.storeScrapeErrors()
+}
+
+// storeScrapeErrors stores the recorded failures ordered by service and region, the caller must hold scrapeErrorsMu
+func (sm *scrapingManager) storeScrapeErrors() {
+	scrapeErrors := make([]types.ScrapeError, 0, len(sm.scrapeErrors))
+	for _, scrapeError := range sm.scrapeErrors {
+		scrapeErrors = append(scrapeErrors, scrapeError)
+	}
+	sort.Slice(scrapeErrors, func(i, j int) bool {
+		if scrapeErrors[i].Service != scrapeErrors[j].Service {
+			return scrapeErrors[i].Service < scrapeErrors[j].Service
+		}
+		return scrapeErrors[i].Region < scrapeErrors[j].Region
+	})
+
+	sm.store.StoreScrapeErrors(sm.provider, scrapeErrors)
 }
 
 func (sm *scrapingManager) updateStatus(ctx context.Context) {
@@ -201,62 +528,188 @@ func (sm *scrapingManager) updateStatus(ctx context.Context) {
 }
 
 // scrapeServiceInformation scrapes service and region dependant cloud information and stores its
-func (sm *scrapingManager) scrapeServiceInformation(ctx context.Context) {
+func (sm *scrapingManager) scrapeServiceInformation(ctx context.Context) error {
 	ctx, _ = sm.tracer.StartWithTags(ctx, "scrape-service-info", map[string]interface{}{"provider": sm.provider})
 	defer sm.tracer.EndSpan(ctx)
 
 	storedServices, ok := sm.store.GetServices(sm.provider)
 	if !ok {
-		sm.metrics.ReportScrapeFailure(sm.provider, "N/A", "N/A")
+		err := errors.NewWithDetails("failed to retrieve services", "provider", sm.provider)
+		sm.reportScrapeFailure("N/A", "N/A", err)
 		sm.log.Error("failed to retrieve services")
-		return
+		return err
 	}
 
 	err := sm.scrapeServiceRegionInfo(ctx, storedServices)
 	if err != nil {
 		sm.log.Error("failed to load service region information")
 		sm.errorHandler.Handle(err)
-		return
+		return err
 	}
 
 	sm.updateStatus(ctx)
+
+	return nil
 }
 
 func (sm *scrapingManager) scrapePricesInRegion(ctx context.Context, region string, wg *sync.WaitGroup) {
 	if wg != nil {
 		defer wg.Done()
 	}
+
+	allowed := sm.priceBreaker.allow()
+	sm.metrics.ReportPriceCircuitBreakerState(sm.provider, int(sm.priceBreaker.currentState()))
+	if !allowed {
+		sm.log.Debug("skipping price scrape, the circuit breaker is open", map[string]interface{}{"region": region})
+		return
+	}
+
 	start := time.Now()
-	prices, err := sm.infoer.GetCurrentPrices(region)
+	var (
+		current map[string]types.Price
+		// the prices are only read on success, a timed out call may still be setting the current prices
+		prices map[string]types.Price
+	)
+	err := sm.retry(ctx, "GetCurrentPrices", func() (err error) {
+		current, err = sm.infoer.GetCurrentPrices(region)
+		return
+	})
 	if err != nil {
+		sm.priceBreaker.failure()
 		sm.metrics.ReportScrapeShortLivedFailure(sm.provider, region)
 		sm.log.Error("failed to scrape spot prices in region")
 		sm.errorHandler.Handle(err)
+	} else if len(current) == 0 {
+		sm.priceBreaker.success()
+		sm.log.Warn("no prices retrieved, keeping the cached prices", map[string]interface{}{"region": region})
+	} else {
+		sm.priceBreaker.success()
+		prices = current
+
+		sm.priceUpdatesMu.Lock()
+		sm.priceUpdates[region] = time.Now()
+		sm.priceUpdatesMu.Unlock()
 	}
+	sm.metrics.ReportPriceCircuitBreakerState(sm.provider, int(sm.priceBreaker.currentState()))
 
-	for instType, price := range prices {
-		sm.store.StorePrice(sm.provider, region, instType, price)
+	previous := make(map[string]types.Price, len(prices))
+	for instType := range prices {
+		if cached, ok := sm.store.GetPrice(sm.provider, region, instType); ok {
+			previous[instType] = cached
+		}
 	}
+	// the scraped prices may be partial (eg.: spot prices only), the changes are reported based on the stored prices
+	stored := sm.storePrices(region, prices)
+
+	sm.reportPriceChanges(region, previous, stored)
+
+	sm.refreshRegionProducts(region)
+
+	sm.scrapeSpotPriceHistoryInRegion(ctx, region, prices)
 
 	sm.metrics.ReportScrapeRegionShortLivedCompleted(sm.provider, region, start)
 }
 
+// reportPriceChanges logs and publishes the prices changed by the scrape; instance types with no previous price are not considered changed
+func (sm *scrapingManager) reportPriceChanges(region string, previous, current map[string]types.Price) {
+	changed := 0
+	now := time.Now()
+	for instType, change := range DiffPrices(previous, current) {
+		if _, ok := previous[instType]; !ok {
+			continue
+		}
+		changed++
+
+		sm.eventBus.PublishPriceChange(types.PriceChangeEvent{
+			Provider:     sm.provider,
+			Region:       region,
+			InstanceType: instType,
+			PriceChange:  change,
+			Time:         now,
+		})
+
+		sm.log.Debug("price changed", map[string]interface{}{"region": region, "instanceType": instType,
+			"oldOnDemandPrice": change.OldOnDemandPrice, "newOnDemandPrice": change.NewOnDemandPrice,
+			"oldSpotPrice": change.OldSpotPrice, "newSpotPrice": change.NewSpotPrice})
+	}
+
+	if changed > 0 {
+		sm.log.Info("prices changed", map[string]interface{}{"region": region, "changes": changed})
+	}
+}
+
+// scrapeSpotPriceHistoryInRegion updates the rolling spot price history window of the instance types having spot prices
+func (sm *scrapingManager) scrapeSpotPriceHistoryInRegion(ctx context.Context, region string, prices map[string]types.Price) {
+	historyInfoer, ok := sm.infoer.(SpotPriceHistoryInfoer)
+	if !ok {
+		sm.log.Debug("spot price history not supported", map[string]interface{}{"region": region})
+		return
+	}
+
+	now := time.Now()
+	for instType, price := range prices {
+		if len(price.SpotPrice) == 0 {
+			continue
+		}
+
+		var history []types.TimestampedPrice
+		err := sm.retry(ctx, "GetSpotPriceHistory", func() (err error) {
+			history, err = historyInfoer.GetSpotPriceHistory(region, instType, defaultSpotPriceHistoryWindow)
+			return
+		})
+		if err != nil {
+			sm.log.Error("failed to scrape spot price history", map[string]interface{}{"region": region, "instanceType": instType})
+			sm.errorHandler.Handle(errors.WithDetails(err, "provider", sm.provider, "region", region, "instanceType", instType))
+			continue
+		}
+
+		stored, _ := sm.store.GetSpotPriceHistory(sm.provider, region, instType)
+		sm.store.StoreSpotPriceHistory(sm.provider, region, instType, mergeSpotPriceHistory(stored, history, now.Add(-defaultSpotPriceHistoryWindow)))
+	}
+}
+
+// mergeSpotPriceHistory merges the histories into a time ordered one, dropping duplicates and the prices older than since
+func mergeSpotPriceHistory(stored, fetched []types.TimestampedPrice, since time.Time) []types.TimestampedPrice {
+	byTime := make(map[int64]types.TimestampedPrice, len(stored)+len(fetched))
+	for _, history := range [][]types.TimestampedPrice{stored, fetched} {
+		for _, tp := range history {
+			if tp.Time.Before(since) {
+				continue
+			}
+			byTime[tp.Time.UnixNano()] = tp
+		}
+	}
+
+	merged := make([]types.TimestampedPrice, 0, len(byTime))
+	for _, tp := range byTime {
+		merged = append(merged, tp)
+	}
+	sort.Slice(merged, func(i, j int) bool {
+		return merged[i].Time.Before(merged[j].Time)
+	})
+
+	return merged
+}
+
 func (sm *scrapingManager) scrapePricesInAllRegions(ctx context.Context) {
 	var wg sync.WaitGroup
 
 	ctx, _ = sm.tracer.StartWithTags(ctx, "scrape-region-prices", map[string]interface{}{"provider": sm.provider})
 	defer sm.tracer.EndSpan(ctx)
+	sm.infoerMu.RLock()
+	defer sm.infoerMu.RUnlock()
+
 	sm.log.Info("start scraping prices")
 
 	// record current time for metrics
 	start := time.Now()
-	regions, err := sm.infoer.GetRegions("compute")
+	regions, err := sm.getRegions(ctx, sm.infoer.PriceRegionService())
 	if err != nil {
 		sm.log.Error("failed to retrieve regions")
 		sm.errorHandler.Handle(err)
 	}
 
-	for regionId := range regions {
+	for regionId := range sm.filterRegions(regions) {
 		wg.Add(1)
 		go sm.scrapePricesInRegion(ctx, regionId, &wg)
 	}
@@ -264,13 +717,50 @@ func (sm *scrapingManager) scrapePricesInAllRegions(ctx context.Context) {
 	sm.metrics.ReportScrapeProviderShortLivedCompleted(sm.provider, start)
 }
 
-func (sm *scrapingManager) updateVirtualMachines(service, region string) error {
-	vms, ok := sm.store.GetVm(sm.provider, service, region)
+// reportPriceAges reports the age of the cached prices of the instance types in the price region service
+// prices are considered updated by the last scrape of the provider or the last short lived price scrape of the region
+func (sm *scrapingManager) reportPriceAges(now time.Time) {
+	var scrapeTime time.Time
+	if status, ok := sm.store.GetStatus(sm.provider); ok {
+		scrapeTime, _ = parseStatus(status)
+	}
+
+	sm.infoerMu.RLock()
+	service := sm.infoer.PriceRegionService()
+	sm.infoerMu.RUnlock()
+
+	regions, ok := sm.store.GetRegions(sm.provider, service)
 	if !ok {
-		sm.log.Debug("VMs not yet cached, update suspended")
-		return errors.NewWithDetails("VMs not yet cached", "provider", sm.provider, "service", service, "region", region)
+		return
+	}
+
+	sm.priceUpdatesMu.Lock()
+	defer sm.priceUpdatesMu.Unlock()
+
+	for region := range regions {
+		updated := scrapeTime
+		if sm.priceUpdates[region].After(updated) {
+			updated = sm.priceUpdates[region]
+		}
+		if updated.IsZero() {
+			continue
+		}
+
+		vms, ok := sm.store.GetVm(sm.provider, service, region)
+		if !ok {
+			continue
+		}
+
+		for _, vm := range vms {
+			if _, ok := sm.store.GetPrice(sm.provider, region, vm.Type); ok {
+				sm.metrics.ReportPriceAge(sm.provider, region, vm.Type, now.Sub(updated))
+			}
+		}
 	}
+}
 
+// updateVirtualMachines sets the cached on demand prices of the VMs, the VMs without on demand price are left out
+func (sm *scrapingManager) updateVirtualMachines(region string, vms []types.VMInfo) []types.VMInfo {
 	virtualMachines := make([]types.VMInfo, 0, len(vms))
 	for _, vm := range vms {
 		prices, found := sm.store.GetPrice(sm.provider, region, vm.Type)
@@ -286,42 +776,128 @@ func (sm *scrapingManager) updateVirtualMachines(service, region string) error {
 		}
 	}
 
-	sm.store.DeleteVm(sm.provider, service, region)
-	sm.store.StoreVm(sm.provider, service, region, virtualMachines)
+	return virtualMachines
+}
 
-	return nil
+// storeRegionProducts stores the VMs along with their cached prices in a single entry
+// the caller must hold regionProductsMu
+func (sm *scrapingManager) storeRegionProducts(service, region string, vms []types.VMInfo) {
+	prices := make(map[string]types.Price, len(vms))
+	for _, vm := range vms {
+		if price, ok := sm.store.GetPrice(sm.provider, region, vm.Type); ok {
+			prices[vm.Type] = price
+		}
+	}
+
+	sm.store.StoreRegionProducts(sm.provider, service, region, types.RegionProducts{Vms: vms, Prices: prices})
+}
+
+// reportCacheSize reports the number of cached VMs of the service in all regions
+// the caller must hold regionProductsMu
+func (sm *scrapingManager) reportCacheSize(service, region string, entries int) {
+	if sm.cachedVms[service] == nil {
+		sm.cachedVms[service] = make(map[string]int)
+	}
+	sm.cachedVms[service][region] = entries
+
+	total := 0
+	for _, count := range sm.cachedVms[service] {
+		total += count
+	}
+
+	sm.metrics.ReportCacheSize(sm.provider, service, total)
+}
+
+// refreshRegionProducts updates the region products of every service having VMs in the region with the cached prices
+func (sm *scrapingManager) refreshRegionProducts(region string) {
+	services, ok := sm.store.GetServices(sm.provider)
+	if !ok {
+		return
+	}
+
+	sm.regionProductsMu.Lock()
+	defer sm.regionProductsMu.Unlock()
+
+	for _, service := range services {
+		if vms, ok := sm.store.GetVm(sm.provider, service.ServiceName(), region); ok {
+			sm.storeRegionProducts(service.ServiceName(), region, vms)
+		}
+	}
 }
 
 // scrape implements the scraping logic for a provider
-func (sm *scrapingManager) scrape(ctx context.Context) {
+// failures are handled by the manager, the returned error is for callers waiting for the outcome
+func (sm *scrapingManager) scrape(ctx context.Context) error {
 	ctx, _ = sm.tracer.StartWithTags(ctx, fmt.Sprintf("scraping-%s", sm.provider), map[string]interface{}{"provider": sm.provider})
 	defer sm.tracer.EndSpan(ctx)
 
+	sm.infoerMu.RLock()
+	defer sm.infoerMu.RUnlock()
+
 	sm.log.Info("start scraping for provider information")
 	start := time.Now()
 
-	sm.initialize(ctx)
+	initErr := sm.initialize(ctx)
 
-	sm.scrapeServiceInformation(ctx)
+	scrapeErr := sm.scrapeServiceInformation(ctx)
 
 	// emit a scraping complete event to notify potential subscribers
 	sm.eventBus.PublishScrapingComplete(sm.provider)
 
 	sm.metrics.ReportScrapeProviderCompleted(sm.provider, start)
+
+	return errors.Combine(initErr, scrapeErr)
+}
+
+// hasShortLivedPriceInfo checks whether the prices of the provider change frequently
+func (sm *scrapingManager) hasShortLivedPriceInfo() bool {
+	sm.infoerMu.RLock()
+	defer sm.infoerMu.RUnlock()
+
+	return sm.infoer.HasShortLivedPriceInfo()
+}
+
+// withInfoer calls the function with the infoer, holding off its replacement until the function returns
+func (sm *scrapingManager) withInfoer(fn func(name string, infoer CloudInfoer) error) error {
+	sm.infoerMu.RLock()
+	defer sm.infoerMu.RUnlock()
+
+	return fn(sm.provider, sm.infoer)
+}
+
+// setInfoer replaces the infoer once the running scrapes complete
+func (sm *scrapingManager) setInfoer(infoer CloudInfoer) {
+	sm.infoerMu.Lock()
+	defer sm.infoerMu.Unlock()
+
+	sm.infoer = infoer
+}
+
+// reconfigure replaces the credentials of the infoer once the running scrapes complete
+func (sm *scrapingManager) reconfigure(creds Credentials) error {
+	sm.infoerMu.Lock()
+	defer sm.infoerMu.Unlock()
+
+	infoer, ok := sm.infoer.(ReconfigurableInfoer)
+	if !ok {
+		return errors.NewWithDetails("infoer can't be reconfigured", "provider", sm.provider)
+	}
+
+	return errors.WrapIfWithDetails(infoer.Reconfigure(creds), "failed to reconfigure infoer", "provider", sm.provider)
 }
 
 func (sm *scrapingManager) scrapePKEImages(ctx context.Context, service types.Service) error {
 	// todo find a better solution - PKE service is static but images need to be scraped
 	if service.ServiceName() == "pke" {
-		regions, err := sm.infoer.GetRegions(service.ServiceName())
+		regions, err := sm.getRegions(ctx, service.ServiceName())
 		if err != nil {
-			sm.metrics.ReportScrapeFailure(sm.provider, service.ServiceName(), "N/A")
+			sm.reportScrapeFailure(service.ServiceName(), "N/A", err)
 			return errors.WithDetails(err, "failed to retrieve regions", "service", service.ServiceName())
 		}
 
 		for regionId := range regions {
 			if err = sm.scrapeServiceRegionImages(ctx, service.ServiceName(), regionId); err != nil {
-				sm.metrics.ReportScrapeFailure(sm.provider, service.ServiceName(), regionId)
+				sm.reportScrapeFailure(service.ServiceName(), regionId, err)
 				return errors.WithDetails(err, "provider", sm.provider, "service", service.ServiceName(), "region", regionId)
 			}
 		}
@@ -330,8 +906,81 @@ func (sm *scrapingManager) scrapePKEImages(ctx context.Context, service types.Se
 	return nil
 }
 
+// ScrapingOptions holds the optional settings of the scraping
+// the zero value scrapes every region of every provider in parallel, without retries, call timeout, rate limit,
+// spot price smoothing and pausing of the failing price scrapes
+type ScrapingOptions struct {
+	// RenewalIntervals holds the provider specific renewal intervals, the other providers are renewed with the default interval
+	RenewalIntervals map[string]time.Duration
+	// ProviderRegions holds the regions scraped per provider, providers missing from it (or having no regions listed) are scraped in every region
+	ProviderRegions map[string][]string
+	// ShortLivedInterval is the renewal interval of the short lived prices, zero means the default interval
+	ShortLivedInterval time.Duration
+
+	// RegionConcurrency caps the number of regions of a provider scraped in parallel, values less than one leave it unbounded
+	RegionConcurrency int
+	// ProviderConcurrency caps the number of providers scraped in parallel, values less than one leave it unbounded
+	ProviderConcurrency int
+
+	// RetryMaxAttempts is the number of attempts of the transiently failing provider calls, values less than two disable retries
+	RetryMaxAttempts int
+	// RetryBaseDelay is the delay before the first retry, doubled for every further one
+	RetryBaseDelay time.Duration
+	// CallTimeout limits the duration of the provider calls, zero means no limit
+	CallTimeout time.Duration
+	// RequestRate is the number of provider calls per second allowed per provider, zero means no limit
+	RequestRate float64
+
+	// SpotSmoothingFactor is the weight of the latest spot price in the smoothed spot price,
+	// a factor of 1 keeps the latest spot price only, factors out of the (0, 1) range disable smoothing
+	SpotSmoothingFactor float64
+	// BreakerThreshold is the number of consecutive price scrape failures pausing the price scrapes of a provider
+	// for the BreakerCooldown, zero disables pausing
+	BreakerThreshold int
+	BreakerCooldown  time.Duration
+
+	// AuditSink records the stored prices, nothing is recorded if it's nil
+	AuditSink AuditSink
+	// Jitter is the maximum fraction of the renewal interval the periodic scrapes of the providers are randomly offset by,
+	// clamped to the [0, 1] range
+	Jitter float64
+	// ExecutorFactory creates the executors running the renewals, periodic executors are used if it's nil
+	ExecutorFactory ExecutorFactory
+}
+
+// NewScrapingManager creates a scraping manager for the provider, the driver level options (eg.: the renewal intervals) are ignored
 func NewScrapingManager(provider string, infoer CloudInfoer, store CloudInfoStore, log Logger,
-	metrics metrics.Reporter, tracer tracing.Tracer, eventBus messaging.EventBus, errorHandler ErrorHandler) *scrapingManager {
+	metrics metrics.Reporter, tracer tracing.Tracer, eventBus messaging.EventBus, errorHandler ErrorHandler,
+	options ScrapingOptions) *scrapingManager {
+	retryMaxAttempts := options.RetryMaxAttempts
+	if retryMaxAttempts < 1 {
+		retryMaxAttempts = 1
+	}
+
+	spotSmoothingFactor := options.SpotSmoothingFactor
+	if spotSmoothingFactor <= 0 || spotSmoothingFactor >= 1 {
+		spotSmoothingFactor = 0
+	}
+
+	var allowed map[string]struct{}
+	if allowedRegions := options.ProviderRegions[provider]; len(allowedRegions) > 0 {
+		allowed = make(map[string]struct{}, len(allowedRegions))
+		for _, region := range allowedRegions {
+			allowed[region] = struct{}{}
+		}
+	}
+
+	auditSink := options.AuditSink
+	if auditSink == nil {
+		auditSink = NewNoOpAuditSink()
+	}
+
+	// a single token is allowed in the bucket, so that the calls are evenly spaced
+	var limiter *rate.Limiter
+	if options.RequestRate > 0 {
+		limiter = rate.NewLimiter(rate.Limit(options.RequestRate), 1)
+	}
+
 	return &scrapingManager{
 		provider:     provider,
 		infoer:       infoer,
@@ -341,56 +990,327 @@ func NewScrapingManager(provider string, infoer CloudInfoer, store CloudInfoStor
 		tracer:       tracer,
 		eventBus:     eventBus,
 		errorHandler: errorHandler,
+
+		regionConcurrency: options.RegionConcurrency,
+		retryMaxAttempts:  retryMaxAttempts,
+		retryBaseDelay:    options.RetryBaseDelay,
+		callTimeout:       options.CallTimeout,
+		limiter:           limiter,
+		auditSink:         auditSink,
+
+		scrapeErrors: make(map[string]types.ScrapeError),
+		cachedVms:    make(map[string]map[string]int),
+		priceUpdates: make(map[string]time.Time),
+
+		allowedRegions:      allowed,
+		spotSmoothingFactor: spotSmoothingFactor,
+		priceBreaker:        newCircuitBreaker(options.BreakerThreshold, options.BreakerCooldown),
 	}
 }
 
+// filterRegions returns the allowed regions out of the given ones
+func (sm *scrapingManager) filterRegions(regions map[string]string) map[string]string {
+	if len(sm.allowedRegions) == 0 {
+		return regions
+	}
+
+	allowed := make(map[string]string, len(sm.allowedRegions))
+	for regionId, name := range regions {
+		if _, ok := sm.allowedRegions[regionId]; ok {
+			allowed[regionId] = name
+		}
+	}
+
+	if skipped := len(regions) - len(allowed); skipped > 0 {
+		sm.log.Debug("skipping regions not allowed", map[string]interface{}{"skippedRegions": skipped})
+	}
+
+	return allowed
+}
+
+// defaultShortLivedInterval is the renewal interval of the short lived prices used when none is configured
+const defaultShortLivedInterval = 4 * time.Minute
+
+// defaultSpotPriceHistoryWindow is the length of the spot price history window kept in the store
+const defaultSpotPriceHistoryWindow = 24 * time.Hour
+
 type ScrapingDriver struct {
 	scrapingManagers []*scrapingManager
 	renewalInterval  time.Duration
-	errorHandler     ErrorHandler
-	log              Logger
+	// renewalIntervals holds the provider specific renewal intervals overriding the default one
+	renewalIntervals map[string]time.Duration
+	// shortLivedInterval is the renewal interval of the short lived (spot) prices
+	shortLivedInterval time.Duration
+	// executorFactory creates the executors running the periodic renewals
+	executorFactory ExecutorFactory
+	// scrapeSlots is the semaphore capping the number of providers scraped in parallel, nil if unbounded
+	scrapeSlots  chan struct{}
+	errorHandler ErrorHandler
+	log          Logger
+
+	// jitter is the maximum fraction of the renewal interval the periodic renewals of the providers are offset by
+	jitter float64
+	// random returns a pseudo-random number in [0, 1), the offset of the renewals is jitter*random() of the interval
+	random func() float64
+	// after waits for the offset of the renewals to elapse
+	after func(time.Duration) <-chan time.Time
 }
 
 func (sd *ScrapingDriver) StartScraping() error {
 	ctx := context.Background()
 
-	if err := NewPeriodicExecutor(sd.renewalInterval, sd.log).Execute(ctx, sd.renewAll); err != nil {
-		return errors.WrapIf(err, "failed to scrape cloud information")
+	// providers are renewed independently, each according to its own interval
+	for _, manager := range sd.scrapingManagers {
+		if err := sd.startRenewal(ctx, manager); err != nil {
+			return errors.WrapIfWithDetails(err, "failed to scrape cloud information", "provider", manager.provider)
+		}
 	}
 
 	// start scraping providers for pricing information
-	if err := NewPeriodicExecutor(4*time.Minute, sd.log).Execute(ctx, sd.renewShortLived); err != nil {
+	if err := sd.shortLivedExecutor().Execute(ctx, sd.renewShortLived); err != nil {
 		return errors.WrapIf(err, "failed to scrape spot price info")
 	}
 
 	return nil
 }
 
-func (sd *ScrapingDriver) renewAll(ctx context.Context) {
-	for _, manager := range sd.scrapingManagers {
-		go manager.scrape(ctx)
+// startRenewal starts the periodic renewal of the provider of the manager
+// the provider is scraped right away, the periodic renewals are offset by a random fraction of the interval if jitter is set,
+// so that the providers are not scraped at the same time
+func (sd *ScrapingDriver) startRenewal(ctx context.Context, manager *scrapingManager) error {
+	interval := sd.providerRenewalInterval(manager.provider)
+	executor := sd.executorFactory(interval, manager.log)
+
+	offset := sd.renewalOffset(interval)
+	if offset <= 0 {
+		return executor.Execute(ctx, sd.renew(manager))
+	}
+
+	manager.log.Debug("offsetting the periodic renewals", map[string]interface{}{"offset": offset})
+	go sd.renew(manager)(ctx)
+
+	go func() {
+		select {
+		case <-sd.after(offset):
+			if err := executor.Execute(ctx, skipFirstRun(sd.renew(manager))); err != nil {
+				sd.errorHandler.Handle(errors.WrapIfWithDetails(err, "failed to scrape cloud information", "provider", manager.provider))
+			}
+		case <-ctx.Done():
+		}
+	}()
+
+	return nil
+}
+
+// renewalOffset returns the random offset of the periodic renewals with the given interval, zero if jitter is not set
+func (sd *ScrapingDriver) renewalOffset(interval time.Duration) time.Duration {
+	if sd.jitter <= 0 {
+		return 0
+	}
+
+	return time.Duration(sd.jitter * sd.random() * float64(interval))
+}
+
+// skipFirstRun creates a task skipping the first run of the passed in task
+// executors run the task right away, that run is skipped as the provider was scraped when the renewal was started
+func skipFirstRun(task TaskFn) TaskFn {
+	var started int32
+
+	return func(ctx context.Context) {
+		if atomic.CompareAndSwapInt32(&started, 0, 1) {
+			return
+		}
+
+		task(ctx)
 	}
 }
 
+// renew creates the task renewing the provider of the manager
+func (sd *ScrapingDriver) renew(manager *scrapingManager) TaskFn {
+	return func(ctx context.Context) {
+		// scrape failures are handled by the manager
+		_ = sd.scrape(ctx, manager)
+	}
+}
+
+// scrape scrapes the provider of the manager once the number of providers scraped in parallel allows it
+func (sd *ScrapingDriver) scrape(ctx context.Context, manager *scrapingManager) error {
+	if sd.scrapeSlots != nil {
+		select {
+		case sd.scrapeSlots <- struct{}{}:
+			defer func() { <-sd.scrapeSlots }()
+		case <-ctx.Done():
+			return errors.WithDetails(ctx.Err(), "provider", manager.provider)
+		}
+	}
+
+	return manager.scrape(ctx)
+}
+
+// shortLivedExecutor creates the executor renewing the short lived (spot) prices
+func (sd *ScrapingDriver) shortLivedExecutor() Executor {
+	return sd.executorFactory(sd.shortLivedInterval, sd.log)
+}
+
+// providerRenewalInterval returns the renewal interval of the provider, falling back to the default one
+func (sd *ScrapingDriver) providerRenewalInterval(provider string) time.Duration {
+	if interval, ok := sd.renewalIntervals[provider]; ok && interval > 0 {
+		return interval
+	}
+
+	return sd.renewalInterval
+}
+
+// renewShortLived starts scraping the short lived prices of the providers without waiting for the scrapes
 func (sd *ScrapingDriver) renewShortLived(ctx context.Context) {
+	go sd.scrapeShortLived(ctx)
+}
+
+// scrapeShortLived scrapes the short lived prices of the providers in parallel and waits for the scrapes to complete
+func (sd *ScrapingDriver) scrapeShortLived(ctx context.Context) {
+	var wg sync.WaitGroup
 	for _, manager := range sd.scrapingManagers {
-		if !manager.infoer.HasShortLivedPriceInfo() {
-			// the manager's logger is used here - that has the provider in it's context
-			manager.log.Debug("skip scraping for short lived prices (not applicable for provider)")
-			continue
-		}
-		go manager.scrapePricesInAllRegions(ctx)
+		wg.Add(1)
+		go func(manager *scrapingManager) {
+			defer wg.Done()
+
+			if manager.hasShortLivedPriceInfo() {
+				manager.scrapePricesInAllRegions(ctx)
+			} else {
+				// the manager's logger is used here - that has the provider in it's context
+				manager.log.Debug("skip scraping for short lived prices (not applicable for provider)")
+			}
+
+			// the price ages are reported even if the prices couldn't be scraped, so that stale prices can be alerted on
+			manager.reportPriceAges(time.Now())
+		}(manager)
 	}
+	wg.Wait()
 }
 
 func (sd *ScrapingDriver) RefreshProvider(ctx context.Context, provider string) {
 	for _, manager := range sd.scrapingManagers {
 		if manager.provider == provider {
-			manager.scrape(ctx)
+			// scrape failures are handled by the manager
+			_ = sd.scrape(ctx, manager)
 		}
 	}
 }
 
+// ReloadInfoer replaces the infoer of the provider (eg.: with one using rotated credentials)
+// the infoer is replaced once the running scrapes of the provider complete, the next scrapes use the new infoer
+func (sd *ScrapingDriver) ReloadInfoer(provider string, infoer CloudInfoer) error {
+	manager, err := sd.manager(provider)
+	if err != nil {
+		return err
+	}
+
+	manager.setInfoer(infoer)
+	sd.log.Info("infoer reloaded", map[string]interface{}{"provider": provider})
+
+	return nil
+}
+
+// ReconfigureProvider replaces the credentials of the provider's infoer, if the infoer supports it
+// the credentials are replaced once the running scrapes of the provider complete
+func (sd *ScrapingDriver) ReconfigureProvider(provider string, creds Credentials) error {
+	manager, err := sd.manager(provider)
+	if err != nil {
+		return err
+	}
+
+	return manager.reconfigure(creds)
+}
+
+// ForEachProvider calls the function with the infoer of every scraped provider in the order of the provider names
+// the infoers are not replaced while the function is called with them, the iteration stops at the first error
+// the function must not reload or reconfigure the provider it's called for
+func (sd *ScrapingDriver) ForEachProvider(fn func(name string, infoer CloudInfoer) error) error {
+	managers := make([]*scrapingManager, len(sd.scrapingManagers))
+	copy(managers, sd.scrapingManagers)
+	sort.Slice(managers, func(i, j int) bool {
+		return managers[i].provider < managers[j].provider
+	})
+
+	for _, manager := range managers {
+		if err := manager.withInfoer(fn); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// manager returns the scraping manager of the provider
+func (sd *ScrapingDriver) manager(provider string) (*scrapingManager, error) {
+	for _, manager := range sd.scrapingManagers {
+		if manager.provider == provider {
+			return manager, nil
+		}
+	}
+
+	return nil, errors.NewWithDetails("provider is not scraped", "provider", provider)
+}
+
+// ScrapeNow scrapes the given provider - or all the providers if it's empty - and returns once the store is updated
+func (sd *ScrapingDriver) ScrapeNow(ctx context.Context, provider string) error {
+	managers := make([]*scrapingManager, 0, len(sd.scrapingManagers))
+	for _, manager := range sd.scrapingManagers {
+		if provider == "" || manager.provider == provider {
+			managers = append(managers, manager)
+		}
+	}
+
+	if len(managers) == 0 {
+		return errors.NewWithDetails("provider is not scraped", "provider", provider)
+	}
+
+	return sd.scrapeAll(ctx, managers)
+}
+
+// RunOnce runs a single scrape cycle for every provider and waits for it to complete
+// the returned error holds the failures of all the providers
+func (sd *ScrapingDriver) RunOnce(ctx context.Context) error {
+	return sd.scrapeAll(ctx, sd.scrapingManagers)
+}
+
+// ScrapeOnceAndReturn runs a single scrape cycle of the cloud information and the short lived prices of every provider
+// and returns once both are complete, it's meant for one-off jobs not renewing the information periodically
+// the returned error holds the failures of all the providers
+func (sd *ScrapingDriver) ScrapeOnceAndReturn(ctx context.Context) error {
+	scrapeErr := sd.scrapeAll(ctx, sd.scrapingManagers)
+
+	sd.scrapeShortLived(ctx)
+
+	return scrapeErr
+}
+
+// scrapeAll scrapes the providers of the managers in parallel and collects their failures
+func (sd *ScrapingDriver) scrapeAll(ctx context.Context, managers []*scrapingManager) error {
+	var (
+		scrapeErr error
+		mu        sync.Mutex
+		wg        sync.WaitGroup
+	)
+	for _, manager := range managers {
+		wg.Add(1)
+		go func(manager *scrapingManager) {
+			defer wg.Done()
+
+			if err := sd.scrape(ctx, manager); err != nil {
+				mu.Lock()
+				scrapeErr = errors.Append(scrapeErr, errors.WrapIff(err, "failed to scrape provider %s", manager.provider))
+				mu.Unlock()
+			}
+		}(manager)
+	}
+	wg.Wait()
+
+	return scrapeErr
+}
+
+// NewScrapingDriver creates a scraping driver renewing all the providers with the same interval and the default options
+// an error is returned if there are no infoers or any of them is nil
 func NewScrapingDriver(renewalInterval time.Duration,
 	infoers map[string]CloudInfoer,
 	store CloudInfoStore,
@@ -398,17 +1318,73 @@ func NewScrapingDriver(renewalInterval time.Duration,
 	metrics metrics.Reporter,
 	tracer tracing.Tracer,
 	errorHandler ErrorHandler,
-	log Logger) *ScrapingDriver {
+	log Logger) (*ScrapingDriver, error) {
+	return NewScrapingDriverWithOptions(renewalInterval, infoers, store, eventBus, metrics, tracer, errorHandler, log, ScrapingOptions{})
+}
+
+// NewScrapingDriverWithOptions creates a scraping driver with the given options, see ScrapingOptions
+// an error is returned if there are no infoers or any of them is nil
+func NewScrapingDriverWithOptions(renewalInterval time.Duration,
+	infoers map[string]CloudInfoer,
+	store CloudInfoStore,
+	eventBus messaging.EventBus,
+	metrics metrics.Reporter,
+	tracer tracing.Tracer,
+	errorHandler ErrorHandler,
+	log Logger,
+	options ScrapingOptions) (*ScrapingDriver, error) {
+	if len(infoers) == 0 {
+		return nil, errors.WithStack(ErrNoInfoer)
+	}
+
+	for provider, infoer := range infoers {
+		if infoer == nil {
+			return nil, errors.WithDetails(ErrNoInfoer, "provider", provider)
+		}
+	}
+
 	managers := make([]*scrapingManager, 0, len(infoers))
 
+	shortLivedInterval := options.ShortLivedInterval
+	if shortLivedInterval == 0 {
+		shortLivedInterval = defaultShortLivedInterval
+	}
+
+	executorFactory := options.ExecutorFactory
+	if executorFactory == nil {
+		executorFactory = NewPeriodicExecutor
+	}
+
+	// the periodic renewals are offset by at most one interval
+	jitter := options.Jitter
+	if jitter < 0 {
+		jitter = 0
+	} else if jitter > 1 {
+		jitter = 1
+	}
+
+	var scrapeSlots chan struct{}
+	if options.ProviderConcurrency > 0 {
+		scrapeSlots = make(chan struct{}, options.ProviderConcurrency)
+	}
+
 	for provider, infoer := range infoers {
-		managers = append(managers, NewScrapingManager(provider, infoer, store, log, metrics, tracer, eventBus, errorHandler))
+		managers = append(managers, NewScrapingManager(provider, infoer, store, log, metrics, tracer, eventBus, errorHandler, options))
 	}
 
 	return &ScrapingDriver{
 		scrapingManagers: managers,
 		renewalInterval:  renewalInterval,
-		errorHandler:     errorHandler,
-		log:              log.WithFields(map[string]interface{}{"component": "scraping-driver"}),
-	}
+		renewalIntervals: options.RenewalIntervals,
+
+		shortLivedInterval: shortLivedInterval,
+		executorFactory:    executorFactory,
+		scrapeSlots:        scrapeSlots,
+		errorHandler:       errorHandler,
+		log:                log.WithFields(map[string]interface{}{"component": "scraping-driver"}),
+
+		jitter: jitter,
+		random: rand.Float64,
+		after:  time.After,
+	}, nil
 }
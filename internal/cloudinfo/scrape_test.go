@@ -0,0 +1,1674 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudinfo
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/banzaicloud/cloudinfo/internal/app/cloudinfo/messaging"
+	"github.com/banzaicloud/cloudinfo/internal/app/cloudinfo/tracing"
+	"github.com/banzaicloud/cloudinfo/internal/cloudinfo/metrics"
+	"github.com/banzaicloud/cloudinfo/internal/cloudinfo/types"
+)
+
+const (
+	cancelScrape     = "cancelScrape"
+	concurrentScrape = "concurrentScrape"
+	failingRegion    = "failingRegion"
+	throttledRegion  = "throttledRegion"
+	failingRegions   = "failingRegions"
+)
+
+// throttlingError is a transient infoer error
+type throttlingError struct{}
+
+func (throttlingError) Error() string {
+	return "request throttled"
+}
+
+func (throttlingError) Retryable() bool {
+	return true
+}
+
+// DummyCloudInfoer type implements the CloudInfoer interface for mocking of external calls
+// the struct is to be extended according to the needs of test cases
+type DummyCloudInfoer struct {
+	TcId string
+	// cancel is called when the zones of the first region are retrieved in the cancelScrape test case
+	cancel context.CancelFunc
+
+	mu sync.Mutex
+	// productRegions holds the regions products were requested for
+	productRegions []string
+	// inFlight and maxInFlight track the number of parallel product requests
+	inFlight    int
+	maxInFlight int
+	// attempts holds the number of product requests per region
+	attempts map[string]int
+
+	// implement the interface
+	CloudInfoer
+}
+
+func (dci *DummyCloudInfoer) Initialize() (map[string]map[string]types.Price, error) {
+	return nil, nil
+}
+
+func (dci *DummyCloudInfoer) GetRegions(service string) (map[string]string, error) {
+	if dci.TcId == failingRegions {
+		return nil, errors.New("failed to retrieve regions")
+	}
+
+	return map[string]string{
+		"dummyRegion1": "Dummy Region 1",
+		"dummyRegion2": "Dummy Region 2",
+		"dummyRegion3": "Dummy Region 3",
+	}, nil
+}
+
+func (dci *DummyCloudInfoer) GetZones(region string) ([]string, error) {
+	if dci.TcId == cancelScrape {
+		dci.cancel()
+	}
+
+	return []string{region + "a", region + "b"}, nil
+}
+
+func (dci *DummyCloudInfoer) GetProducts(vms []types.VMInfo, service, regionId string) ([]types.VMInfo, error) {
+	dci.mu.Lock()
+	dci.productRegions = append(dci.productRegions, regionId)
+	if dci.attempts == nil {
+		dci.attempts = make(map[string]int)
+	}
+	dci.attempts[regionId]++
+	attempt := dci.attempts[regionId]
+	dci.inFlight++
+	if dci.inFlight > dci.maxInFlight {
+		dci.maxInFlight = dci.inFlight
+	}
+	dci.mu.Unlock()
+
+	defer func() {
+		dci.mu.Lock()
+		dci.inFlight--
+		dci.mu.Unlock()
+	}()
+
+	if dci.TcId == failingRegion && regionId == "dummyRegion2" {
+		return nil, errors.New("failed to retrieve products")
+	}
+
+	if dci.TcId == throttledRegion && regionId == "dummyRegion2" && attempt <= 2 {
+		return nil, throttlingError{}
+	}
+
+	if dci.TcId == concurrentScrape {
+		// give the sibling regions the chance to be scraped in parallel
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	return []types.VMInfo{
+		{
+			Type:          "dummyType1",
+			OnDemandPrice: 0.32,
+			Cpus:          2,
+			Mem:           32,
+		},
+	}, nil
+}
+
+func (dci *DummyCloudInfoer) HasImages() bool {
+	return false
+}
+
+func (dci *DummyCloudInfoer) HasZones() bool {
+	return true
+}
+
+func (dci *DummyCloudInfoer) HasVersions() bool {
+	return true
+}
+
+func (dci *DummyCloudInfoer) HasReserved() bool {
+	return false
+}
+
+func (dci *DummyCloudInfoer) GetVersions(service, region string) ([]types.LocationVersion, error) {
+	return nil, nil
+}
+
+func (dci *DummyCloudInfoer) HasShortLivedPriceInfo() bool {
+	return true
+}
+
+func (dci *DummyCloudInfoer) PriceRegionService() string {
+	return DefaultPriceRegionService
+}
+
+func (dci *DummyCloudInfoer) GetCurrentPrices(region string) (map[string]types.Price, error) {
+	return map[string]types.Price{
+		"dummyType1": {
+			OnDemandPrice: 0.32,
+			SpotPrice:     types.SpotPriceInfo{region + "a": 0.112},
+		},
+	}, nil
+}
+
+// requestedProductRegions returns the regions products were requested for
+func (dci *DummyCloudInfoer) requestedProductRegions() []string {
+	dci.mu.Lock()
+	defer dci.mu.Unlock()
+
+	return append([]string(nil), dci.productRegions...)
+}
+
+// spotPriceHistoryCloudInfoer is a DummyCloudInfoer able to retrieve spot price history
+type spotPriceHistoryCloudInfoer struct {
+	DummyCloudInfoer
+	history []types.TimestampedPrice
+}
+
+func (shi *spotPriceHistoryCloudInfoer) GetSpotPriceHistory(region, instanceType string, window time.Duration) ([]types.TimestampedPrice, error) {
+	return shi.history, nil
+}
+
+// reservedPriceCloudInfoer is a DummyCloudInfoer able to retrieve reserved prices
+type reservedPriceCloudInfoer struct {
+	DummyCloudInfoer
+}
+
+func (rpi *reservedPriceCloudInfoer) HasReserved() bool {
+	return true
+}
+
+func (rpi *reservedPriceCloudInfoer) GetReservedPrices(region string) (map[string]map[string]float64, error) {
+	return map[string]map[string]float64{
+		"dummyType1": {"1yr-all-upfront": 0.2, "3yr-no-upfront": 0.15},
+	}, nil
+}
+
+// priceServiceCloudInfoer is a DummyCloudInfoer naming its compute service differently
+type priceServiceCloudInfoer struct {
+	DummyCloudInfoer
+	services []string
+}
+
+func (psi *priceServiceCloudInfoer) PriceRegionService() string {
+	return "vm"
+}
+
+func (psi *priceServiceCloudInfoer) GetRegions(service string) (map[string]string, error) {
+	psi.mu.Lock()
+	psi.services = append(psi.services, service)
+	psi.mu.Unlock()
+
+	if service != "vm" {
+		return nil, nil
+	}
+
+	return psi.DummyCloudInfoer.GetRegions(service)
+}
+
+// slowCloudInfoer is a DummyCloudInfoer taking its time to retrieve the zones
+type slowCloudInfoer struct {
+	DummyCloudInfoer
+	delay time.Duration
+}
+
+func (sci *slowCloudInfoer) GetZones(region string) ([]string, error) {
+	time.Sleep(sci.delay)
+
+	return sci.DummyCloudInfoer.GetZones(region)
+}
+
+// testCloudInfoStore is a map backed CloudInfoStore for exercising the scraping logic
+type testCloudInfoStore struct {
+	mu    sync.Mutex
+	items map[string]interface{}
+
+	// implement the interface
+	CloudInfoStore
+}
+
+func newTestCloudInfoStore() *testCloudInfoStore {
+	return &testCloudInfoStore{items: make(map[string]interface{})}
+}
+
+func (s *testCloudInfoStore) set(key string, val interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.items[key] = val
+}
+
+func (s *testCloudInfoStore) get(key string) (interface{}, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	val, ok := s.items[key]
+	return val, ok
+}
+
+func (s *testCloudInfoStore) delete(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.items, key)
+}
+
+func (s *testCloudInfoStore) StoreRegions(provider, service string, val map[string]string) {
+	s.set(fmt.Sprintf(RegionKeyTemplate, provider, service), val)
+}
+
+func (s *testCloudInfoStore) GetRegions(provider, service string) (map[string]string, bool) {
+	if val, ok := s.get(fmt.Sprintf(RegionKeyTemplate, provider, service)); ok {
+		return val.(map[string]string), true
+	}
+	return nil, false
+}
+
+func (s *testCloudInfoStore) DeleteRegions(provider, service string) {
+	s.delete(fmt.Sprintf(RegionKeyTemplate, provider, service))
+}
+
+func (s *testCloudInfoStore) StoreZones(provider, service, region string, val []string) {
+	s.set(fmt.Sprintf(ZoneKeyTemplate, provider, service, region), val)
+}
+
+func (s *testCloudInfoStore) GetZones(provider, service, region string) ([]string, bool) {
+	if val, ok := s.get(fmt.Sprintf(ZoneKeyTemplate, provider, service, region)); ok {
+		return val.([]string), true
+	}
+	return nil, false
+}
+
+func (s *testCloudInfoStore) DeleteZones(provider, service, region string) {
+	s.delete(fmt.Sprintf(ZoneKeyTemplate, provider, service, region))
+}
+
+func (s *testCloudInfoStore) StorePrice(provider, region, instanceType string, val types.Price) {
+	s.set(fmt.Sprintf(PriceKeyTemplate, provider, region, instanceType), val)
+}
+
+func (s *testCloudInfoStore) StorePrices(provider, region string, prices map[string]types.Price) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for instanceType, price := range prices {
+		s.items[fmt.Sprintf(PriceKeyTemplate, provider, region, instanceType)] = price
+	}
+}
+
+func (s *testCloudInfoStore) GetPrice(provider, region, instanceType string) (types.Price, bool) {
+	if val, ok := s.get(fmt.Sprintf(PriceKeyTemplate, provider, region, instanceType)); ok {
+		return val.(types.Price), true
+	}
+	return types.Price{}, false
+}
+
+func (s *testCloudInfoStore) StoreSpotPriceHistory(provider, region, instanceType string, val []types.TimestampedPrice) {
+	s.set(fmt.Sprintf(SpotPriceHistoryKeyTemplate, provider, region, instanceType), val)
+}
+
+func (s *testCloudInfoStore) GetSpotPriceHistory(provider, region, instanceType string) ([]types.TimestampedPrice, bool) {
+	if val, ok := s.get(fmt.Sprintf(SpotPriceHistoryKeyTemplate, provider, region, instanceType)); ok {
+		return val.([]types.TimestampedPrice), true
+	}
+	return nil, false
+}
+
+func (s *testCloudInfoStore) StoreVm(provider, service, region string, val []types.VMInfo) {
+	s.set(fmt.Sprintf(VmKeyTemplate, provider, service, region), val)
+}
+
+func (s *testCloudInfoStore) GetVm(provider, service, region string) ([]types.VMInfo, bool) {
+	if val, ok := s.get(fmt.Sprintf(VmKeyTemplate, provider, service, region)); ok {
+		return val.([]types.VMInfo), true
+	}
+	return nil, false
+}
+
+func (s *testCloudInfoStore) DeleteVm(provider, service, region string) {
+	s.delete(fmt.Sprintf(VmKeyTemplate, provider, service, region))
+}
+
+func (s *testCloudInfoStore) StoreRegionProducts(provider, service, region string, val types.RegionProducts) {
+	s.set(fmt.Sprintf(ProductsKeyTemplate, provider, service, region), val)
+}
+
+func (s *testCloudInfoStore) GetRegionProducts(provider, service, region string) (types.RegionProducts, bool) {
+	if val, ok := s.get(fmt.Sprintf(ProductsKeyTemplate, provider, service, region)); ok {
+		return val.(types.RegionProducts), true
+	}
+	return types.RegionProducts{}, false
+}
+
+func (s *testCloudInfoStore) StoreCatalogChanges(provider, service, region string, val types.CatalogChanges) {
+	s.set(fmt.Sprintf(CatalogChangesKeyTemplate, provider, service, region), val)
+}
+
+func (s *testCloudInfoStore) GetCatalogChanges(provider, service, region string) (types.CatalogChanges, bool) {
+	if val, ok := s.get(fmt.Sprintf(CatalogChangesKeyTemplate, provider, service, region)); ok {
+		return val.(types.CatalogChanges), true
+	}
+	return types.CatalogChanges{}, false
+}
+
+func (s *testCloudInfoStore) StoreImage(provider, service, regionId string, val []types.Image) {
+	s.set(fmt.Sprintf(ImageKeyTemplate, provider, service, regionId), val)
+}
+
+func (s *testCloudInfoStore) GetImage(provider, service, regionId string) ([]types.Image, bool) {
+	if val, ok := s.get(fmt.Sprintf(ImageKeyTemplate, provider, service, regionId)); ok {
+		return val.([]types.Image), true
+	}
+	return nil, false
+}
+
+func (s *testCloudInfoStore) DeleteImage(provider, service, regionId string) {
+	s.delete(fmt.Sprintf(ImageKeyTemplate, provider, service, regionId))
+}
+
+func (s *testCloudInfoStore) StoreVersion(provider, service, region string, val []types.LocationVersion) {
+	s.set(fmt.Sprintf(VersionKeyTemplate, provider, service, region), val)
+}
+
+func (s *testCloudInfoStore) GetVersion(provider, service, region string) ([]types.LocationVersion, bool) {
+	if val, ok := s.get(fmt.Sprintf(VersionKeyTemplate, provider, service, region)); ok {
+		return val.([]types.LocationVersion), true
+	}
+	return nil, false
+}
+
+func (s *testCloudInfoStore) DeleteVersion(provider, service, region string) {
+	s.delete(fmt.Sprintf(VersionKeyTemplate, provider, service, region))
+}
+
+func (s *testCloudInfoStore) StoreStatus(provider string, val string) {
+	s.set(fmt.Sprintf(StatusKeyTemplate, provider), val)
+}
+
+func (s *testCloudInfoStore) GetStatus(provider string) (string, bool) {
+	if val, ok := s.get(fmt.Sprintf(StatusKeyTemplate, provider)); ok {
+		return val.(string), true
+	}
+	return "", false
+}
+
+func (s *testCloudInfoStore) StoreServices(provider string, services []types.Service) {
+	s.set(fmt.Sprintf(ServicesKeyTemplate, provider), services)
+}
+
+func (s *testCloudInfoStore) GetServices(provider string) ([]types.Service, bool) {
+	if val, ok := s.get(fmt.Sprintf(ServicesKeyTemplate, provider)); ok {
+		return val.([]types.Service), true
+	}
+	return nil, false
+}
+
+func (s *testCloudInfoStore) StoreScrapeErrors(provider string, val []types.ScrapeError) {
+	s.set(fmt.Sprintf(ScrapeErrorsKeyTemplate, provider), val)
+}
+
+func (s *testCloudInfoStore) GetScrapeErrors(provider string) ([]types.ScrapeError, bool) {
+	if val, ok := s.get(fmt.Sprintf(ScrapeErrorsKeyTemplate, provider)); ok {
+		return val.([]types.ScrapeError), true
+	}
+	return nil, false
+}
+
+// dummyErrorHandler collects the handled errors
+type dummyErrorHandler struct {
+	mu   sync.Mutex
+	errs []error
+}
+
+func (eh *dummyErrorHandler) Handle(err error) {
+	eh.mu.Lock()
+	defer eh.mu.Unlock()
+
+	eh.errs = append(eh.errs, err)
+}
+
+func newTestScrapingManager(infoer CloudInfoer, store CloudInfoStore, regionConcurrency int) *scrapingManager {
+	return NewScrapingManager("dummyProvider", infoer, store, cloudinfoLogger, metrics.NewNoOpMetricsReporter(),
+		tracing.NewNoOpTracer(), messaging.NewDefaultEventBus(nil), &dummyErrorHandler{},
+		ScrapingOptions{RegionConcurrency: regionConcurrency, RetryMaxAttempts: 3, RetryBaseDelay: time.Millisecond})
+}
+
+func TestScrapingManager_scrapeServiceRegionInfo(t *testing.T) {
+	tests := []struct {
+		name        string
+		infoer      *DummyCloudInfoer
+		concurrency int
+		checker     func(infoer *DummyCloudInfoer, store *testCloudInfoStore, err error)
+	}{
+		{
+			name:        "all the regions are scraped",
+			infoer:      &DummyCloudInfoer{},
+			concurrency: 1,
+			checker: func(infoer *DummyCloudInfoer, store *testCloudInfoStore, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.ElementsMatch(t, []string{"dummyRegion1", "dummyRegion2", "dummyRegion3"}, infoer.requestedProductRegions())
+				for _, region := range []string{"dummyRegion1", "dummyRegion2", "dummyRegion3"} {
+					vms, ok := store.GetVm("dummyProvider", "compute", region)
+					assert.True(t, ok, "the vms should be cached")
+					assert.Equal(t, 1, len(vms))
+				}
+			},
+		},
+		{
+			name:        "cancelled scrape stops walking the regions",
+			infoer:      &DummyCloudInfoer{TcId: cancelScrape},
+			concurrency: 1,
+			checker: func(infoer *DummyCloudInfoer, store *testCloudInfoStore, err error) {
+				assert.True(t, errors.Is(err, context.Canceled), "the error should be the context error")
+				assert.Equal(t, 1, len(infoer.requestedProductRegions()))
+			},
+		},
+		{
+			name:        "a failing region doesn't prevent scraping the others",
+			infoer:      &DummyCloudInfoer{TcId: failingRegion},
+			concurrency: 1,
+			checker: func(infoer *DummyCloudInfoer, store *testCloudInfoStore, err error) {
+				assert.EqualError(t, err, "failed to retrieve products for region: failed to retrieve products")
+				assert.ElementsMatch(t, []string{"dummyRegion1", "dummyRegion2", "dummyRegion3"}, infoer.requestedProductRegions())
+				for _, region := range []string{"dummyRegion1", "dummyRegion3"} {
+					_, ok := store.GetVm("dummyProvider", "compute", region)
+					assert.True(t, ok, "the vms should be cached")
+				}
+				_, ok := store.GetVm("dummyProvider", "compute", "dummyRegion2")
+				assert.False(t, ok, "the vms of the failing region should not be cached")
+				assert.Equal(t, 1, infoer.attempts["dummyRegion2"], "non retryable errors should not be retried")
+
+				scrapeErrors, ok := store.GetScrapeErrors("dummyProvider")
+				assert.True(t, ok, "the scrape errors should be stored")
+				assert.Equal(t, 1, len(scrapeErrors))
+				assert.Equal(t, "compute", scrapeErrors[0].Service)
+				assert.Equal(t, "dummyRegion2", scrapeErrors[0].Region)
+				assert.Equal(t, "failed to retrieve products for region: failed to retrieve products", scrapeErrors[0].Message)
+			},
+		},
+		{
+			name:        "throttled calls are retried",
+			infoer:      &DummyCloudInfoer{TcId: throttledRegion},
+			concurrency: 1,
+			checker: func(infoer *DummyCloudInfoer, store *testCloudInfoStore, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, 3, infoer.attempts["dummyRegion2"])
+				vms, ok := store.GetVm("dummyProvider", "compute", "dummyRegion2")
+				assert.True(t, ok, "the vms should be cached")
+				assert.Equal(t, 1, len(vms))
+			},
+		},
+		{
+			name:        "regions are scraped in parallel up to the concurrency limit",
+			infoer:      &DummyCloudInfoer{TcId: concurrentScrape},
+			concurrency: 2,
+			checker: func(infoer *DummyCloudInfoer, store *testCloudInfoStore, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.ElementsMatch(t, []string{"dummyRegion1", "dummyRegion2", "dummyRegion3"}, infoer.requestedProductRegions())
+				assert.Equal(t, 2, infoer.maxInFlight)
+			},
+		},
+		{
+			name:        "regions are scraped in parallel without limit if no concurrency is set",
+			infoer:      &DummyCloudInfoer{TcId: concurrentScrape},
+			concurrency: 0,
+			checker: func(infoer *DummyCloudInfoer, store *testCloudInfoStore, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, 3, infoer.maxInFlight)
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			test.infoer.cancel = cancel
+
+			store := newTestCloudInfoStore()
+			sm := newTestScrapingManager(test.infoer, store, test.concurrency)
+			test.checker(test.infoer, store, sm.scrapeServiceRegionInfo(ctx, []types.Service{{Service: "compute"}}))
+		})
+	}
+}
+
+// recordingLogger records the fields of the info messages
+type recordingLogger struct {
+	noOpLogger
+
+	mu    sync.Mutex
+	infos map[string]map[string]interface{}
+}
+
+func (l *recordingLogger) Info(msg string, fields ...map[string]interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if len(fields) > 0 {
+		l.infos[msg] = fields[0]
+	}
+}
+
+// initializingCloudInfoer returns the prices of two regions on initialization
+type initializingCloudInfoer struct {
+	DummyCloudInfoer
+}
+
+func (ici *initializingCloudInfoer) Initialize() (map[string]map[string]types.Price, error) {
+	return map[string]map[string]types.Price{
+		"dummyRegion1": {"dummyType1": {OnDemandPrice: 0.32}, "dummyType2": {OnDemandPrice: 0.52}},
+		"dummyRegion2": {"dummyType1": {OnDemandPrice: 0.33}},
+	}, nil
+}
+
+func TestScrapingManager_scrapeCountLogs(t *testing.T) {
+	logger := &recordingLogger{infos: make(map[string]map[string]interface{})}
+	sm := newTestScrapingManager(&initializingCloudInfoer{DummyCloudInfoer{TcId: failingRegion}}, newTestCloudInfoStore(), 2)
+	sm.log = logger
+
+	assert.Nil(t, sm.initialize(context.Background()))
+	assert.Equal(t, map[string]interface{}{"regions": 2, "prices": 3}, logger.infos["finished initializing cloud product information"])
+
+	assert.Error(t, sm.scrapeServiceRegionInfo(context.Background(), []types.Service{{Service: "compute"}}))
+	assert.Equal(t, map[string]interface{}{"service": "compute", "regions": 2, "products": 2},
+		logger.infos["finished scraping service region information"], "only the successfully scraped regions should be counted")
+}
+
+// limitedCloudInfoer is a DummyCloudInfoer without zone and version support, recording the unsupported calls
+type limitedCloudInfoer struct {
+	DummyCloudInfoer
+
+	unsupportedCalls int32
+}
+
+func (lci *limitedCloudInfoer) HasZones() bool {
+	return false
+}
+
+func (lci *limitedCloudInfoer) GetZones(region string) ([]string, error) {
+	atomic.AddInt32(&lci.unsupportedCalls, 1)
+	return nil, errors.New("zones are not supported")
+}
+
+func (lci *limitedCloudInfoer) HasVersions() bool {
+	return false
+}
+
+func (lci *limitedCloudInfoer) GetVersions(service, region string) ([]types.LocationVersion, error) {
+	atomic.AddInt32(&lci.unsupportedCalls, 1)
+	return nil, errors.New("versions are not supported")
+}
+
+func TestScrapingManager_scrapeServiceRegionInfo_capabilities(t *testing.T) {
+	infoer := &limitedCloudInfoer{}
+	store := newTestCloudInfoStore()
+	sm := newTestScrapingManager(infoer, store, 1)
+
+	err := sm.scrapeServiceRegionInfo(context.Background(), []types.Service{{Service: "compute"}})
+	assert.Nil(t, err, "the unsupported operations should be skipped")
+	assert.Equal(t, int32(0), atomic.LoadInt32(&infoer.unsupportedCalls), "the unsupported operations should not be called")
+
+	for _, region := range []string{"dummyRegion1", "dummyRegion2", "dummyRegion3"} {
+		vms, ok := store.GetVm("dummyProvider", "compute", region)
+		assert.True(t, ok, "the vms should be cached")
+		assert.Equal(t, 1, len(vms))
+
+		zones, ok := store.GetZones("dummyProvider", "compute", region)
+		assert.True(t, ok, "the region should be cached without zones")
+		assert.Empty(t, zones)
+
+		_, ok = store.GetVersion("dummyProvider", "compute", region)
+		assert.False(t, ok, "the versions should not be cached")
+	}
+}
+
+func TestScrapingManager_allowedRegions(t *testing.T) {
+	infoer := &DummyCloudInfoer{}
+	store := newTestCloudInfoStore()
+	sm := NewScrapingManager("dummyProvider", infoer, store, cloudinfoLogger, metrics.NewNoOpMetricsReporter(),
+		tracing.NewNoOpTracer(), messaging.NewDefaultEventBus(nil), &dummyErrorHandler{}, ScrapingOptions{
+			ProviderRegions:   map[string][]string{"dummyProvider": {"dummyRegion1", "dummyRegion3"}},
+			RegionConcurrency: 2,
+		})
+
+	err := sm.scrapeServiceRegionInfo(context.Background(), []types.Service{{Service: "compute"}})
+	assert.Nil(t, err, "the error should be nil")
+	assert.ElementsMatch(t, []string{"dummyRegion1", "dummyRegion3"}, infoer.requestedProductRegions(),
+		"the denied regions should never be passed to GetProducts")
+
+	regions, ok := store.GetRegions("dummyProvider", "compute")
+	assert.True(t, ok, "the regions should be cached")
+	assert.Equal(t, 3, len(regions), "every region should be reported")
+
+	_, ok = store.GetVm("dummyProvider", "compute", "dummyRegion2")
+	assert.False(t, ok, "the vms of the denied region should not be cached")
+
+	sm.scrapePricesInAllRegions(context.Background())
+	for region, allowed := range map[string]bool{"dummyRegion1": true, "dummyRegion2": false, "dummyRegion3": true} {
+		_, ok := store.GetPrice("dummyProvider", region, "dummyType1")
+		assert.Equal(t, allowed, ok, "only the prices of the allowed regions should be scraped")
+	}
+}
+
+func TestScrapingManager_concurrentReads(t *testing.T) {
+	store := newTestCloudInfoStore()
+	store.StoreServices("dummyProvider", []types.Service{{Service: "compute"}})
+	sm := newTestScrapingManager(&DummyCloudInfoer{}, store, 2)
+	info, _ := NewCloudInfo([]string{"dummyProvider"}, store, cloudinfoLogger)
+
+	assert.Nil(t, sm.scrape(context.Background()))
+	sm.scrapePricesInAllRegions(context.Background())
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 20; i++ {
+			_ = sm.scrape(context.Background())
+			sm.scrapePricesInAllRegions(context.Background())
+		}
+	}()
+
+	for {
+		select {
+		case <-done:
+			return
+		default:
+		}
+
+		details, err := info.GetProductDetails("dummyProvider", "compute", "dummyRegion1")
+		if !assert.Nil(t, err, "the products should be available while being rescraped") {
+			return
+		}
+		assert.Equal(t, 1, len(details))
+		assert.Equal(t, 0.32, details[0].OnDemandPrice)
+		assert.Equal(t, []types.ZonePrice{{Zone: "dummyRegion1a", Price: 0.112}}, details[0].SpotPrice)
+	}
+}
+
+func TestScrapingManager_clearScrapeFailure(t *testing.T) {
+	store := newTestCloudInfoStore()
+	sm := newTestScrapingManager(&DummyCloudInfoer{}, store, 1)
+
+	sm.reportScrapeFailure("compute", "dummyRegion2", errors.New("failed to retrieve products"))
+	sm.reportScrapeFailure("compute", "dummyRegion1", errors.New("failed to retrieve zones"))
+
+	scrapeErrors, _ := store.GetScrapeErrors("dummyProvider")
+	assert.Equal(t, 2, len(scrapeErrors))
+	assert.Equal(t, "dummyRegion1", scrapeErrors[0].Region, "the scrape errors should be ordered by region")
+
+	sm.clearScrapeFailure("compute", "dummyRegion1")
+
+	scrapeErrors, _ = store.GetScrapeErrors("dummyProvider")
+	assert.Equal(t, 1, len(scrapeErrors))
+	assert.Equal(t, "dummyRegion2", scrapeErrors[0].Region)
+}
+
+func TestScrapingManager_scrapePricesInRegion(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name    string
+		infoer  CloudInfoer
+		stored  []types.TimestampedPrice
+		checker func(store *testCloudInfoStore)
+	}{
+		{
+			name: "the spot price history window is rolled",
+			infoer: &spotPriceHistoryCloudInfoer{
+				history: []types.TimestampedPrice{
+					{Time: now.Add(-time.Hour), Price: 0.11},
+					{Time: now.Add(-30 * time.Minute), Price: 0.112},
+				},
+			},
+			stored: []types.TimestampedPrice{
+				{Time: now.Add(-48 * time.Hour), Price: 0.09},
+				{Time: now.Add(-2 * time.Hour), Price: 0.1},
+				{Time: now.Add(-time.Hour), Price: 0.11},
+			},
+			checker: func(store *testCloudInfoStore) {
+				history, ok := store.GetSpotPriceHistory("dummyProvider", "dummyRegion1", "dummyType1")
+				assert.True(t, ok, "the spot price history should be stored")
+				assert.Equal(t, []types.TimestampedPrice{
+					{Time: now.Add(-2 * time.Hour), Price: 0.1},
+					{Time: now.Add(-time.Hour), Price: 0.11},
+					{Time: now.Add(-30 * time.Minute), Price: 0.112},
+				}, history)
+			},
+		},
+		{
+			name:   "the spot price history is not stored for providers not supporting it",
+			infoer: &DummyCloudInfoer{},
+			checker: func(store *testCloudInfoStore) {
+				_, ok := store.GetSpotPriceHistory("dummyProvider", "dummyRegion1", "dummyType1")
+				assert.False(t, ok, "the spot price history should not be stored")
+
+				_, ok = store.GetPrice("dummyProvider", "dummyRegion1", "dummyType1")
+				assert.True(t, ok, "the price should be stored")
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			store := newTestCloudInfoStore()
+			if test.stored != nil {
+				store.StoreSpotPriceHistory("dummyProvider", "dummyRegion1", "dummyType1", test.stored)
+			}
+
+			sm := newTestScrapingManager(test.infoer, store, 1)
+			sm.scrapePricesInRegion(context.Background(), "dummyRegion1", nil)
+
+			test.checker(store)
+		})
+	}
+}
+
+// bandwidthCloudInfoer is a DummyCloudInfoer supplying the bandwidths of its qualitative network performance values
+type bandwidthCloudInfoer struct {
+	DummyCloudInfoer
+}
+
+func (i *bandwidthCloudInfoer) GetProducts(vms []types.VMInfo, service, regionId string) ([]types.VMInfo, error) {
+	return []types.VMInfo{
+		{Type: "dummyType1", OnDemandPrice: 0.32, NtwPerf: "High"},
+		{Type: "dummyType2", OnDemandPrice: 0.64, NtwPerf: "Up to 10 Gigabit"},
+		{Type: "dummyType3", OnDemandPrice: 1.28, NtwPerf: "Unknown"},
+		{Type: "dummyType4", OnDemandPrice: 2.56, NtwPerf: "High", NtwGbps: 2},
+	}, nil
+}
+
+func (i *bandwidthCloudInfoer) NetworkBandwidths() map[string]float64 {
+	return map[string]float64{"High": 1}
+}
+
+func TestScrapingManager_scrapeServiceRegionProducts_ntwGbps(t *testing.T) {
+	store := newTestCloudInfoStore()
+	sm := newTestScrapingManager(&bandwidthCloudInfoer{}, store, 1)
+
+	_, err := sm.scrapeServiceRegionProducts(context.Background(), "compute", "dummyRegion1")
+	assert.Nil(t, err, "the error should be nil")
+
+	vms, _ := store.GetVm("dummyProvider", "compute", "dummyRegion1")
+	bandwidths := make(map[string]float64)
+	for _, vm := range vms {
+		bandwidths[vm.Type] = vm.NtwGbps
+	}
+	assert.Equal(t, map[string]float64{"dummyType1": 1, "dummyType2": 10, "dummyType3": 0, "dummyType4": 2}, bandwidths,
+		"the bandwidths should be parsed unless reported by the infoer")
+}
+
+// growingCatalogCloudInfoer is a DummyCloudInfoer adding an instance type to the catalog at the second scrape
+type growingCatalogCloudInfoer struct {
+	DummyCloudInfoer
+	scrapes int32
+}
+
+func (i *growingCatalogCloudInfoer) GetProducts(vms []types.VMInfo, service, regionId string) ([]types.VMInfo, error) {
+	products := []types.VMInfo{{Type: "dummyType1", OnDemandPrice: 0.32}, {Type: "dummyType2", OnDemandPrice: 0.64}}
+	if atomic.AddInt32(&i.scrapes, 1) > 1 {
+		products = append(products[1:], types.VMInfo{Type: "dummyType3", OnDemandPrice: 1.28})
+	}
+
+	return products, nil
+}
+
+func TestScrapingManager_catalogChanges(t *testing.T) {
+	store := newTestCloudInfoStore()
+	sm := newTestScrapingManager(&growingCatalogCloudInfoer{}, store, 1)
+	info, _ := NewCloudInfo([]string{"dummyProvider"}, store, cloudinfoLogger)
+
+	_, err := sm.scrapeServiceRegionProducts(context.Background(), "compute", "dummyRegion1")
+	assert.Nil(t, err, "the error should be nil")
+	_, _, err = info.GetCatalogChanges("dummyProvider", "compute", "dummyRegion1")
+	assert.True(t, errors.Is(err, ErrNotCached), "there should be no changes recorded at the first scrape")
+
+	_, err = sm.scrapeServiceRegionProducts(context.Background(), "compute", "dummyRegion1")
+	assert.Nil(t, err, "the error should be nil")
+	added, removed, err := info.GetCatalogChanges("dummyProvider", "compute", "dummyRegion1")
+	assert.Nil(t, err, "the error should be nil")
+	assert.Equal(t, []string{"dummyType3"}, added)
+	assert.Equal(t, []string{"dummyType1"}, removed)
+
+	_, err = sm.scrapeServiceRegionProducts(context.Background(), "compute", "dummyRegion1")
+	assert.Nil(t, err, "the error should be nil")
+	added, removed, err = info.GetCatalogChanges("dummyProvider", "compute", "dummyRegion1")
+	assert.Nil(t, err, "the error should be nil")
+	assert.Empty(t, added, "only the changes of the latest scrape should be kept")
+	assert.Empty(t, removed, "only the changes of the latest scrape should be kept")
+}
+
+// emptyingCatalogCloudInfoer is a DummyCloudInfoer returning no products and prices after the first scrape
+type emptyingCatalogCloudInfoer struct {
+	DummyCloudInfoer
+	scrapes int32
+}
+
+func (i *emptyingCatalogCloudInfoer) GetProducts(vms []types.VMInfo, service, regionId string) ([]types.VMInfo, error) {
+	if atomic.AddInt32(&i.scrapes, 1) > 1 {
+		return []types.VMInfo{}, nil
+	}
+
+	return []types.VMInfo{{Type: "dummyType1", OnDemandPrice: 0.32}, {Type: "dummyType2", OnDemandPrice: 0.64}}, nil
+}
+
+func (i *emptyingCatalogCloudInfoer) GetCurrentPrices(region string) (map[string]types.Price, error) {
+	if atomic.LoadInt32(&i.scrapes) > 1 {
+		return map[string]types.Price{}, nil
+	}
+
+	return i.DummyCloudInfoer.GetCurrentPrices(region)
+}
+
+func TestScrapingManager_emptyResultsKeepCache(t *testing.T) {
+	store := newTestCloudInfoStore()
+	infoer := &emptyingCatalogCloudInfoer{}
+	sm := newTestScrapingManager(infoer, store, 1)
+
+	sm.scrapePricesInRegion(context.Background(), "dummyRegion1", nil)
+	count, err := sm.scrapeServiceRegionProducts(context.Background(), "compute", "dummyRegion1")
+	assert.Nil(t, err, "the error should be nil")
+	assert.Equal(t, 2, count)
+	prices, _ := infoer.DummyCloudInfoer.GetCurrentPrices("dummyRegion1")
+
+	count, err = sm.scrapeServiceRegionProducts(context.Background(), "compute", "dummyRegion1")
+	assert.Nil(t, err, "the error should be nil")
+	assert.Equal(t, 2, count, "the cached products should be counted")
+	sm.scrapePricesInRegion(context.Background(), "dummyRegion1", nil)
+
+	vms, ok := store.GetVm("dummyProvider", "compute", "dummyRegion1")
+	assert.True(t, ok, "the VMs should be cached")
+	assert.Equal(t, []string{"dummyType1", "dummyType2"}, []string{vms[0].Type, vms[1].Type}, "the VMs of the first scrape should survive")
+	for instanceType, price := range prices {
+		cached, ok := store.GetPrice("dummyProvider", "dummyRegion1", instanceType)
+		assert.True(t, ok, "the prices of the first scrape should survive")
+		assert.Equal(t, price.SpotPrice, cached.SpotPrice)
+	}
+}
+
+// partialSpotCloudInfoer is a DummyCloudInfoer returning the spot prices of different zones on subsequent scrapes
+type partialSpotCloudInfoer struct {
+	DummyCloudInfoer
+	scrapes int32
+}
+
+func (i *partialSpotCloudInfoer) GetCurrentPrices(region string) (map[string]types.Price, error) {
+	if atomic.AddInt32(&i.scrapes, 1) > 1 {
+		return map[string]types.Price{
+			"dummyType1": {SpotPrice: types.SpotPriceInfo{"dummyZone2": 0.121, "dummyZone3": 0.13}},
+		}, nil
+	}
+
+	return map[string]types.Price{
+		"dummyType1": {OnDemandPrice: 0.32, SpotPrice: types.SpotPriceInfo{"dummyZone1": 0.112, "dummyZone2": 0.115}},
+	}, nil
+}
+
+func TestScrapingManager_scrapePricesInRegion_mergesSpotPrices(t *testing.T) {
+	store := newTestCloudInfoStore()
+	sm := newTestScrapingManager(&partialSpotCloudInfoer{}, store, 1)
+
+	sm.scrapePricesInRegion(context.Background(), "dummyRegion1", nil)
+	sm.scrapePricesInRegion(context.Background(), "dummyRegion1", nil)
+
+	price, ok := store.GetPrice("dummyProvider", "dummyRegion1", "dummyType1")
+	assert.True(t, ok, "the price should be stored")
+	assert.Equal(t, 0.32, price.OnDemandPrice, "the on demand price of the first scrape should be kept")
+	assert.Equal(t, types.SpotPriceInfo{"dummyZone1": 0.112, "dummyZone2": 0.121, "dummyZone3": 0.13}, price.SpotPrice,
+		"the spot prices of the scrapes should be merged")
+}
+
+// spotOnlyCloudInfoer is a DummyCloudInfoer returning the unchanged spot price of a single zone without
+// on demand price after the first scrape, like the providers scraping the spot prices separately
+type spotOnlyCloudInfoer struct {
+	DummyCloudInfoer
+	scrapes int32
+}
+
+func (i *spotOnlyCloudInfoer) GetCurrentPrices(region string) (map[string]types.Price, error) {
+	if atomic.AddInt32(&i.scrapes, 1) > 1 {
+		return map[string]types.Price{
+			"dummyType1": {OnDemandPrice: -1, SpotPrice: types.SpotPriceInfo{"dummyZone1": 0.112}},
+		}, nil
+	}
+
+	return map[string]types.Price{
+		"dummyType1": {OnDemandPrice: 0.32, SpotPrice: types.SpotPriceInfo{"dummyZone1": 0.112, "dummyZone2": 0.115}},
+	}, nil
+}
+
+func TestScrapingManager_scrapePricesInRegion_spotOnlyScrapeChangesNothing(t *testing.T) {
+	eventBus := messaging.NewDefaultEventBus(nil)
+	var changes []types.PriceChangeEvent
+	eventBus.SubscribePriceChanges(func(change types.PriceChangeEvent) {
+		changes = append(changes, change)
+	})
+
+	sm := newTestScrapingManager(&spotOnlyCloudInfoer{}, newTestCloudInfoStore(), 1)
+	sm.eventBus = eventBus
+
+	sm.scrapePricesInRegion(context.Background(), "dummyRegion1", nil)
+	sm.scrapePricesInRegion(context.Background(), "dummyRegion1", nil)
+
+	assert.Empty(t, changes, "no price change should be published if the stored price is unchanged")
+}
+
+// priceCallTimesCloudInfoer is a DummyCloudInfoer recording the time of the price retrievals
+type priceCallTimesCloudInfoer struct {
+	DummyCloudInfoer
+	callsMu sync.Mutex
+	calls   []time.Time
+}
+
+func (i *priceCallTimesCloudInfoer) GetCurrentPrices(region string) (map[string]types.Price, error) {
+	i.callsMu.Lock()
+	i.calls = append(i.calls, time.Now())
+	i.callsMu.Unlock()
+
+	return i.DummyCloudInfoer.GetCurrentPrices(region)
+}
+
+func TestScrapingManager_requestRate(t *testing.T) {
+	infoer := &priceCallTimesCloudInfoer{}
+	sm := NewScrapingManager("dummyProvider", infoer, newTestCloudInfoStore(), cloudinfoLogger, metrics.NewNoOpMetricsReporter(),
+		tracing.NewNoOpTracer(), messaging.NewDefaultEventBus(nil), &dummyErrorHandler{}, ScrapingOptions{RegionConcurrency: 3, RequestRate: 20})
+
+	sm.scrapePricesInAllRegions(context.Background())
+
+	assert.Len(t, infoer.calls, 3, "the prices of every region should be retrieved")
+	sort.Slice(infoer.calls, func(i, j int) bool { return infoer.calls[i].Before(infoer.calls[j]) })
+	for i := 1; i < len(infoer.calls); i++ {
+		// 20 calls per second are allowed, some slack is given to the timer
+		assert.True(t, infoer.calls[i].Sub(infoer.calls[i-1]) >= 40*time.Millisecond, "the calls should be spaced by the rate limit")
+	}
+}
+
+// memoryAuditSink keeps the recorded prices in memory
+type memoryAuditSink struct {
+	mu      sync.Mutex
+	records map[string]types.Price
+}
+
+func (s *memoryAuditSink) RecordPrice(ts time.Time, provider, region, instanceType string, price types.Price) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.records[fmt.Sprintf("%s/%s/%s", provider, region, instanceType)] = price
+}
+
+func TestScrapingManager_auditSink(t *testing.T) {
+	sink := &memoryAuditSink{records: make(map[string]types.Price)}
+	sm := NewScrapingManager("dummyProvider", &DummyCloudInfoer{}, newTestCloudInfoStore(), cloudinfoLogger, metrics.NewNoOpMetricsReporter(),
+		tracing.NewNoOpTracer(), messaging.NewDefaultEventBus(nil), &dummyErrorHandler{}, ScrapingOptions{RegionConcurrency: 3, AuditSink: sink})
+
+	sm.scrapePricesInAllRegions(context.Background())
+
+	assert.Len(t, sink.records, 3, "the price of every region should be recorded")
+	assert.Equal(t, types.SpotPriceInfo{"dummyRegion2a": 0.112}, sink.records["dummyProvider/dummyRegion2/dummyType1"].SpotPrice)
+}
+
+// gpuCloudInfoer is a DummyCloudInfoer declaring the gpu attribute
+type gpuCloudInfoer struct {
+	DummyCloudInfoer
+}
+
+func (i *gpuCloudInfoer) Attributes() []string {
+	return []string{types.CPU, types.Memory, types.GPU}
+}
+
+func (i *gpuCloudInfoer) GetProducts(vms []types.VMInfo, service, regionId string) ([]types.VMInfo, error) {
+	return []types.VMInfo{
+		{Type: "dummyType1", Cpus: 4, Mem: 16, OnDemandPrice: 0.2},
+		{Type: "dummyType2", Cpus: 8, Mem: 61, Gpus: 1, OnDemandPrice: 0.9},
+		{Type: "dummyType3", Cpus: 32, Mem: 244, Gpus: 4, OnDemandPrice: 7.2},
+	}, nil
+}
+
+func TestScrapingManager_declaredAttributes(t *testing.T) {
+	store := newTestCloudInfoStore()
+	store.StoreRegions("dummyProvider", "compute", map[string]string{"dummyRegion1": "Dummy Region 1"})
+	sm := newTestScrapingManager(&gpuCloudInfoer{}, store, 1)
+
+	_, err := sm.scrapeServiceRegionProducts(context.Background(), "compute", "dummyRegion1")
+	assert.Nil(t, err, "the error should be nil")
+
+	vms, ok := store.GetVm("dummyProvider", "compute", "dummyRegion1")
+	assert.True(t, ok, "the VMs should be cached")
+	gpus := make(map[string]string)
+	for _, vm := range vms {
+		gpus[vm.Type] = vm.Attributes[types.GPU]
+	}
+	assert.Equal(t, map[string]string{"dummyType1": "0", "dummyType2": "1", "dummyType3": "4"}, gpus)
+
+	info, _ := NewCloudInfo([]string{"dummyProvider"}, store, cloudinfoLogger)
+	min, max, count, err := info.GetAttrRange("dummyProvider", "compute", types.GPU)
+	assert.Nil(t, err, "the error should be nil")
+	assert.Equal(t, 0.0, min)
+	assert.Equal(t, 4.0, max)
+	assert.Equal(t, 3, count)
+}
+
+// failingPriceCloudInfoer is a DummyCloudInfoer failing the price retrieval while the fail flag is set
+type failingPriceCloudInfoer struct {
+	DummyCloudInfoer
+	fail  int32
+	calls int32
+}
+
+func (i *failingPriceCloudInfoer) GetCurrentPrices(region string) (map[string]types.Price, error) {
+	atomic.AddInt32(&i.calls, 1)
+	if atomic.LoadInt32(&i.fail) == 1 {
+		return nil, errors.New("pricing API is down")
+	}
+
+	return i.DummyCloudInfoer.GetCurrentPrices(region)
+}
+
+// breakerStateReporter records the last reported circuit breaker state
+type breakerStateReporter struct {
+	metrics.Reporter
+	state int
+}
+
+func (r *breakerStateReporter) ReportPriceCircuitBreakerState(provider string, state int) {
+	r.state = state
+}
+
+func TestScrapingManager_scrapePricesInRegion_circuitBreaker(t *testing.T) {
+	now := time.Now()
+	infoer := &failingPriceCloudInfoer{fail: 1}
+	store := newTestCloudInfoStore()
+	reporter := &breakerStateReporter{Reporter: metrics.NewNoOpMetricsReporter()}
+
+	sm := NewScrapingManager("dummyProvider", infoer, store, cloudinfoLogger, reporter,
+		tracing.NewNoOpTracer(), messaging.NewDefaultEventBus(nil), &dummyErrorHandler{},
+		ScrapingOptions{RegionConcurrency: 1, BreakerThreshold: 2, BreakerCooldown: time.Minute})
+	sm.priceBreaker.now = func() time.Time { return now }
+
+	for i := 0; i < 3; i++ {
+		sm.scrapePricesInRegion(context.Background(), "dummyRegion1", nil)
+	}
+	assert.Equal(t, int32(2), atomic.LoadInt32(&infoer.calls), "the scrape should be skipped once the breaker is open")
+	assert.Equal(t, int(circuitOpen), reporter.state, "the open state should be reported")
+
+	// the pricing API recovers
+	atomic.StoreInt32(&infoer.fail, 0)
+	sm.scrapePricesInRegion(context.Background(), "dummyRegion1", nil)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&infoer.calls), "the scrape should be skipped during the cooldown")
+
+	now = now.Add(time.Minute)
+	sm.scrapePricesInRegion(context.Background(), "dummyRegion1", nil)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&infoer.calls), "the scrape should be tested after the cooldown")
+	assert.Equal(t, int(circuitClosed), reporter.state, "the closed state should be reported after the recovery")
+
+	_, ok := store.GetPrice("dummyProvider", "dummyRegion1", "dummyType1")
+	assert.True(t, ok, "the price should be stored after the recovery")
+}
+
+// priceAgeReporter records the reported price ages
+type priceAgeReporter struct {
+	metrics.Reporter
+	ages map[string]time.Duration
+}
+
+func (r *priceAgeReporter) ReportPriceAge(provider, region, instanceType string, age time.Duration) {
+	r.ages[fmt.Sprintf("%s/%s/%s", provider, region, instanceType)] = age
+}
+
+func TestScrapingManager_reportPriceAges(t *testing.T) {
+	now := time.Now()
+	store := newTestCloudInfoStore()
+	store.StoreStatus("dummyProvider", fmt.Sprintf("%d", now.Add(-time.Hour).UnixNano()/int64(time.Millisecond)))
+	store.StoreRegions("dummyProvider", "compute", map[string]string{"dummyRegion1": "Dummy Region 1", "dummyRegion2": "Dummy Region 2"})
+	store.StoreVm("dummyProvider", "compute", "dummyRegion1", []types.VMInfo{{Type: "dummyType1"}, {Type: "dummyType2"}})
+	store.StoreVm("dummyProvider", "compute", "dummyRegion2", []types.VMInfo{{Type: "dummyType1"}})
+	store.StorePrice("dummyProvider", "dummyRegion1", "dummyType1", types.Price{OnDemandPrice: 0.1})
+	store.StorePrice("dummyProvider", "dummyRegion2", "dummyType1", types.Price{OnDemandPrice: 0.1})
+
+	reporter := &priceAgeReporter{Reporter: metrics.NewNoOpMetricsReporter(), ages: make(map[string]time.Duration)}
+	sm := newTestScrapingManager(&DummyCloudInfoer{}, store, 1)
+	sm.metrics = reporter
+	sm.priceUpdates["dummyRegion2"] = now.Add(-time.Minute)
+
+	sm.reportPriceAges(now)
+
+	assert.Equal(t, 2, len(reporter.ages), "only the instance types with cached prices should be reported")
+	assert.InDelta(t, time.Hour.Seconds(), reporter.ages["dummyProvider/dummyRegion1/dummyType1"].Seconds(), 0.001,
+		"the age should be based on the provider status")
+	assert.InDelta(t, time.Minute.Seconds(), reporter.ages["dummyProvider/dummyRegion2/dummyType1"].Seconds(), 0.001,
+		"the age should be based on the last short lived price scrape")
+}
+
+func TestScrapingManager_scrapeReservedPricesInRegion(t *testing.T) {
+	store := newTestCloudInfoStore()
+	sm := newTestScrapingManager(&reservedPriceCloudInfoer{}, store, 1)
+
+	sm.scrapeReservedPricesInRegion(context.Background(), "dummyRegion1")
+	sm.scrapePricesInRegion(context.Background(), "dummyRegion1", nil)
+
+	price, ok := store.GetPrice("dummyProvider", "dummyRegion1", "dummyType1")
+	assert.True(t, ok, "the price should be stored")
+	assert.Equal(t, 0.32, price.OnDemandPrice)
+	assert.Equal(t, map[string]float64{"1yr-all-upfront": 0.2, "3yr-no-upfront": 0.15}, price.ReservedPrice,
+		"the reserved prices should be kept when the short lived prices are renewed")
+}
+
+func TestScrapingManager_callTimeout(t *testing.T) {
+	tests := []struct {
+		name        string
+		callTimeout time.Duration
+		checker     func(store *testCloudInfoStore, err error)
+	}{
+		{
+			name:        "the call is given up after the timeout",
+			callTimeout: 10 * time.Millisecond,
+			checker: func(store *testCloudInfoStore, err error) {
+				assert.True(t, errors.Is(err, context.DeadlineExceeded), "the error should be a deadline exceeded error")
+				_, ok := store.GetZones("dummyProvider", "compute", "dummyRegion1")
+				assert.False(t, ok, "the zones should not be stored")
+			},
+		},
+		{
+			name:        "the call completes without timeout",
+			callTimeout: 0,
+			checker: func(store *testCloudInfoStore, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				_, ok := store.GetZones("dummyProvider", "compute", "dummyRegion1")
+				assert.True(t, ok, "the zones should be stored")
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			store := newTestCloudInfoStore()
+			sm := newTestScrapingManager(&slowCloudInfoer{delay: 100 * time.Millisecond}, store, 1)
+			sm.callTimeout = test.callTimeout
+
+			test.checker(store, sm.scrapeServiceRegionZones(context.Background(), "compute", "dummyRegion1"))
+		})
+	}
+}
+
+func TestScrapingManager_scrapePricesInAllRegions(t *testing.T) {
+	store := newTestCloudInfoStore()
+	infoer := &priceServiceCloudInfoer{}
+	sm := newTestScrapingManager(infoer, store, 1)
+
+	sm.scrapePricesInAllRegions(context.Background())
+
+	assert.Equal(t, []string{"vm"}, infoer.services, "the regions of the price region service should be retrieved")
+	for _, region := range []string{"dummyRegion1", "dummyRegion2", "dummyRegion3"} {
+		_, ok := store.GetPrice("dummyProvider", region, "dummyType1")
+		assert.True(t, ok, "the prices should be stored in every region")
+	}
+}
+
+func TestScrapingDriver_ScrapeNow(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider string
+		checker  func(store *testCloudInfoStore, err error)
+	}{
+		{
+			name:     "the provider is scraped",
+			provider: "dummyProvider",
+			checker: func(store *testCloudInfoStore, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				_, ok := store.GetStatus("dummyProvider")
+				assert.True(t, ok, "the status should be updated")
+				vms, ok := store.GetVm("dummyProvider", "compute", "dummyRegion1")
+				assert.True(t, ok, "the vms should be cached")
+				assert.Equal(t, 1, len(vms))
+			},
+		},
+		{
+			name:     "all the providers are scraped when no provider is given",
+			provider: "",
+			checker: func(store *testCloudInfoStore, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				_, ok := store.GetStatus("dummyProvider")
+				assert.True(t, ok, "the status should be updated")
+			},
+		},
+		{
+			name:     "unknown provider",
+			provider: "unknownProvider",
+			checker: func(store *testCloudInfoStore, err error) {
+				assert.EqualError(t, err, "provider is not scraped")
+				_, ok := store.GetStatus("dummyProvider")
+				assert.False(t, ok, "the status should not be updated")
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			store := newTestCloudInfoStore()
+			store.StoreServices("dummyProvider", []types.Service{{Service: "compute"}})
+
+			sd, _ := NewScrapingDriver(time.Hour, map[string]CloudInfoer{"dummyProvider": &DummyCloudInfoer{}}, store,
+				messaging.NewDefaultEventBus(nil), metrics.NewNoOpMetricsReporter(), tracing.NewNoOpTracer(),
+				&dummyErrorHandler{}, cloudinfoLogger)
+
+			test.checker(store, sd.ScrapeNow(context.Background(), test.provider))
+		})
+	}
+}
+
+func TestScrapingDriver_ReloadInfoer(t *testing.T) {
+	store := newTestCloudInfoStore()
+	store.StoreServices("dummyProvider", []types.Service{{Service: "compute"}})
+
+	sd, _ := NewScrapingDriver(time.Hour, map[string]CloudInfoer{"dummyProvider": &DummyCloudInfoer{TcId: failingRegions}}, store,
+		messaging.NewDefaultEventBus(nil), metrics.NewNoOpMetricsReporter(), tracing.NewNoOpTracer(),
+		&dummyErrorHandler{}, cloudinfoLogger)
+
+	assert.NotNil(t, sd.ScrapeNow(context.Background(), "dummyProvider"), "the scrape should fail with the failing infoer")
+
+	assert.Nil(t, sd.ReloadInfoer("dummyProvider", &DummyCloudInfoer{}), "the error should be nil")
+	assert.Nil(t, sd.ScrapeNow(context.Background(), "dummyProvider"), "the scrape should succeed with the reloaded infoer")
+	_, ok := store.GetStatus("dummyProvider")
+	assert.True(t, ok, "the status should be updated")
+
+	assert.EqualError(t, sd.ReloadInfoer("unknownProvider", &DummyCloudInfoer{}), "provider is not scraped")
+	assert.EqualError(t, sd.ReconfigureProvider("dummyProvider", Credentials{"token": "secret"}), "infoer can't be reconfigured")
+}
+
+func TestScrapingDriver_ForEachProvider(t *testing.T) {
+	infoers := map[string]CloudInfoer{"dummyProvider": &DummyCloudInfoer{}, "otherProvider": &DummyCloudInfoer{}}
+	sd, _ := NewScrapingDriver(time.Hour, infoers, newTestCloudInfoStore(), messaging.NewDefaultEventBus(nil),
+		metrics.NewNoOpMetricsReporter(), tracing.NewNoOpTracer(), &dummyErrorHandler{}, cloudinfoLogger)
+
+	visited := make([]string, 0)
+	err := sd.ForEachProvider(func(name string, infoer CloudInfoer) error {
+		assert.Equal(t, infoers[name], infoer, "the infoer of the provider should be passed")
+		visited = append(visited, name)
+		return nil
+	})
+	assert.Nil(t, err, "the error should be nil")
+	assert.Equal(t, []string{"dummyProvider", "otherProvider"}, visited, "every provider should be visited once")
+
+	calls := 0
+	err = sd.ForEachProvider(func(name string, infoer CloudInfoer) error {
+		calls++
+		return errors.New("failed to process provider")
+	})
+	assert.EqualError(t, err, "failed to process provider")
+	assert.Equal(t, 1, calls, "the iteration should stop at the first error")
+}
+
+func TestScrapingDriver_RunOnce(t *testing.T) {
+	store := newTestCloudInfoStore()
+	store.StoreServices("dummyProvider", []types.Service{{Service: "compute"}})
+	store.StoreServices("failingProvider", []types.Service{{Service: "compute"}})
+
+	sd, _ := NewScrapingDriver(time.Hour, map[string]CloudInfoer{
+		"dummyProvider":   &DummyCloudInfoer{},
+		"failingProvider": &DummyCloudInfoer{TcId: failingRegions},
+	}, store, messaging.NewDefaultEventBus(nil), metrics.NewNoOpMetricsReporter(), tracing.NewNoOpTracer(),
+		&dummyErrorHandler{}, cloudinfoLogger)
+
+	err := sd.RunOnce(context.Background())
+
+	assert.EqualError(t, err, "failed to scrape provider failingProvider: failed to retrieve regions")
+	_, ok := store.GetStatus("dummyProvider")
+	assert.True(t, ok, "the status of the succeeding provider should be updated")
+	_, ok = store.GetStatus("failingProvider")
+	assert.False(t, ok, "the status of the failing provider should not be updated")
+}
+
+func TestNewScrapingDriver_infoers(t *testing.T) {
+	tests := []struct {
+		name    string
+		infoers map[string]CloudInfoer
+		checker func(sd *ScrapingDriver, err error)
+	}{
+		{
+			name:    "nil infoer",
+			infoers: map[string]CloudInfoer{"dummyProvider": &DummyCloudInfoer{}, "nilProvider": nil},
+			checker: func(sd *ScrapingDriver, err error) {
+				assert.Nil(t, sd, "the driver should be nil")
+				assert.True(t, errors.Is(err, ErrNoInfoer), "the error should signal the missing infoer")
+				assert.Equal(t, []interface{}{"provider", "nilProvider"}, errors.GetDetails(err))
+			},
+		},
+		{
+			name:    "no infoers",
+			infoers: map[string]CloudInfoer{},
+			checker: func(sd *ScrapingDriver, err error) {
+				assert.Nil(t, sd, "the driver should be nil")
+				assert.True(t, errors.Is(err, ErrNoInfoer), "the error should signal the missing infoer")
+			},
+		},
+		{
+			name:    "valid infoers",
+			infoers: map[string]CloudInfoer{"dummyProvider": &DummyCloudInfoer{}},
+			checker: func(sd *ScrapingDriver, err error) {
+				assert.Nil(t, err, "the error should be nil")
+				assert.Equal(t, 1, len(sd.scrapingManagers))
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.checker(NewScrapingDriver(time.Hour, test.infoers, newTestCloudInfoStore(), messaging.NewDefaultEventBus(nil),
+				metrics.NewNoOpMetricsReporter(), tracing.NewNoOpTracer(), &dummyErrorHandler{}, cloudinfoLogger))
+		})
+	}
+}
+
+func TestScrapingDriver_ScrapeOnceAndReturn(t *testing.T) {
+	store := newTestCloudInfoStore()
+	store.StoreServices("dummyProvider", []types.Service{{Service: "compute"}})
+
+	sd, _ := NewScrapingDriver(time.Hour, map[string]CloudInfoer{"dummyProvider": &DummyCloudInfoer{}}, store,
+		messaging.NewDefaultEventBus(nil), metrics.NewNoOpMetricsReporter(), tracing.NewNoOpTracer(),
+		&dummyErrorHandler{}, cloudinfoLogger)
+
+	done := make(chan error)
+	go func() {
+		done <- sd.ScrapeOnceAndReturn(context.Background())
+	}()
+
+	select {
+	case err := <-done:
+		assert.Nil(t, err, "the error should be nil")
+	case <-time.After(5 * time.Second):
+		t.Fatal("the scrape should return after a single cycle")
+	}
+
+	_, ok := store.GetStatus("dummyProvider")
+	assert.True(t, ok, "the status of the provider should be updated")
+	price, ok := store.GetPrice("dummyProvider", "dummyRegion1", "dummyType1")
+	assert.True(t, ok, "the short lived prices should be scraped")
+	assert.Equal(t, types.SpotPriceInfo{"dummyRegion1a": 0.112}, price.SpotPrice)
+}
+
+func TestScrapingDriver_providerRenewalInterval(t *testing.T) {
+	sd, _ := NewScrapingDriverWithOptions(24*time.Hour, map[string]CloudInfoer{"dummyProvider": &DummyCloudInfoer{}, "otherProvider": &DummyCloudInfoer{}},
+		newTestCloudInfoStore(), messaging.NewDefaultEventBus(nil), metrics.NewNoOpMetricsReporter(), tracing.NewNoOpTracer(),
+		&dummyErrorHandler{}, cloudinfoLogger, ScrapingOptions{RenewalIntervals: map[string]time.Duration{"dummyProvider": 48 * time.Hour}})
+
+	assert.Equal(t, 48*time.Hour, sd.providerRenewalInterval("dummyProvider"))
+	assert.Equal(t, 24*time.Hour, sd.providerRenewalInterval("otherProvider"))
+}
+
+func TestScrapingDriver_shortLivedExecutor(t *testing.T) {
+	tests := []struct {
+		name               string
+		shortLivedInterval time.Duration
+		checker            func(executor Executor)
+	}{
+		{
+			name:               "the configured interval is used",
+			shortLivedInterval: time.Minute,
+			checker: func(executor Executor) {
+				assert.Equal(t, time.Minute, executor.(*PeriodicExecutor).interval)
+			},
+		},
+		{
+			name:               "the default interval is used when not configured",
+			shortLivedInterval: 0,
+			checker: func(executor Executor) {
+				assert.Equal(t, 4*time.Minute, executor.(*PeriodicExecutor).interval)
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sd, _ := NewScrapingDriverWithOptions(time.Hour, map[string]CloudInfoer{"dummyProvider": &DummyCloudInfoer{}},
+				newTestCloudInfoStore(), messaging.NewDefaultEventBus(nil), metrics.NewNoOpMetricsReporter(), tracing.NewNoOpTracer(),
+				&dummyErrorHandler{}, cloudinfoLogger, ScrapingOptions{ShortLivedInterval: test.shortLivedInterval})
+
+			test.checker(sd.shortLivedExecutor())
+		})
+	}
+}
+
+// fixedRunsExecutor runs the task the given number of times before returning
+type fixedRunsExecutor struct {
+	runs int
+}
+
+func (e *fixedRunsExecutor) Execute(ctx context.Context, sf TaskFn) error {
+	for i := 0; i < e.runs; i++ {
+		sf(ctx)
+	}
+
+	return nil
+}
+
+// initializeCountingCloudInfoer counts the initializations, that is the renewals of the provider
+type initializeCountingCloudInfoer struct {
+	DummyCloudInfoer
+
+	initializations int
+}
+
+func (ici *initializeCountingCloudInfoer) Initialize() (map[string]map[string]types.Price, error) {
+	ici.mu.Lock()
+	defer ici.mu.Unlock()
+
+	ici.initializations++
+
+	return nil, nil
+}
+
+func TestScrapingDriver_StartScraping(t *testing.T) {
+	store := newTestCloudInfoStore()
+	store.StoreServices("dummyProvider", []types.Service{{Service: "compute"}})
+	infoer := &initializeCountingCloudInfoer{}
+
+	// the provider renewal is run three times, the short lived prices are not renewed
+	executorFactory := func(period time.Duration, log Logger) Executor {
+		if period == time.Hour {
+			return &fixedRunsExecutor{runs: 3}
+		}
+		return &fixedRunsExecutor{}
+	}
+
+	sd, _ := NewScrapingDriverWithOptions(time.Hour, map[string]CloudInfoer{"dummyProvider": infoer},
+		store, messaging.NewDefaultEventBus(nil), metrics.NewNoOpMetricsReporter(), tracing.NewNoOpTracer(),
+		&dummyErrorHandler{}, cloudinfoLogger, ScrapingOptions{ShortLivedInterval: time.Minute, ExecutorFactory: executorFactory})
+
+	assert.Nil(t, sd.StartScraping(), "the error should be nil")
+	assert.Equal(t, 3, infoer.initializations, "the provider should be renewed once per executor run")
+}
+
+// fakeClock hands out a channel per wait, so that the waits elapse only when the test fires them
+type fakeClock struct {
+	mu      sync.Mutex
+	offsets []time.Duration
+	waits   []chan time.Time
+}
+
+func (c *fakeClock) after(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	wait := make(chan time.Time, 1)
+	c.offsets = append(c.offsets, d)
+	c.waits = append(c.waits, wait)
+
+	return wait
+}
+
+func (c *fakeClock) pending() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.waits)
+}
+
+// startSignalingExecutor signals when the periodic execution is started
+type startSignalingExecutor struct {
+	started chan<- time.Duration
+	period  time.Duration
+}
+
+func (e *startSignalingExecutor) Execute(ctx context.Context, sf TaskFn) error {
+	e.started <- e.period
+	return nil
+}
+
+func TestScrapingDriver_jitter(t *testing.T) {
+	store := newTestCloudInfoStore()
+	infoers := make(map[string]CloudInfoer)
+	for i := 0; i < 3; i++ {
+		infoers[fmt.Sprintf("dummyProvider%d", i)] = &initializeCountingCloudInfoer{}
+	}
+
+	started := make(chan time.Duration, 3)
+	executorFactory := func(period time.Duration, log Logger) Executor {
+		return &startSignalingExecutor{started: started, period: period}
+	}
+
+	sd, _ := NewScrapingDriverWithOptions(time.Hour, infoers, store, messaging.NewDefaultEventBus(nil),
+		metrics.NewNoOpMetricsReporter(), tracing.NewNoOpTracer(), &dummyErrorHandler{}, cloudinfoLogger,
+		ScrapingOptions{ShortLivedInterval: time.Minute, Jitter: 0.5, ExecutorFactory: executorFactory})
+	clock := &fakeClock{}
+	randoms := []float64{0.2, 0.4, 0.6}
+	sd.random = func() float64 {
+		r := randoms[0]
+		randoms = randoms[1:]
+		return r
+	}
+	sd.after = clock.after
+
+	assert.Nil(t, sd.StartScraping(), "the error should be nil")
+	assert.Equal(t, time.Minute, <-started, "the short lived prices should be renewed without offset")
+	assert.Eventually(t, func() bool { return clock.pending() == 3 }, time.Second, time.Millisecond)
+
+	sort.Slice(clock.offsets, func(i, j int) bool { return clock.offsets[i] < clock.offsets[j] })
+	assert.Equal(t, []time.Duration{6 * time.Minute, 12 * time.Minute, 18 * time.Minute}, clock.offsets)
+	assert.Len(t, started, 0, "the periodic renewals shouldn't start before their offset")
+
+	clock.waits[0] <- time.Now()
+	assert.Equal(t, time.Hour, <-started)
+	assert.Len(t, started, 0, "only the renewal with elapsed offset should start")
+}
+
+func TestSkipFirstRun(t *testing.T) {
+	runs := 0
+	task := skipFirstRun(func(ctx context.Context) { runs++ })
+
+	for i := 0; i < 3; i++ {
+		task(context.Background())
+	}
+
+	assert.Equal(t, 2, runs, "the first run should be skipped")
+}
+
+// concurrencyTracker tracks the number of providers scraped in parallel
+type concurrencyTracker struct {
+	mu          sync.Mutex
+	inFlight    int
+	maxInFlight int
+}
+
+// blockingCloudInfoer blocks the initialization of the provider for a while
+type blockingCloudInfoer struct {
+	DummyCloudInfoer
+
+	tracker *concurrencyTracker
+}
+
+func (bci *blockingCloudInfoer) Initialize() (map[string]map[string]types.Price, error) {
+	bci.tracker.mu.Lock()
+	bci.tracker.inFlight++
+	if bci.tracker.inFlight > bci.tracker.maxInFlight {
+		bci.tracker.maxInFlight = bci.tracker.inFlight
+	}
+	bci.tracker.mu.Unlock()
+
+	time.Sleep(20 * time.Millisecond)
+
+	bci.tracker.mu.Lock()
+	bci.tracker.inFlight--
+	bci.tracker.mu.Unlock()
+
+	return nil, nil
+}
+
+func TestScrapingDriver_providerConcurrency(t *testing.T) {
+	tracker := &concurrencyTracker{}
+	store := newTestCloudInfoStore()
+	infoers := make(map[string]CloudInfoer)
+	for i := 0; i < 5; i++ {
+		provider := fmt.Sprintf("dummyProvider%d", i)
+		store.StoreServices(provider, []types.Service{{Service: "compute"}})
+		infoers[provider] = &blockingCloudInfoer{tracker: tracker}
+	}
+
+	sd, _ := NewScrapingDriverWithOptions(time.Hour, infoers, store, messaging.NewDefaultEventBus(nil), metrics.NewNoOpMetricsReporter(),
+		tracing.NewNoOpTracer(), &dummyErrorHandler{}, cloudinfoLogger, ScrapingOptions{ProviderConcurrency: 2})
+
+	assert.Nil(t, sd.RunOnce(context.Background()), "the error should be nil")
+	assert.Equal(t, 2, tracker.maxInFlight, "no more than the configured number of providers should be scraped in parallel")
+}
+
+func TestScrapingManager_storePrices_spotSmoothing(t *testing.T) {
+	store := newTestCloudInfoStore()
+	sm := NewScrapingManager("dummyProvider", &DummyCloudInfoer{}, store, cloudinfoLogger, metrics.NewNoOpMetricsReporter(),
+		tracing.NewNoOpTracer(), messaging.NewDefaultEventBus(nil), &dummyErrorHandler{}, ScrapingOptions{SpotSmoothingFactor: 0.5})
+
+	steps := []struct {
+		spotPrice types.SpotPriceInfo
+		smoothed  types.SpotPriceInfo
+	}{
+		{
+			spotPrice: types.SpotPriceInfo{"dummyZone1": 1.0},
+			smoothed:  types.SpotPriceInfo{"dummyZone1": 1.0},
+		},
+		{
+			spotPrice: types.SpotPriceInfo{"dummyZone1": 0.5, "dummyZone2": 0.4},
+			smoothed:  types.SpotPriceInfo{"dummyZone1": 0.75, "dummyZone2": 0.4},
+		},
+		{
+			spotPrice: types.SpotPriceInfo{"dummyZone1": 0.8, "dummyZone2": 0.2},
+			smoothed:  types.SpotPriceInfo{"dummyZone1": 0.775, "dummyZone2": 0.3},
+		},
+	}
+	for i, step := range steps {
+		sm.storePrices("dummyRegion1", map[string]types.Price{"dummyType1": {OnDemandPrice: 1.2, SpotPrice: step.spotPrice}})
+
+		price, ok := store.GetPrice("dummyProvider", "dummyRegion1", "dummyType1")
+		assert.True(t, ok, "the price should be cached")
+		assert.Equal(t, step.spotPrice, price.SpotPrice, "the raw spot price should be kept in step %d", i)
+		assert.Equal(t, len(step.smoothed), len(price.SmoothedSpotPrice))
+		for zone, avg := range step.smoothed {
+			assert.InDelta(t, avg, price.SmoothedSpotPrice[zone], 1e-9, "unexpected moving average in step %d", i)
+		}
+	}
+
+	store.StoreVm("dummyProvider", "compute", "dummyRegion1", []types.VMInfo{{Type: "dummyType1", OnDemandPrice: 1.2}})
+	info, _ := NewCloudInfo([]string{"dummyProvider"}, store, cloudinfoLogger)
+	details, err := info.GetProductDetails("dummyProvider", "compute", "dummyRegion1")
+	assert.Nil(t, err, "the error should be nil")
+	assert.ElementsMatch(t, []types.ZonePrice{{Zone: "dummyZone1", Price: 0.8}, {Zone: "dummyZone2", Price: 0.2}}, details[0].SpotPrice)
+	assert.Equal(t, 2, len(details[0].SpotPriceSmoothed), "the smoothed spot prices should be exposed")
+
+	unsmoothed := newTestScrapingManager(&DummyCloudInfoer{}, store, 1)
+	unsmoothed.storePrices("dummyRegion2", map[string]types.Price{"dummyType1": {SpotPrice: types.SpotPriceInfo{"dummyZone1": 1.0}}})
+	price, _ := store.GetPrice("dummyProvider", "dummyRegion2", "dummyType1")
+	assert.Nil(t, price.SmoothedSpotPrice, "the spot prices should not be smoothed if smoothing is disabled")
+}
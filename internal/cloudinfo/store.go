@@ -24,6 +24,9 @@ const (
 	// vmKeyTemplate format for generating vm cache keys
 	VmKeyTemplate = "/banzaicloud.com/cloudinfo/providers/%s/services/%s/regions/%s/vms"
 
+	// productsKeyTemplate format for generating region products cache keys
+	ProductsKeyTemplate = "/banzaicloud.com/cloudinfo/providers/%s/services/%s/regions/%s/products"
+
 	// priceKeyTemplate format for generating price cache keys
 	PriceKeyTemplate = "/banzaicloud.com/cloudinfo/providers/%s/regions/%s/prices/%s"
 
@@ -44,6 +47,15 @@ const (
 
 	// servicesKeyTemplate key for storing provider specific services
 	ServicesKeyTemplate = "/banzaicloud.com/cloudinfo/providers/%s/services"
+
+	// scrapeErrorsKeyTemplate format for generating scrape failure cache keys
+	ScrapeErrorsKeyTemplate = "/banzaicloud.com/cloudinfo/providers/%s/scrapeerrors"
+
+	// spotPriceHistoryKeyTemplate format for generating spot price history cache keys
+	SpotPriceHistoryKeyTemplate = "/banzaicloud.com/cloudinfo/providers/%s/regions/%s/spotpricehistory/%s"
+
+	// catalogChangesKeyTemplate format for generating catalog change cache keys
+	CatalogChangesKeyTemplate = "/banzaicloud.com/cloudinfo/providers/%s/services/%s/regions/%s/catalogchanges"
 )
 
 // Storage operations for cloud information
@@ -59,12 +71,25 @@ type CloudInfoStore interface {
 	DeleteZones(provider, service, region string)
 
 	StorePrice(provider, region, instanceType string, val types.Price)
+	// StorePrices stores the prices of the instance types in the region in a single write
+	StorePrices(provider, region string, prices map[string]types.Price)
 	GetPrice(provider, region, instanceType string) (types.Price, bool)
 
+	StoreSpotPriceHistory(provider, region, instanceType string, val []types.TimestampedPrice)
+	GetSpotPriceHistory(provider, region, instanceType string) ([]types.TimestampedPrice, bool)
+
 	StoreVm(provider, service, region string, val []types.VMInfo)
 	GetVm(provider, service, region string) ([]types.VMInfo, bool)
 	DeleteVm(provider, service, region string)
 
+	// StoreRegionProducts stores the VMs along with their prices in a single entry
+	StoreRegionProducts(provider, service, region string, val types.RegionProducts)
+	GetRegionProducts(provider, service, region string) (types.RegionProducts, bool)
+
+	// StoreCatalogChanges stores the instance types added and removed by the latest scrape of the region
+	StoreCatalogChanges(provider, service, region string, val types.CatalogChanges)
+	GetCatalogChanges(provider, service, region string) (types.CatalogChanges, bool)
+
 	StoreImage(provider, service, regionId string, val []types.Image)
 	GetImage(provider, service, regionId string) ([]types.Image, bool)
 	DeleteImage(provider, service, regionId string)
@@ -79,6 +104,12 @@ type CloudInfoStore interface {
 	StoreServices(provider string, services []types.Service)
 	GetServices(provider string) ([]types.Service, bool)
 
+	StoreScrapeErrors(provider string, val []types.ScrapeError)
+	GetScrapeErrors(provider string) ([]types.ScrapeError, bool)
+
+	// Keys lists the keys of the stored entries starting with the prefix, the ordering of the keys is unspecified
+	Keys(prefix string) []string
+
 	Export(w io.Writer) error
 	Import(r io.Reader) error
 
@@ -25,6 +25,9 @@ const (
 
 	// Cpu represents the cpu attribute for the product info
 	CPU = "cpu"
+
+	// GPU represents the gpu count attribute for the product info
+	GPU = "gpu"
 )
 
 // CloudInfo is the main entry point for retrieving vm type characteristics and pricing information on different cloud providers
@@ -82,6 +85,11 @@ const (
 	ContinentAfrica       = "Africa"
 	ContinentAsia         = "Asia"
 	ContinentAustralia    = "Australia"
+
+	// ArchX86_64 the 64 bit x86 cpu architecture
+	ArchX86_64 = "x86_64"
+	// ArchArm64 the 64 bit arm cpu architecture
+	ArchArm64 = "arm64"
 )
 
 // NetworkPerfMapper operations related  to mapping between virtual machines to network performance categories
@@ -123,6 +131,9 @@ func NewLocationVersion(location string, versions []string, def string) Location
 	}
 }
 
+// HoursPerMonth the number of hours in a month used for cost projections (365 * 24 / 12)
+const HoursPerMonth = 730
+
 // ProductDetails extended view of the virtual machine details
 type ProductDetails struct {
 	// Embedded struct!
@@ -130,6 +141,26 @@ type ProductDetails struct {
 
 	// Burst this is derived for now
 	Burst bool `json:"burst,omitempty"`
+
+	// ZonePrices holds the prices of the product per availability zone
+	// for providers without per zone on demand prices every zone repeats the regional on demand price
+	ZonePrices map[string]Price `json:"zonePrices,omitempty"`
+
+	// ReservedPrice holds the hourly reserved (committed use) prices of the product keyed by term
+	ReservedPrice map[string]float64 `json:"reservedPrice,omitempty"`
+
+	// PricePerCpu the hourly on demand price of a cpu of the product, zero if the product reports no cpus
+	PricePerCpu float64 `json:"pricePerCpu"`
+
+	// PricePerGB the hourly on demand price of a GB of memory of the product, zero if the product reports no memory
+	PricePerGB float64 `json:"pricePerGB"`
+
+	// PriceAvailable is set if the price of the product is cached, otherwise the prices of the product are not known (rather than zero)
+	PriceAvailable bool `json:"priceAvailable"`
+
+	// SpotPriceSmoothed the exponential moving average of the spot prices per zone, empty if the spot prices are not smoothed
+	// the SpotPrice field holds the latest (raw) spot prices
+	SpotPriceSmoothed []ZonePrice `json:"spotPriceSmoothed,omitempty"`
 }
 
 // ProductDetailSource product details related set of operations
@@ -143,9 +174,136 @@ func NewProductDetails(vm VMInfo) *ProductDetails {
 	pd := ProductDetails{}
 	pd.VMInfo = vm
 	pd.Burst = vm.IsBurst()
+	// fall back to the instance type naming if the provider doesn't report burstable instances
+	pd.Burstable = vm.Burstable || vm.IsBurst()
+	pd.PricePerCpu = unitPrice(vm.OnDemandPrice, vm.Cpus)
+	pd.PricePerGB = unitPrice(vm.OnDemandPrice, vm.Mem)
 	return &pd
 }
 
+// PricePerNormalizedUnit returns the on demand price of a normalized unit of the product, zero if the units are unknown
+func (pd ProductDetails) PricePerNormalizedUnit() float64 {
+	return unitPrice(pd.OnDemandPrice, pd.NormalizedUnits)
+}
+
+// unitPrice divides the price by the number of units, zero is returned if there are no units
+func unitPrice(price, units float64) float64 {
+	if units <= 0 {
+		return 0
+	}
+
+	return price / units
+}
+
+// SpotSavings returns the savings of the cheapest spot zone price compared to the on demand price in percent
+// zero is returned if there's no spot price or on demand price information
+func (pd ProductDetails) SpotSavings() float64 {
+	if pd.OnDemandPrice <= 0 || len(pd.SpotPrice) == 0 {
+		return 0
+	}
+
+	return (pd.OnDemandPrice - pd.CheapestSpotPrice()) / pd.OnDemandPrice * 100
+}
+
+// CheapestSpotPrice returns the cheapest spot price of the product across the zones
+// zero is returned if there's no spot price information
+func (pd ProductDetails) CheapestSpotPrice() float64 {
+	min, _, _, _ := pd.SpotStats()
+	return min
+}
+
+// SpotStats summarizes the spot prices of the product across the zones
+// the minimum, average and maximum prices are returned along with the number of zones, zeros if there's no spot price information
+func (pd ProductDetails) SpotStats() (min, avg, max float64, zones int) {
+	if len(pd.SpotPrice) == 0 {
+		return 0, 0, 0, 0
+	}
+
+	min, max = pd.SpotPrice[0].Price, pd.SpotPrice[0].Price
+	var sum float64
+	for _, zonePrice := range pd.SpotPrice {
+		if zonePrice.Price < min {
+			min = zonePrice.Price
+		}
+		if zonePrice.Price > max {
+			max = zonePrice.Price
+		}
+		sum += zonePrice.Price
+	}
+
+	return min, sum / float64(len(pd.SpotPrice)), max, len(pd.SpotPrice)
+}
+
+// MonthlyOnDemandCost returns the cost of running the product on demand for a month of HoursPerMonth hours
+func (pd ProductDetails) MonthlyOnDemandCost() float64 {
+	return pd.OnDemandPrice * HoursPerMonth
+}
+
+// CpuPerDollar returns the number of cpus the product provides for an hourly dollar of its on demand price
+// zero is returned if there's no on demand price information
+func (pd ProductDetails) CpuPerDollar() float64 {
+	if pd.OnDemandPrice <= 0 {
+		return 0
+	}
+
+	return pd.Cpus / pd.OnDemandPrice
+}
+
+// MemPerDollar returns the memory the product provides for an hourly dollar of its on demand price
+// zero is returned if there's no on demand price information
+func (pd ProductDetails) MemPerDollar() float64 {
+	if pd.OnDemandPrice <= 0 {
+		return 0
+	}
+
+	return pd.Mem / pd.OnDemandPrice
+}
+
+// RegionProducts the products of a service in a region along with their prices
+// the products and the prices are stored and retrieved together, so readers never observe a partially updated region
+type RegionProducts struct {
+	Vms    []VMInfo         `json:"vms"`
+	Prices map[string]Price `json:"prices"`
+}
+
+// CatalogChanges the instance types added to and removed from the catalog of a service in a region by the latest scrape
+type CatalogChanges struct {
+	Added   []string  `json:"added"`
+	Removed []string  `json:"removed"`
+	Time    time.Time `json:"time"`
+}
+
+// ScrapeError the last failure of scraping a service in a region
+type ScrapeError struct {
+	Service string    `json:"service"`
+	Region  string    `json:"region"`
+	Message string    `json:"message"`
+	Time    time.Time `json:"time"`
+}
+
+// TimestampedPrice a price valid at the given time
+type TimestampedPrice struct {
+	Time  time.Time `json:"time"`
+	Price float64   `json:"price"`
+}
+
+// PriceChange holds the previous and the current price of an instance type
+type PriceChange struct {
+	OldOnDemandPrice float64       `json:"oldOnDemandPrice"`
+	NewOnDemandPrice float64       `json:"newOnDemandPrice"`
+	OldSpotPrice     SpotPriceInfo `json:"oldSpotPrice"`
+	NewSpotPrice     SpotPriceInfo `json:"newSpotPrice"`
+}
+
+// PriceChangeEvent the price change of an instance type in a region detected by a scrape
+type PriceChangeEvent struct {
+	Provider     string `json:"provider"`
+	Region       string `json:"region"`
+	InstanceType string `json:"instanceType"`
+	PriceChange
+	Time time.Time `json:"time"`
+}
+
 // ServiceDescriber represents a service; eg.: oke, eks
 // Extend this interface with other operations if needed
 type ServiceDescriber interface {
@@ -158,6 +316,8 @@ type ServiceDescriber interface {
 type Service struct {
 	Service  string `json:"service"`
 	IsStatic bool   `json:"isStatic"`
+	// Label the human readable name of the service (eg.: Elastic Kubernetes Service for eks)
+	Label string `json:"displayName,omitempty"`
 }
 
 // ServiceName returns the service name
@@ -165,6 +325,56 @@ func (s Service) ServiceName() string {
 	return s.Service
 }
 
+// DisplayName returns the human readable name of the service, the service name if it has no label
+func (s Service) DisplayName() string {
+	if s.Label != "" {
+		return s.Label
+	}
+
+	return s.Service
+}
+
+// serviceDisplayNames holds the human readable names of the services per provider
+// nolint: gochecknoglobals
+var serviceDisplayNames = map[string]map[string]string{
+	"amazon": {
+		"compute": "Elastic Compute Cloud",
+		"eks":     "Elastic Kubernetes Service",
+		"pke":     "Banzai Cloud Pipeline Kubernetes Engine",
+	},
+	"google": {
+		"compute": "Compute Engine",
+		"gke":     "Google Kubernetes Engine",
+	},
+	"azure": {
+		"compute": "Virtual Machines",
+		"aks":     "Azure Kubernetes Service",
+		"pke":     "Banzai Cloud Pipeline Kubernetes Engine",
+	},
+	"alibaba": {
+		"compute": "Elastic Compute Service",
+		"ack":     "Alibaba Cloud Container Service for Kubernetes",
+	},
+	"oracle": {
+		"compute": "Oracle Cloud Infrastructure Compute",
+		"oke":     "Oracle Container Engine for Kubernetes",
+	},
+	"digitalocean": {
+		"compute": "Droplets",
+		"dok":     "DigitalOcean Kubernetes",
+	},
+}
+
+// GetServiceDisplayName returns the human readable name of the service of the provider
+// services missing from the registry get their name as display name
+func GetServiceDisplayName(provider, service string) string {
+	if name, ok := serviceDisplayNames[provider][service]; ok {
+		return name
+	}
+
+	return service
+}
+
 // ProviderDescriber describes a provider
 type ProviderDescriber interface {
 	// ProviderName returns the name of the provider
@@ -173,8 +383,60 @@ type ProviderDescriber interface {
 
 // Provider represents a cloud provider
 type Provider struct {
-	Provider string    `json:"provider"`
-	Services []Service `json:"services"`
+	Provider string           `json:"provider"`
+	Services []Service        `json:"services"`
+	Metadata ProviderMetadata `json:"metadata"`
+}
+
+// ProviderMetadata holds the presentational information of a provider
+type ProviderMetadata struct {
+	DisplayName      string `json:"displayName"`
+	IconKey          string `json:"iconKey,omitempty"`
+	DocumentationURL string `json:"documentationUrl,omitempty"`
+}
+
+// nolint: gochecknoglobals
+var providerMetadata = map[string]ProviderMetadata{
+	"amazon": {
+		DisplayName:      "Amazon Web Services",
+		IconKey:          "aws",
+		DocumentationURL: "https://aws.amazon.com/ec2/instance-types/",
+	},
+	"google": {
+		DisplayName:      "Google Cloud",
+		IconKey:          "gcp",
+		DocumentationURL: "https://cloud.google.com/compute/docs/machine-types",
+	},
+	"azure": {
+		DisplayName:      "Microsoft Azure",
+		IconKey:          "azure",
+		DocumentationURL: "https://docs.microsoft.com/en-us/azure/virtual-machines/sizes",
+	},
+	"alibaba": {
+		DisplayName:      "Alibaba Cloud",
+		IconKey:          "alibaba",
+		DocumentationURL: "https://www.alibabacloud.com/help/doc-detail/25378.htm",
+	},
+	"oracle": {
+		DisplayName:      "Oracle",
+		IconKey:          "oracle",
+		DocumentationURL: "https://docs.cloud.oracle.com/iaas/Content/Compute/References/computeshapes.htm",
+	},
+	"digitalocean": {
+		DisplayName:      "DigitalOcean",
+		IconKey:          "digitalocean",
+		DocumentationURL: "https://www.digitalocean.com/docs/droplets/",
+	},
+}
+
+// GetProviderMetadata returns the metadata of the provider
+// providers missing from the registry get their code as display name
+func GetProviderMetadata(name string) ProviderMetadata {
+	if metadata, ok := providerMetadata[name]; ok {
+		return metadata
+	}
+
+	return ProviderMetadata{DisplayName: name}
 }
 
 // ProviderName returns the name of the provider
@@ -186,6 +448,7 @@ func (p Provider) ProviderName() string {
 func NewProvider(name string) Provider {
 	return Provider{
 		Provider: name,
+		Metadata: GetProviderMetadata(name),
 	}
 }
 
@@ -196,6 +459,10 @@ type Image struct {
 	Version      string            `json:"version,omitempty"`
 	GpuAvailable bool              `json:"gpu,omitempty"`
 	Tags         map[string]string `json:"tags,omitempty"`
+	// Os the operating system of the image (eg.: ubuntu), empty if unknown
+	Os string `json:"os,omitempty"`
+	// OsVersion the version of the operating system (eg.: 20.04), empty if unknown
+	OsVersion string `json:"osVersion,omitempty"`
 }
 
 // NewImage create new provider describer struct
@@ -223,30 +490,96 @@ type Region struct {
 	Name string `json:"name"`
 }
 
+// RegionInfo the geographic location of a region
+type RegionInfo struct {
+	ID        string  `json:"id"`
+	Name      string  `json:"name"`
+	Continent string  `json:"continent"`
+	Lat       float64 `json:"lat"`
+	Lng       float64 `json:"lng"`
+}
+
 // SpotPriceInfo represents different prices per availability zones
 type SpotPriceInfo map[string]float64
 
+// Merge returns the union of the spot prices, the prices of the zones present in both are taken from the newer ones
+func (spi SpotPriceInfo) Merge(newer SpotPriceInfo) SpotPriceInfo {
+	if len(spi) == 0 {
+		return newer
+	}
+	if len(newer) == 0 {
+		return spi
+	}
+
+	merged := make(SpotPriceInfo, len(spi)+len(newer))
+	for zone, price := range spi {
+		merged[zone] = price
+	}
+	for zone, price := range newer {
+		merged[zone] = price
+	}
+
+	return merged
+}
+
 // Price describes the on demand price and spot prices per availability zones
 type Price struct {
 	OnDemandPrice float64       `json:"onDemandPrice"`
 	SpotPrice     SpotPriceInfo `json:"spotPrice"`
+	// ReservedPrice holds the hourly reserved (committed use) prices keyed by term, eg.: 1yr-all-upfront, 3yr-no-upfront
+	ReservedPrice map[string]float64 `json:"reservedPrice,omitempty"`
+	// SmoothedSpotPrice holds the exponential moving average of the spot prices per availability zone, set only if smoothing is enabled
+	SmoothedSpotPrice SpotPriceInfo `json:"smoothedSpotPrice,omitempty"`
+}
+
+// Merge combines the newer price into the price; the spot prices are merged zone by zone,
+// the on demand and reserved prices are replaced only if the newer price has them
+func (p Price) Merge(newer Price) Price {
+	merged := Price{
+		OnDemandPrice:     p.OnDemandPrice,
+		SpotPrice:         p.SpotPrice.Merge(newer.SpotPrice),
+		ReservedPrice:     p.ReservedPrice,
+		SmoothedSpotPrice: p.SmoothedSpotPrice.Merge(newer.SmoothedSpotPrice),
+	}
+	if newer.OnDemandPrice > 0 {
+		merged.OnDemandPrice = newer.OnDemandPrice
+	}
+	if len(newer.ReservedPrice) > 0 {
+		merged.ReservedPrice = newer.ReservedPrice
+	}
+
+	return merged
 }
 
 // VMInfo representation of a virtual machine
 type VMInfo struct {
-	Category      string            `json:"category"`
-	Type          string            `json:"type"`
-	OnDemandPrice float64           `json:"onDemandPrice"`
-	SpotPrice     []ZonePrice       `json:"spotPrice"`
-	Cpus          float64           `json:"cpusPerVm"`
-	Mem           float64           `json:"memPerVm"`
-	Gpus          float64           `json:"gpusPerVm"`
-	NtwPerf       string            `json:"ntwPerf"`
-	NtwPerfCat    string            `json:"ntwPerfCategory"`
-	Zones         []string          `json:"zones"`
-	Attributes    map[string]string `json:"attributes"`
+	Category      string      `json:"category"`
+	Type          string      `json:"type"`
+	OnDemandPrice float64     `json:"onDemandPrice"`
+	SpotPrice     []ZonePrice `json:"spotPrice"`
+	Cpus          float64     `json:"cpusPerVm"`
+	Mem           float64     `json:"memPerVm"`
+	Gpus          float64     `json:"gpusPerVm"`
+	NtwPerf       string      `json:"ntwPerf"`
+	NtwPerfCat    string      `json:"ntwPerfCategory"`
+	// NtwGbps the network bandwidth in Gbps parsed from the network performance, zero if it can't be determined
+	NtwGbps    float64           `json:"ntwGbps"`
+	Zones      []string          `json:"zones"`
+	Attributes map[string]string `json:"attributes"`
 	// CurrentGen signals whether the instance type generation is the current one. Only applies for amazon
 	CurrentGen bool `json:"currentGen"`
+	// Architecture the cpu architecture of the instance type (x86_64, arm64), empty if not reported by the provider
+	Architecture string `json:"architecture,omitempty"`
+	// Burstable signals that the instance type accumulates cpu credits and bursts above its baseline
+	Burstable bool `json:"burstable,omitempty"`
+	// BaselinePerf the baseline cpu utilization of burstable instance types per vCPU in percent
+	BaselinePerf float64 `json:"baselinePerf,omitempty"`
+	// InstanceStorageGB the total size of the local (instance store) disks, zero if the instance type has none
+	InstanceStorageGB float64 `json:"instanceStorageGB,omitempty"`
+	// InstanceStorageType the type of the local disks (eg.: NVMe SSD, SSD, HDD)
+	InstanceStorageType string `json:"instanceStorageType,omitempty"`
+	// NormalizedUnits the capacity of the instance type in normalized units (*.large = 4), zero if unknown
+	NormalizedUnits float64 `json:"normalizedUnits,omitempty"`
 }
 
 // IsBurst returns true if the EC2 instance vCPU is burst type
@@ -254,3 +587,8 @@ type VMInfo struct {
 func (vm VMInfo) IsBurst() bool {
 	return strings.HasPrefix(strings.ToUpper(vm.Type), "T")
 }
+
+// HasGpu returns true if the instance has GPUs attached
+func (vm VMInfo) HasGpu() bool {
+	return vm.Gpus > 0
+}
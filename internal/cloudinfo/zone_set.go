@@ -0,0 +1,36 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudinfo
+
+// ZoneSet is a set of availability zones for repeated membership checks
+// prefer it to Contains when checking many zones against the same zone list
+type ZoneSet map[string]struct{}
+
+// NewZoneSet creates a set of the given zones
+func NewZoneSet(zones []string) ZoneSet {
+	set := make(ZoneSet, len(zones))
+	for _, zone := range zones {
+		set[zone] = struct{}{}
+	}
+
+	return set
+}
+
+// Has checks whether the zone is in the set
+func (zs ZoneSet) Has(zone string) bool {
+	_, ok := zs[zone]
+
+	return ok
+}
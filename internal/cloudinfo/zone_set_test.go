@@ -0,0 +1,63 @@
+// Copyright © 2019 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudinfo
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestZoneSet_Has(t *testing.T) {
+	set := NewZoneSet([]string{"dummyZone1", "dummyZone2"})
+
+	assert.True(t, set.Has("dummyZone1"))
+	assert.True(t, set.Has("dummyZone2"))
+	assert.False(t, set.Has("dummyZone3"))
+	assert.False(t, NewZoneSet(nil).Has("dummyZone1"), "the empty set should have no zones")
+}
+
+func benchmarkZones() []string {
+	zones := make([]string, 0, 30)
+	for i := 0; i < 30; i++ {
+		zones = append(zones, fmt.Sprintf("dummyRegion-%da", i))
+	}
+
+	return zones
+}
+
+func BenchmarkContains(b *testing.B) {
+	zones := benchmarkZones()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, zone := range zones {
+			Contains(zones, zone)
+		}
+	}
+}
+
+func BenchmarkZoneSet_Has(b *testing.B) {
+	zones := benchmarkZones()
+	set := NewZoneSet(zones)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, zone := range zones {
+			set.Has(zone)
+		}
+	}
+}
@@ -16,6 +16,7 @@ package redis
 
 import (
 	"fmt"
+	"time"
 
 	"emperror.dev/errors"
 )
@@ -31,6 +32,9 @@ type Config struct {
 	// Password list supports passing multiple passwords making password changes easier
 	Password []string
 
+	// Expiration is the time to live of the stored entries (zero means no expiration)
+	Expiration time.Duration
+
 	Enabled bool
 }
 